@@ -32,6 +32,10 @@ func main() {
 
 	srv := application.HTTPServer
 
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	application.RunJobs(jobsCtx)
+
 	go func() {
 		logger.Info("http server starting", app.ZapString("addr", cfg.HTTP.Addr))
 		if err := srv.ListenAndServe(); err != nil {
@@ -51,5 +55,9 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("shutdown failed", app.ZapErr(err))
 	}
+	// задачи останавливаем после сервера, чтобы финальный сброс счётчиков
+	// захватил последние запросы
+	stopJobs()
+	application.WaitJobs()
 	logger.Info("bye")
 }
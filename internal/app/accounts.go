@@ -0,0 +1,20 @@
+package app
+
+import (
+	"context"
+
+	userdomain "autera/internal/modules/users/domain"
+)
+
+// adsAccounts отдаёт модулю ads сведения об аккаунтах из users.
+type adsAccounts struct {
+	repo userdomain.Repository
+}
+
+func (a adsAccounts) IsCompany(ctx context.Context, userID int64) (bool, error) {
+	u, err := a.repo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return u.Type == "company", nil
+}
@@ -2,15 +2,27 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
 	"net/http"
+	"sync"
 
 	transport "autera/internal/transport/http"
+	"autera/internal/transport/http/middleware"
 
 	adsapp "autera/internal/modules/ads/application"
+	adsdomain "autera/internal/modules/ads/domain"
 	adsinfra "autera/internal/modules/ads/infrastructure"
 	adstr "autera/internal/modules/ads/transport/http"
 
+	catalogapp "autera/internal/modules/catalog/application"
+	cataloginfra "autera/internal/modules/catalog/infrastructure"
+	catalogtr "autera/internal/modules/catalog/transport/http"
+
+	convapp "autera/internal/modules/conversations/application"
+	convinfra "autera/internal/modules/conversations/infrastructure"
+	convtr "autera/internal/modules/conversations/transport/http"
+
 	insapp "autera/internal/modules/inspections/application"
 	insinfra "autera/internal/modules/inspections/infrastructure"
 	instr "autera/internal/modules/inspections/transport/http"
@@ -24,6 +36,8 @@ import (
 	usertr "autera/internal/modules/users/transport/http"
 
 	"autera/pkg/auth"
+	"autera/pkg/events"
+	"autera/pkg/storage"
 
 	"go.uber.org/zap"
 )
@@ -31,6 +45,10 @@ import (
 type Application struct {
 	DB         *sql.DB
 	HTTPServer *http.Server
+
+	logger *zap.Logger
+	jobs   []Job
+	jobsWG sync.WaitGroup
 }
 
 func New(ctx context.Context, cfg *Config, logger *zap.Logger) (*Application, error) {
@@ -50,13 +68,68 @@ func New(ctx context.Context, cfg *Config, logger *zap.Logger) (*Application, er
 		TTLMin: cfg.JWT.TTLMin,
 	})
 
+	// доменные события между модулями
+	bus := events.NewBus()
+
 	// Users
 	usersRepo := userinfra.NewPostgresRepo(db)
 	usersSvc := userapp.NewService(usersRepo, jwtSvc)
 
+	// Media
+	media, mediaHandler, err := storage.NewMediaStorage(storage.Config{
+		Driver:   cfg.Media.Driver,
+		LocalDir: cfg.Media.LocalDir,
+		BaseURL:  cfg.Media.BaseURL,
+		S3: storage.S3Config{
+			Endpoint:  cfg.Media.S3.Endpoint,
+			Region:    cfg.Media.S3.Region,
+			Bucket:    cfg.Media.S3.Bucket,
+			AccessKey: cfg.Media.S3.AccessKey,
+			SecretKey: cfg.Media.S3.SecretKey,
+			PublicURL: cfg.Media.S3.PublicURL,
+		},
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	// Catalog
+	catalogRepo := cataloginfra.NewPostgresRepo(db)
+	catalogSvc := catalogapp.NewService(catalogRepo)
+
 	// Ads
 	adsRepo := adsinfra.NewPostgresRepo(db)
-	adsSvc := adsapp.NewService(adsRepo)
+	adsSvc := adsapp.NewService(adsRepo, media, adsCatalog{svc: catalogSvc}, adsAccounts{repo: usersRepo}, bus, adsapp.RulesConfig{
+		AutoApprove:      cfg.Moderation.AutoApprove,
+		RejectRules:      cfg.Moderation.RejectRules,
+		DisabledRules:    cfg.Moderation.DisabledRules,
+		BannedWords:      cfg.Moderation.BannedWords,
+		MinYear:          cfg.Moderation.MinYear,
+		MaxAdsPerDay:     cfg.Moderation.MaxAdsPerDay,
+		PriceMedianRatio: cfg.Moderation.PriceMedianRatio,
+		MinMedianSample:  cfg.Moderation.MinMedianSample,
+
+		ComplaintThreshold: cfg.Moderation.ComplaintThreshold,
+	}, adsapp.SimilarConfig{
+		Query: adsdomain.SimilarQuery{
+			PriceSpread: cfg.Similar.PriceSpread,
+			YearSpread:  cfg.Similar.YearSpread,
+			Candidates:  cfg.Similar.Candidates,
+		},
+		Weights: adsdomain.SimilarWeights{
+			SameModel: cfg.Similar.WeightSameModel,
+			SameCity:  cfg.Similar.WeightSameCity,
+			Inspected: cfg.Similar.WeightInspected,
+			Price:     cfg.Similar.WeightPrice,
+			Year:      cfg.Similar.WeightYear,
+			Mileage:   cfg.Similar.WeightMileage,
+		},
+	})
+
+	// Conversations
+	convRepo := convinfra.NewPostgresRepo(db)
+	convSvc := convapp.NewService(convRepo, conversationsAds{repo: adsRepo})
 
 	// Inspections
 	insRepo := insinfra.NewPostgresRepo(db)
@@ -66,20 +139,102 @@ func New(ctx context.Context, cfg *Config, logger *zap.Logger) (*Application, er
 	repRepo := repinfra.NewPostgresRepo(db)
 	repSvc := repapp.NewService(repRepo)
 
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	deviceKey := []byte(cfg.Ads.DeviceSecret)
+	if len(deviceKey) == 0 {
+		// без ключа из конфига дедупликация счётчиков сбрасывается при рестарте
+		deviceKey = make([]byte, 32)
+		if _, err := rand.Read(deviceKey); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		logger.Warn("ads.device_secret is not set, using a random key")
+	}
+
 	router := transport.NewRouter(transport.RouterDeps{
 		Logger: logger,
 		JWT:    jwtSvc,
 
-		UsersHandler: usertr.NewHandler(usersSvc),
-		AdsHandler:   adstr.NewHandler(adsSvc),
-		InsHandler:   instr.NewHandler(insSvc),
-		RepHandler:   reptr.NewHandler(repSvc),
+		TrustedProxies: trustedProxies,
+
+		MediaHandler: mediaHandler,
+
+		UsersHandler:   usertr.NewHandler(usersSvc),
+		AdsHandler:     adstr.NewHandler(adsSvc, cfg.Site.BaseURL, deviceKey),
+		CatalogHandler: catalogtr.NewHandler(catalogSvc),
+		ConvHandler:    convtr.NewHandler(convSvc),
+		InsHandler:     instr.NewHandler(insSvc),
+		RepHandler:     reptr.NewHandler(repSvc),
 	})
 
 	srv := NewHTTPServer(cfg.HTTP.Addr, router)
 
+	jobs := []Job{
+		{
+			Name:     "ads_expiry",
+			Interval: cfg.Ads.ExpiryInterval,
+			Run: func(ctx context.Context) error {
+				ids, err := adsSvc.ExpireOld(ctx, cfg.Ads.PublicationPeriod)
+				if len(ids) > 0 {
+					logger.Info("ads expired", zap.Int("count", len(ids)))
+				}
+				return err
+			},
+		},
+		{
+			Name:     "ads_stats_flush",
+			Interval: cfg.Ads.StatsFlushInterval,
+			Final:    true,
+			Run: func(ctx context.Context) error {
+				_, err := adsSvc.FlushStats(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "dealer_feed_sync",
+			Interval: cfg.Ads.FeedSyncInterval,
+			Run: func(ctx context.Context) error {
+				n, err := adsSvc.SyncFeeds(ctx)
+				if n > 0 {
+					logger.Info("dealer feed syncs queued", zap.Int("count", n))
+				}
+				return err
+			},
+		},
+		{
+			Name:     "dealer_feed_imports",
+			Interval: cfg.Ads.FeedImportInterval,
+			Run: func(ctx context.Context) error {
+				n, err := adsSvc.ProcessFeedImports(ctx)
+				if n > 0 {
+					logger.Info("dealer feed imports processed", zap.Int("count", n))
+				}
+				return err
+			},
+		},
+		{
+			Name:     "saved_search_matcher",
+			Interval: cfg.Ads.MatchInterval,
+			Run: func(ctx context.Context) error {
+				n, err := adsSvc.MatchSavedSearches(ctx)
+				if n > 0 {
+					logger.Info("saved search notifications queued", zap.Int("count", n))
+				}
+				return err
+			},
+		},
+	}
+
 	return &Application{
 		DB:         db,
 		HTTPServer: srv,
+
+		logger: logger,
+		jobs:   jobs,
 	}, nil
 }
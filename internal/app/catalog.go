@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+
+	adsdomain "autera/internal/modules/ads/domain"
+	catalogapp "autera/internal/modules/catalog/application"
+	catalogdomain "autera/internal/modules/catalog/domain"
+)
+
+// adsCatalog отдаёт модулю ads справочник catalog, не связывая модули напрямую.
+type adsCatalog struct {
+	svc *catalogapp.Service
+}
+
+func (c adsCatalog) Resolve(ctx context.Context, brand, model string, year int) (adsdomain.CatalogMatch, error) {
+	var out adsdomain.CatalogMatch
+	m, err := c.svc.Resolve(ctx, brand, model, year)
+	if err != nil {
+		return out, err
+	}
+	if m.Brand != nil {
+		out.BrandID, out.Brand = &m.Brand.ID, m.Brand.Name
+	}
+	if m.Model != nil {
+		out.ModelID, out.Model = &m.Model.ID, m.Model.Name
+	}
+	if m.Generation != nil {
+		out.GenerationID = &m.Generation.ID
+	}
+	return out, nil
+}
+
+func (c adsCatalog) BodyType(ctx context.Context, text string) (string, error) {
+	ref, err := c.svc.ResolveRef(ctx, catalogdomain.RefBodyType, text)
+	if err != nil || ref == nil {
+		return "", err
+	}
+	return ref.Code, nil
+}
@@ -13,9 +13,17 @@ type (
 		Env string `mapstructure:"env"`
 	}
 
+	// Site — публичный адрес сайта (ссылки в выгрузке объявлений и sitemap).
+	Site struct {
+		BaseURL string `mapstructure:"base_url"`
+	}
+
 	HTTP struct {
 		Addr    string        `mapstructure:"addr"`
 		Timeout time.Duration `mapstructure:"timeout"`
+
+		// прокси, от которых принимаем X-Forwarded-For / X-Real-IP: подсети или адреса
+		TrustedProxies []string `mapstructure:"trusted_proxies"`
 	}
 
 	DB struct {
@@ -36,14 +44,73 @@ type (
 	Migrations struct {
 		URL string `mapstructure:"url"`
 	}
+
+	Ads struct {
+		PublicationPeriod  time.Duration `mapstructure:"publication_period"`
+		ExpiryInterval     time.Duration `mapstructure:"expiry_interval"`
+		MatchInterval      time.Duration `mapstructure:"match_interval"`       // матчер сохранённых поисков
+		StatsFlushInterval time.Duration `mapstructure:"stats_flush_interval"` // сброс счётчиков просмотров
+		FeedSyncInterval   time.Duration `mapstructure:"feed_sync_interval"`   // синхронизация фидов дилеров
+		FeedImportInterval time.Duration `mapstructure:"feed_import_interval"` // очередь загрузок фидов
+
+		DeviceSecret string `mapstructure:"device_secret"` // ключ HMAC устройств в счётчиках
+	}
+
+	Moderation struct {
+		AutoApprove      bool     `mapstructure:"auto_approve"`
+		RejectRules      []string `mapstructure:"reject_rules"`
+		DisabledRules    []string `mapstructure:"disabled_rules"`
+		BannedWords      []string `mapstructure:"banned_words"`
+		MinYear          int      `mapstructure:"min_year"`
+		MaxAdsPerDay     int      `mapstructure:"max_ads_per_day"`
+		PriceMedianRatio float64  `mapstructure:"price_median_ratio"`
+		MinMedianSample  int      `mapstructure:"min_median_sample"`
+
+		ComplaintThreshold int `mapstructure:"complaint_threshold"` // жалоб до возврата на модерацию
+	}
+
+	// Similar — отбор и веса рекомендаций «похожие объявления».
+	Similar struct {
+		PriceSpread float64 `mapstructure:"price_spread"`
+		YearSpread  int     `mapstructure:"year_spread"`
+		Candidates  int     `mapstructure:"candidates"`
+
+		WeightSameModel float64 `mapstructure:"weight_same_model"`
+		WeightSameCity  float64 `mapstructure:"weight_same_city"`
+		WeightInspected float64 `mapstructure:"weight_inspected"`
+		WeightPrice     float64 `mapstructure:"weight_price"`
+		WeightYear      float64 `mapstructure:"weight_year"`
+		WeightMileage   float64 `mapstructure:"weight_mileage"`
+	}
+
+	S3 struct {
+		Endpoint  string `mapstructure:"endpoint"`
+		Region    string `mapstructure:"region"`
+		Bucket    string `mapstructure:"bucket"`
+		AccessKey string `mapstructure:"access_key"`
+		SecretKey string `mapstructure:"secret_key"`
+		PublicURL string `mapstructure:"public_url"`
+	}
+
+	Media struct {
+		Driver   string `mapstructure:"driver"` // local / s3
+		LocalDir string `mapstructure:"local_dir"`
+		BaseURL  string `mapstructure:"base_url"`
+		S3       S3     `mapstructure:"s3"`
+	}
 )
 
 type Config struct {
 	App        App        `mapstructure:"app"`
 	HTTP       HTTP       `mapstructure:"http"`
+	Site       Site       `mapstructure:"site"`
 	DB         DB         `mapstructure:"db"`
 	JWT        JWT        `mapstructure:"jwt"`
 	Migrations Migrations `mapstructure:"migrations"`
+	Media      Media      `mapstructure:"media"`
+	Ads        Ads        `mapstructure:"ads"`
+	Moderation Moderation `mapstructure:"moderation"`
+	Similar    Similar    `mapstructure:"similar"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -54,6 +121,9 @@ func LoadConfig() (*Config, error) {
 
 	v.SetDefault("http.addr", ":8080")
 	v.SetDefault("http.timeout", "60s") // строкой, чтобы viper смог распарсить
+	v.SetDefault("http.trusted_proxies", []string{})
+
+	v.SetDefault("site.base_url", "http://localhost:8080")
 
 	v.SetDefault("db.host", "localhost")
 	v.SetDefault("db.port", "5432")
@@ -67,6 +137,45 @@ func LoadConfig() (*Config, error) {
 
 	v.SetDefault("migrations.url", "file://migrations")
 
+	v.SetDefault("ads.publication_period", "720h") // 30 дней
+	v.SetDefault("ads.expiry_interval", "1h")
+	v.SetDefault("ads.match_interval", "1m")
+	v.SetDefault("ads.stats_flush_interval", "10s")
+	v.SetDefault("ads.feed_sync_interval", "1h")
+	v.SetDefault("ads.feed_import_interval", "5s")
+	v.SetDefault("ads.device_secret", "")
+
+	// премодерация: по умолчанию только помечает, отклоняет лишь явные нарушения
+	v.SetDefault("moderation.auto_approve", false)
+	v.SetDefault("moderation.reject_rules", []string{"year_range", "banned_words"})
+	v.SetDefault("moderation.disabled_rules", []string{})
+	v.SetDefault("moderation.banned_words", []string{})
+	v.SetDefault("moderation.min_year", 1950)
+	v.SetDefault("moderation.max_ads_per_day", 10)
+	v.SetDefault("moderation.price_median_ratio", 0.5)
+	v.SetDefault("moderation.min_median_sample", 5)
+	v.SetDefault("moderation.complaint_threshold", 3)
+
+	v.SetDefault("similar.price_spread", 0.3)
+	v.SetDefault("similar.year_spread", 3)
+	v.SetDefault("similar.candidates", 100)
+	v.SetDefault("similar.weight_same_model", 3)
+	v.SetDefault("similar.weight_same_city", 1)
+	v.SetDefault("similar.weight_inspected", 1)
+	v.SetDefault("similar.weight_price", 2)
+	v.SetDefault("similar.weight_year", 1)
+	v.SetDefault("similar.weight_mileage", 1)
+
+	v.SetDefault("media.driver", "local")
+	v.SetDefault("media.local_dir", "./data/media")
+	v.SetDefault("media.base_url", "/media")
+	v.SetDefault("media.s3.endpoint", "")
+	v.SetDefault("media.s3.region", "us-east-1")
+	v.SetDefault("media.s3.bucket", "")
+	v.SetDefault("media.s3.access_key", "")
+	v.SetDefault("media.s3.secret_key", "")
+	v.SetDefault("media.s3.public_url", "")
+
 	// env: APP_ENV -> app.env и т.п.
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
@@ -85,6 +194,23 @@ func LoadConfig() (*Config, error) {
 		}
 		cfg.HTTP.Timeout = d
 	}
+	for key, dst := range map[string]*time.Duration{
+		"ads.publication_period":   &cfg.Ads.PublicationPeriod,
+		"ads.expiry_interval":      &cfg.Ads.ExpiryInterval,
+		"ads.match_interval":       &cfg.Ads.MatchInterval,
+		"ads.stats_flush_interval": &cfg.Ads.StatsFlushInterval,
+		"ads.feed_sync_interval":   &cfg.Ads.FeedSyncInterval,
+		"ads.feed_import_interval": &cfg.Ads.FeedImportInterval,
+	} {
+		if *dst != 0 {
+			continue
+		}
+		d, err := time.ParseDuration(v.GetString(key))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s", key)
+		}
+		*dst = d
+	}
 
 	return &cfg, nil
 }
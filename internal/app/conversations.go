@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+
+	adsdomain "autera/internal/modules/ads/domain"
+	convdomain "autera/internal/modules/conversations/domain"
+)
+
+// conversationsAds отдаёт модулю conversations сведения об объявлениях из ads.
+type conversationsAds struct {
+	repo adsdomain.Repository
+}
+
+func (a conversationsAds) AdRef(ctx context.Context, adID int64) (convdomain.AdRef, error) {
+	ad, err := a.repo.Get(ctx, adID)
+	if err != nil {
+		return convdomain.AdRef{}, err
+	}
+	return convdomain.AdRef{
+		ID:        ad.ID,
+		SellerID:  ad.SellerID,
+		Published: ad.Status == adsdomain.AdPublished,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job — периодическая фоновая задача.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+	Final    bool // выполнить ещё раз при остановке (сброс буферов)
+}
+
+const finalRunTimeout = 5 * time.Second
+
+// RunJobs запускает задачи в отдельных горутинах и возвращается сразу;
+// задачи останавливаются по отмене ctx.
+func (a *Application) RunJobs(ctx context.Context) {
+	for _, j := range a.jobs {
+		a.jobsWG.Add(1)
+		go func() {
+			defer a.jobsWG.Done()
+			runJob(ctx, a.logger, j)
+		}()
+	}
+}
+
+// WaitJobs ждёт завершения задач после отмены ctx, включая финальные запуски.
+func (a *Application) WaitJobs() {
+	a.jobsWG.Wait()
+}
+
+func runJob(ctx context.Context, logger *zap.Logger, j Job) {
+	t := time.NewTicker(j.Interval)
+	defer t.Stop()
+
+	for {
+		if err := runOnce(ctx, j); err != nil && ctx.Err() == nil {
+			logger.Error("job failed", zap.String("job", j.Name), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			if j.Final {
+				fctx, cancel := context.WithTimeout(context.Background(), finalRunTimeout)
+				if err := runOnce(fctx, j); err != nil {
+					logger.Error("job final run failed", zap.String("job", j.Name), zap.Error(err))
+				}
+				cancel()
+			}
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// runOnce — один запуск задачи; паника превращается в ошибку, чтобы сбой
+// одной задачи не ронял процесс.
+func runOnce(ctx context.Context, j Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v\n%s", p, debug.Stack())
+		}
+	}()
+	return j.Run(ctx)
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"slices"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// Catalog — справочник марок/моделей/поколений (модуль catalog), подключается при сборке.
+type Catalog interface {
+	Resolve(ctx context.Context, brand, model string, year int) (domain.CatalogMatch, error)
+	// BodyType возвращает код типа кузова по коду, названию или синониму; "" — не найден.
+	BodyType(ctx context.Context, text string) (string, error)
+}
+
+// applyCatalog привязывает объявление к справочнику и приводит марку/модель
+// к каноническому написанию. Несопоставленный текст сохраняется как есть.
+func (s *Service) applyCatalog(ctx context.Context, ad *domain.Ad) error {
+	ad.BrandID, ad.ModelID, ad.GenerationID = nil, nil, nil
+	if s.catalog == nil {
+		return nil
+	}
+	m, err := s.catalog.Resolve(ctx, ad.Brand, ad.Model, ad.Year)
+	if err != nil {
+		return err
+	}
+	ad.BrandID, ad.ModelID, ad.GenerationID = m.BrandID, m.ModelID, m.GenerationID
+	if m.Brand != "" {
+		ad.Brand = m.Brand
+	}
+	if m.Model != "" {
+		ad.Model = m.Model
+	}
+	return nil
+}
+
+// resolveFilter переводит текстовый фильтр марки в id справочника, чтобы
+// "BMW", "bmw" и "БМВ" находили одно и то же; типы кузова — в коды справочника
+// (неизвестный — ErrUnknownBodyType).
+func (s *Service) resolveFilter(ctx context.Context, f *domain.ListFilter) error {
+	if s.catalog == nil {
+		return nil
+	}
+	if len(f.BodyTypes) > 0 {
+		codes := make([]string, 0, len(f.BodyTypes))
+		for _, body := range f.BodyTypes {
+			code, err := s.catalog.BodyType(ctx, body)
+			if err != nil {
+				return err
+			}
+			if code == "" {
+				return domain.ErrUnknownBodyType
+			}
+			if !slices.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+		f.BodyTypes = codes
+	}
+	if f.Brand == "" || f.BrandID != nil {
+		return nil
+	}
+	m, err := s.catalog.Resolve(ctx, f.Brand, "", 0)
+	if err != nil {
+		return err
+	}
+	if m.BrandID != nil {
+		f.BrandID = m.BrandID
+		f.Brand = m.Brand
+	}
+	return nil
+}
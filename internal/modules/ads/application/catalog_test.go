@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// fakeCatalog знает одну марку с русским синонимом и один тип кузова.
+type fakeCatalog struct{}
+
+func (fakeCatalog) Resolve(_ context.Context, brand, model string, _ int) (domain.CatalogMatch, error) {
+	var m domain.CatalogMatch
+	switch strings.ToLower(brand) {
+	case "bmw", "бмв":
+		id := int64(1)
+		m.BrandID, m.Brand = &id, "BMW"
+	}
+	if m.BrandID != nil && strings.EqualFold(model, "x5") {
+		id := int64(10)
+		m.ModelID, m.Model = &id, "X5"
+	}
+	return m, nil
+}
+
+func (fakeCatalog) BodyType(_ context.Context, text string) (string, error) {
+	if strings.EqualFold(text, "внедорожник") || strings.EqualFold(text, "suv") {
+		return "suv", nil
+	}
+	return "", nil
+}
+
+func TestNewAdCatalogBeforeVIN(t *testing.T) {
+	s := &Service{catalog: fakeCatalog{}}
+
+	tests := []struct {
+		name      string
+		in        CreateAdInput
+		wantBrand string
+		wantModel bool
+	}{
+		{
+			name:      "alias brand with matching vin",
+			in:        CreateAdInput{Brand: "БМВ", Model: "x5", Year: 2011, Price: 1, VIN: "WBAPH5C55BA123456"},
+			wantBrand: "BMW",
+			wantModel: true,
+		},
+		{
+			name:      "brand prefilled from vin is resolved",
+			in:        CreateAdInput{Model: "X5", Year: 2011, Price: 1, VIN: "wbaph5c55ba123456"},
+			wantBrand: "BMW",
+			wantModel: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ad, err := s.newAd(context.Background(), tt.in)
+			if err != nil {
+				t.Fatalf("newAd: %v", err)
+			}
+			if ad.Brand != tt.wantBrand || ad.BrandID == nil {
+				t.Fatalf("brand = %q (id %v), want %q", ad.Brand, ad.BrandID, tt.wantBrand)
+			}
+			if (ad.ModelID != nil) != tt.wantModel {
+				t.Fatalf("model id = %v, want set %v", ad.ModelID, tt.wantModel)
+			}
+			if ad.VIN != "WBAPH5C55BA123456" {
+				t.Fatalf("vin = %q", ad.VIN)
+			}
+			if msg := vinMismatch(ad.VIN, ad.Brand, ad.Year); msg != "" {
+				t.Fatalf("unexpected mismatch: %s", msg)
+			}
+		})
+	}
+}
+
+func TestResolveFilterBodyTypes(t *testing.T) {
+	s := &Service{catalog: fakeCatalog{}}
+
+	f := domain.ListFilter{Brand: "бмв", BodyTypes: []string{"внедорожник", "suv"}}
+	if err := s.resolveFilter(context.Background(), &f); err != nil {
+		t.Fatalf("resolveFilter: %v", err)
+	}
+	if len(f.BodyTypes) != 1 || f.BodyTypes[0] != "suv" {
+		t.Fatalf("body types = %v, want [suv]", f.BodyTypes)
+	}
+	if f.BrandID == nil || f.Brand != "BMW" {
+		t.Fatalf("brand = %q (id %v)", f.Brand, f.BrandID)
+	}
+
+	f = domain.ListFilter{BodyTypes: []string{"танк"}}
+	if err := s.resolveFilter(context.Background(), &f); !errors.Is(err, domain.ErrUnknownBodyType) {
+		t.Fatalf("err = %v, want ErrUnknownBodyType", err)
+	}
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"autera/internal/modules/ads/domain"
+)
+
+type ComplaintInput struct {
+	Reason  string `json:"reason"`
+	Comment string `json:"comment"`
+}
+
+type ResolveComplaintsInput struct {
+	Outcome string `json:"outcome"` // dismissed / confirmed
+	Reason  string `json:"reason"`  // причина отклонения из каталога, обязательна для confirmed
+	Comment string `json:"comment"`
+}
+
+// FileComplaint принимает жалобу на опубликованное объявление. Когда число
+// жалоб разных пользователей достигает порога, объявление снимается с витрины
+// и возвращается на модерацию.
+func (s *Service) FileComplaint(ctx context.Context, adID, userID int64, in ComplaintInput) (*domain.Complaint, error) {
+	c := &domain.Complaint{
+		AdID:    adID,
+		UserID:  userID,
+		Reason:  domain.ComplaintReason(in.Reason),
+		Comment: strings.TrimSpace(in.Comment),
+	}
+	if _, ok := domain.ComplaintReasons[c.Reason]; !ok {
+		return nil, errors.New("unknown complaint reason")
+	}
+	if c.Reason == domain.ComplaintOther && c.Comment == "" {
+		return nil, errors.New("comment required for reason other")
+	}
+	if utf8.RuneCountInString(c.Comment) > domain.MaxComplaintComment {
+		return nil, errors.New("comment is too long")
+	}
+
+	ad, err := s.repo.Get(ctx, adID)
+	if err != nil {
+		return nil, err
+	}
+	if ad.Status != domain.AdPublished {
+		return nil, errors.New("only published ads can be reported")
+	}
+	if ad.SellerID == userID {
+		return nil, errors.New("cannot report your own ad")
+	}
+
+	open, err := s.repo.AddComplaint(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if s.rules.ComplaintThreshold > 0 && open >= int64(s.rules.ComplaintThreshold) {
+		// best effort: жалоба уже сохранена, а при параллельной смене статуса
+		// объявление и так ушло с витрины
+		_ = s.transition(ctx, ad, domain.AdModeration)
+	}
+	return c, nil
+}
+
+func (s *Service) ComplaintQueue(ctx context.Context, limit, offset int) ([]domain.ComplaintGroup, int64, error) {
+	return s.repo.ComplaintQueue(ctx, limit, offset)
+}
+
+func (s *Service) AdComplaints(ctx context.Context, adID int64) ([]domain.Complaint, error) {
+	return s.repo.OpenComplaints(ctx, adID)
+}
+
+// ResolveComplaints закрывает все нерешённые жалобы по объявлению. Подтверждённые
+// идут в историю продавца, а активное объявление отклоняется с причиной из каталога —
+// в одной транзакции с закрытием жалоб.
+func (s *Service) ResolveComplaints(ctx context.Context, adID, adminID int64, in ResolveComplaintsInput) (int64, error) {
+	outcome := domain.ComplaintOutcome(in.Outcome)
+	if outcome != domain.ComplaintDismissed && outcome != domain.ComplaintConfirmed {
+		return 0, errors.New("outcome must be dismissed or confirmed")
+	}
+
+	var reject *domain.ModerationDecision
+	if outcome == domain.ComplaintConfirmed {
+		reject = &domain.ModerationDecision{
+			AdID:        adID,
+			ModeratorID: adminID,
+			Decision:    domain.DecisionReject,
+			Reason:      domain.RejectionReason(in.Reason),
+			Comment:     strings.TrimSpace(in.Comment),
+		}
+		if err := validateRejection(reject); err != nil {
+			return 0, err
+		}
+		ad, err := s.repo.Get(ctx, adID)
+		if err != nil {
+			return 0, err
+		}
+		switch ad.Status {
+		case domain.AdPublished, domain.AdModeration:
+			// снятие идёт через модерацию: published → moderation → rejected
+		default:
+			reject = nil // объявление уже неактивно, закрываем только жалобы
+		}
+	}
+
+	n, err := s.repo.ResolveComplaints(ctx, adID, adminID, outcome, reject)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, errors.New("no open complaints for this ad")
+	}
+	return n, nil
+}
@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// complaintsRepo — объявление в памяти и заданное число нерешённых жалоб.
+type complaintsRepo struct {
+	domain.Repository
+	ad          domain.Ad
+	open        int64
+	transitions []domain.AdStatus
+	resolved    bool
+	reject      *domain.ModerationDecision
+}
+
+func (r *complaintsRepo) Get(_ context.Context, _ int64) (*domain.Ad, error) {
+	ad := r.ad
+	return &ad, nil
+}
+
+func (r *complaintsRepo) AddComplaint(_ context.Context, c *domain.Complaint) (int64, error) {
+	c.SellerID = r.ad.SellerID
+	r.open++
+	return r.open, nil
+}
+
+func (r *complaintsRepo) Transition(_ context.Context, _ int64, _, to domain.AdStatus) error {
+	r.transitions = append(r.transitions, to)
+	r.ad.Status = to
+	return nil
+}
+
+func (r *complaintsRepo) ResolveComplaints(_ context.Context, _, _ int64, _ domain.ComplaintOutcome, reject *domain.ModerationDecision) (int64, error) {
+	r.resolved, r.reject = true, reject
+	n := r.open
+	r.open = 0
+	return n, nil
+}
+
+func TestFileComplaintThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		open      int64
+		want      bool
+	}{
+		{name: "below threshold", threshold: 3, open: 1, want: false},
+		{name: "reaches threshold", threshold: 3, open: 2, want: true},
+		{name: "threshold disabled", threshold: 0, open: 10, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &complaintsRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished}, open: tt.open}
+			s := &Service{repo: repo, rules: RulesConfig{ComplaintThreshold: tt.threshold}}
+
+			if _, err := s.FileComplaint(context.Background(), 1, 8, ComplaintInput{Reason: string(domain.ComplaintFraud)}); err != nil {
+				t.Fatalf("FileComplaint: %v", err)
+			}
+			got := len(repo.transitions) == 1 && repo.transitions[0] == domain.AdModeration
+			if got != tt.want {
+				t.Fatalf("moved to moderation = %v, want %v (transitions %v)", got, tt.want, repo.transitions)
+			}
+		})
+	}
+}
+
+func TestFileComplaintValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.AdStatus
+		user   int64
+		in     ComplaintInput
+	}{
+		{name: "unknown reason", status: domain.AdPublished, user: 8, in: ComplaintInput{Reason: "boring"}},
+		{name: "other without comment", status: domain.AdPublished, user: 8, in: ComplaintInput{Reason: string(domain.ComplaintOther)}},
+		{name: "not published", status: domain.AdModeration, user: 8, in: ComplaintInput{Reason: string(domain.ComplaintFraud)}},
+		{name: "own ad", status: domain.AdPublished, user: 7, in: ComplaintInput{Reason: string(domain.ComplaintFraud)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &complaintsRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: tt.status}}
+			s := &Service{repo: repo, rules: RulesConfig{ComplaintThreshold: 1}}
+			if _, err := s.FileComplaint(context.Background(), 1, tt.user, tt.in); err == nil {
+				t.Fatal("expected error")
+			}
+			if repo.open != 0 {
+				t.Fatal("complaint must not be saved")
+			}
+		})
+	}
+}
+
+func TestResolveComplaints(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     domain.AdStatus
+		in         ResolveComplaintsInput
+		wantErr    bool
+		wantReject bool
+	}{
+		{name: "dismiss", status: domain.AdModeration, in: ResolveComplaintsInput{Outcome: "dismissed"}},
+		{name: "confirm published", status: domain.AdPublished, in: ResolveComplaintsInput{Outcome: "confirmed", Reason: string(domain.ReasonWrongPrice)}, wantReject: true},
+		{name: "confirm in moderation", status: domain.AdModeration, in: ResolveComplaintsInput{Outcome: "confirmed", Reason: string(domain.ReasonWrongPrice)}, wantReject: true},
+		{name: "confirm sold ad closes complaints only", status: domain.AdSold, in: ResolveComplaintsInput{Outcome: "confirmed", Reason: string(domain.ReasonWrongPrice)}},
+		{name: "confirm without reason", status: domain.AdPublished, in: ResolveComplaintsInput{Outcome: "confirmed"}, wantErr: true},
+		{name: "confirm other without comment", status: domain.AdPublished, in: ResolveComplaintsInput{Outcome: "confirmed", Reason: string(domain.ReasonOther)}, wantErr: true},
+		{name: "unknown outcome", status: domain.AdPublished, in: ResolveComplaintsInput{Outcome: "maybe"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &complaintsRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: tt.status}, open: 2}
+			s := &Service{repo: repo}
+
+			n, err := s.ResolveComplaints(context.Background(), 1, 99, tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				// ошибка валидации не должна ни закрывать жалобы, ни трогать объявление
+				if repo.resolved || len(repo.transitions) > 0 {
+					t.Fatal("repository must not be touched")
+				}
+				return
+			}
+			if n != 2 {
+				t.Fatalf("resolved = %d, want 2", n)
+			}
+			if (repo.reject != nil) != tt.wantReject {
+				t.Fatalf("reject = %+v, want %v", repo.reject, tt.wantReject)
+			}
+			if repo.reject != nil && (repo.reject.ModeratorID != 99 || repo.reject.Decision != domain.DecisionReject) {
+				t.Fatalf("reject = %+v", repo.reject)
+			}
+		})
+	}
+}
+
+func TestResolveComplaintsNothingOpen(t *testing.T) {
+	repo := &complaintsRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished}}
+	s := &Service{repo: repo}
+	if _, err := s.ResolveComplaints(context.Background(), 1, 99, ResolveComplaintsInput{Outcome: "dismissed"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
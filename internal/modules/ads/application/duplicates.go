@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+)
+
+type ResolveDuplicateInput struct {
+	Action      string `json:"action"`       // merge / reject / dismiss
+	DuplicateOf int64  `json:"duplicate_of"` // обязателен для merge и dismiss
+	Comment     string `json:"comment"`
+}
+
+// detectDuplicates пересчитывает подозрения на дубли и возвращает их число.
+func (s *Service) detectDuplicates(ctx context.Context, adID int64) (int, error) {
+	dups, err := s.repo.FindDuplicates(ctx, adID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.repo.SaveDuplicates(ctx, adID, dups); err != nil {
+		return 0, err
+	}
+	return len(dups), nil
+}
+
+// ResolveDuplicate — действие модератора над подозрением на дубль:
+//   - merge: повторная подача продавцом своего же автомобиля, объявление уходит в архив;
+//   - reject: отклонение с причиной «дубль»;
+//   - dismiss: ложное срабатывание, объявление остаётся в очереди.
+func (s *Service) ResolveDuplicate(ctx context.Context, adID, moderatorID int64, in ResolveDuplicateInput) error {
+	if in.Action != "reject" && in.DuplicateOf <= 0 {
+		return errors.New("duplicate_of required")
+	}
+
+	var pair *domain.Duplicate
+	if in.DuplicateOf > 0 {
+		byAd, err := s.repo.DuplicatesByAds(ctx, []int64{adID})
+		if err != nil {
+			return err
+		}
+		for _, d := range byAd[adID] {
+			if d.DuplicateOf == in.DuplicateOf {
+				pair = &d
+				break
+			}
+		}
+		if pair == nil {
+			return errors.New("no pending duplicate for this pair")
+		}
+	}
+
+	comment := strings.TrimSpace(in.Comment)
+	switch in.Action {
+	case "merge":
+		if !pair.SameSeller {
+			return errors.New("merge is only allowed for ads of the same seller")
+		}
+		if comment == "" {
+			comment = fmt.Sprintf("Объединено с объявлением #%d", in.DuplicateOf)
+		}
+		if err := s.repo.Decide(ctx, &domain.ModerationDecision{
+			AdID:        adID,
+			ModeratorID: moderatorID,
+			Decision:    domain.DecisionMerge,
+			Reason:      domain.ReasonDuplicate,
+			Comment:     comment,
+		}, domain.AdArchived); err != nil {
+			return err
+		}
+		_, err := s.repo.ResolveDuplicates(ctx, adID, 0, moderatorID, domain.DuplicateMerged)
+		return err
+	case "reject":
+		if _, err := s.Moderate(ctx, adID, moderatorID, ModerateInput{
+			Decision: string(domain.DecisionReject),
+			Reason:   string(domain.ReasonDuplicate),
+			Comment:  comment,
+		}); err != nil {
+			return err
+		}
+		_, err := s.repo.ResolveDuplicates(ctx, adID, 0, moderatorID, domain.DuplicateRejected)
+		return err
+	case "dismiss":
+		_, err := s.repo.ResolveDuplicates(ctx, adID, in.DuplicateOf, moderatorID, domain.DuplicateDismissed)
+		return err
+	default:
+		return errors.New("action must be merge, reject or dismiss")
+	}
+}
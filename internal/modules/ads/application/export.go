@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+var ErrSitemapSegmentNotFound = errors.New("sitemap segment not found")
+
+// EachPublished обходит опубликованные объявления пачками (с фото) и вызывает fn
+// для каждого; ошибка fn (например, отключился клиент) прерывает обход.
+func (s *Service) EachPublished(ctx context.Context, fn func(*domain.Ad) error) error {
+	var after int64
+	for {
+		items, err := s.repo.PublishedAfter(ctx, after, domain.ExportBatch)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		ptrs := make([]*domain.Ad, 0, len(items))
+		for i := range items {
+			ptrs = append(ptrs, &items[i])
+		}
+		if err := s.attachPhotos(ctx, ptrs); err != nil {
+			return err
+		}
+		for _, ad := range ptrs {
+			if err := fn(ad); err != nil {
+				return err
+			}
+		}
+		after = items[len(items)-1].ID
+	}
+}
+
+// sitemapIndexTTL — сколько отдаём закэшированный индекс, не пересчитывая сводки.
+const sitemapIndexTTL = time.Minute
+
+// sitemapCache хранит записи сегментов; сегмент перечитывается, только если
+// изменилась его сводка (число объявлений или последнее изменение). Индекс
+// сегментов кэшируется на sitemapIndexTTL. Под mu — только работа с картой,
+// запросы к базе идут без блокировки.
+type sitemapCache struct {
+	mu       sync.Mutex
+	segments map[int64]cachedSegment
+	index    []domain.SitemapSegment
+	indexAt  time.Time
+}
+
+type cachedSegment struct {
+	summary domain.SitemapSegment
+	entries []domain.SitemapEntry
+}
+
+func newSitemapCache() *sitemapCache {
+	return &sitemapCache{segments: make(map[int64]cachedSegment)}
+}
+
+// SitemapIndex — сводки непустых сегментов; по ним строится sitemap index.
+func (s *Service) SitemapIndex(ctx context.Context) ([]domain.SitemapSegment, error) {
+	s.sitemap.mu.Lock()
+	if s.sitemap.index != nil && time.Since(s.sitemap.indexAt) < sitemapIndexTTL {
+		index := s.sitemap.index
+		s.sitemap.mu.Unlock()
+		return index, nil
+	}
+	s.sitemap.mu.Unlock()
+
+	index, err := s.repo.SitemapSegments(ctx, domain.SitemapSegmentSize)
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		index = []domain.SitemapSegment{}
+	}
+	s.sitemap.mu.Lock()
+	s.sitemap.index, s.sitemap.indexAt = index, time.Now()
+	s.sitemap.mu.Unlock()
+	return index, nil
+}
+
+// SitemapSegment возвращает сводку и записи сегмента, из кэша если он не менялся.
+func (s *Service) SitemapSegment(ctx context.Context, index int64) (domain.SitemapSegment, []domain.SitemapEntry, error) {
+	summary, err := s.repo.SitemapSegment(ctx, index, domain.SitemapSegmentSize)
+	if err != nil {
+		return domain.SitemapSegment{}, nil, err
+	}
+
+	s.sitemap.mu.Lock()
+	if summary.Count == 0 {
+		delete(s.sitemap.segments, index)
+		s.sitemap.mu.Unlock()
+		return domain.SitemapSegment{}, nil, ErrSitemapSegmentNotFound
+	}
+	c, ok := s.sitemap.segments[index]
+	s.sitemap.mu.Unlock()
+	if ok && c.summary.Count == summary.Count && c.summary.LastMod.Equal(summary.LastMod) {
+		return c.summary, c.entries, nil
+	}
+
+	// параллельные запросы могут перечитать сегмент дважды — это дешевле,
+	// чем держать блокировку всех сегментов на время запроса
+	entries, err := s.repo.SitemapEntries(ctx, index, domain.SitemapSegmentSize)
+	if err != nil {
+		return domain.SitemapSegment{}, nil, err
+	}
+	s.sitemap.mu.Lock()
+	s.sitemap.segments[index] = cachedSegment{summary: summary, entries: entries}
+	s.sitemap.mu.Unlock()
+	return summary, entries, nil
+}
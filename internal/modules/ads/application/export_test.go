@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// sitemapRepo считает обращения к базе; summary — текущая сводка сегмента.
+type sitemapRepo struct {
+	domain.Repository
+	summary      domain.SitemapSegment
+	indexCalls   int
+	entriesCalls int
+	onEntries    func()
+}
+
+func (r *sitemapRepo) SitemapSegments(_ context.Context, _ int64) ([]domain.SitemapSegment, error) {
+	r.indexCalls++
+	return []domain.SitemapSegment{r.summary}, nil
+}
+
+func (r *sitemapRepo) SitemapSegment(_ context.Context, _, _ int64) (domain.SitemapSegment, error) {
+	return r.summary, nil
+}
+
+func (r *sitemapRepo) SitemapEntries(_ context.Context, _, _ int64) ([]domain.SitemapEntry, error) {
+	r.entriesCalls++
+	if r.onEntries != nil {
+		r.onEntries()
+	}
+	return []domain.SitemapEntry{{AdID: 1, LastMod: r.summary.LastMod}}, nil
+}
+
+func TestSitemapSegmentCache(t *testing.T) {
+	now := time.Now()
+	repo := &sitemapRepo{summary: domain.SitemapSegment{Index: 0, Count: 1, LastMod: now}}
+	s := &Service{repo: repo, sitemap: newSitemapCache()}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := s.SitemapSegment(ctx, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if repo.entriesCalls != 1 {
+		t.Fatalf("entries read %d times, want 1", repo.entriesCalls)
+	}
+
+	repo.summary.LastMod = now.Add(time.Second)
+	if _, _, err := s.SitemapSegment(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if repo.entriesCalls != 2 {
+		t.Fatalf("changed segment must be re-read, entries calls = %d", repo.entriesCalls)
+	}
+
+	repo.summary.Count = 0
+	if _, _, err := s.SitemapSegment(ctx, 0); !errors.Is(err, ErrSitemapSegmentNotFound) {
+		t.Fatalf("err = %v, want ErrSitemapSegmentNotFound", err)
+	}
+}
+
+func TestSitemapSegmentUnlockedDuringQuery(t *testing.T) {
+	repo := &sitemapRepo{summary: domain.SitemapSegment{Count: 1, LastMod: time.Now()}}
+	s := &Service{repo: repo, sitemap: newSitemapCache()}
+	// запрос к базе не должен держать блокировку кэша
+	repo.onEntries = func() {
+		if !s.sitemap.mu.TryLock() {
+			t.Error("sitemap cache is locked during the query")
+			return
+		}
+		s.sitemap.mu.Unlock()
+	}
+	if _, _, err := s.SitemapSegment(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSitemapIndexCached(t *testing.T) {
+	repo := &sitemapRepo{summary: domain.SitemapSegment{Count: 1, LastMod: time.Now()}}
+	s := &Service{repo: repo, sitemap: newSitemapCache()}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		segs, err := s.SitemapIndex(ctx)
+		if err != nil || len(segs) != 1 {
+			t.Fatalf("SitemapIndex = %v, %v", segs, err)
+		}
+	}
+	if repo.indexCalls != 1 {
+		t.Fatalf("index queried %d times, want 1", repo.indexCalls)
+	}
+
+	s.sitemap.indexAt = time.Now().Add(-sitemapIndexTTL)
+	if _, err := s.SitemapIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.indexCalls != 2 {
+		t.Fatalf("stale index must be recomputed, calls = %d", repo.indexCalls)
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const maxFeedRedirects = 5
+
+var errFeedHostForbidden = errors.New("feed url points to a private or local address")
+
+// feedClient ходит только во внешнюю сеть: адрес проверяется после DNS на
+// каждом соединении (в том числе после редиректов), прокси из окружения не
+// используется, иначе проверялся бы адрес прокси.
+var feedClient = &http.Client{
+	Timeout: 60 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: feedDialControl,
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       90 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxFeedRedirects {
+			return errors.New("too many redirects")
+		}
+		return checkFeedURL(req.Context(), req.URL)
+	},
+}
+
+// feedDialControl вызывается с уже разрешённым IP-адресом.
+func feedDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return errFeedHostForbidden
+	}
+	return nil
+}
+
+// publicIP — адрес не loopback, не частный, не link-local и не unspecified.
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// checkFeedURL — схема http(s) и все адреса хоста публичные. Окончательная
+// проверка — в feedDialControl: DNS к моменту соединения может ответить иначе.
+func checkFeedURL(ctx context.Context, u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("invalid feed url")
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil {
+		if !publicIP(ip) {
+			return errFeedHostForbidden
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return errors.New("feed host not resolved")
+	}
+	for _, a := range addrs {
+		if !publicIP(a.IP) {
+			return errFeedHostForbidden
+		}
+	}
+	return nil
+}
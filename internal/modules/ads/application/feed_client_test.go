@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+)
+
+func TestFeedDialControl(t *testing.T) {
+	tests := []struct {
+		addr string
+		ok   bool
+	}{
+		{"93.184.216.34:80", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"10.1.2.3:443", false},
+		{"172.16.0.1:80", false},
+		{"192.168.1.1:80", false},
+		{"169.254.169.254:80", false},
+		{"0.0.0.0:80", false},
+		{"[::1]:80", false},
+		{"[fe80::1]:80", false},
+		{"[fd00::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+	}
+	for _, tt := range tests {
+		err := feedDialControl("tcp", tt.addr, nil)
+		if (err == nil) != tt.ok {
+			t.Errorf("feedDialControl(%s) = %v, want ok=%v", tt.addr, err, tt.ok)
+		}
+	}
+}
+
+func TestCheckFeedURL(t *testing.T) {
+	tests := []struct {
+		raw string
+		ok  bool
+	}{
+		{"https://93.184.216.34/feed.xml", true},
+		{"ftp://93.184.216.34/feed.xml", false},
+		{"http://127.0.0.1:8080/feed.csv", false},
+		{"http://169.254.169.254/latest/meta-data/", false},
+		{"http://[::1]/feed", false},
+		{"http://localhost/feed", false},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = checkFeedURL(context.Background(), u)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkFeedURL(%s) = %v, want ok=%v", tt.raw, err, tt.ok)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	if !publicIP(net.ParseIP("8.8.8.8")) || publicIP(net.ParseIP("192.168.0.10")) {
+		t.Fatal("publicIP misclassifies addresses")
+	}
+}
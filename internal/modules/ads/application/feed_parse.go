@@ -0,0 +1,314 @@
+package application
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+)
+
+const maxFeedRows = 5000
+
+// FeedItem — строка фида, приведённая к входным данным объявления.
+type FeedItem struct {
+	Line       int
+	ExternalID string
+	Ad         CreateAdInput
+}
+
+// feedValues — русские значения характеристик, принятые в автофидах.
+var feedValues = map[string]string{
+	"механика": "manual", "автомат": "automatic", "робот": "robot", "вариатор": "cvt",
+	"бензин": "petrol", "дизель": "diesel", "гибрид": "hybrid", "электро": "electric", "газ": "lpg",
+	"передний": "fwd", "задний": "rwd", "полный": "awd",
+	"левый": "left", "правый": "right",
+	"белый": "white", "черный": "black", "чёрный": "black", "серебристый": "silver", "серый": "grey",
+	"синий": "blue", "голубой": "blue", "красный": "red", "зеленый": "green", "зелёный": "green",
+	"коричневый": "brown", "бежевый": "beige", "желтый": "yellow", "жёлтый": "yellow",
+	"оранжевый": "orange", "фиолетовый": "purple", "золотой": "gold",
+}
+
+func feedValue(v string) string {
+	v = strings.TrimSpace(v)
+	if code, ok := feedValues[strings.ToLower(v)]; ok {
+		return code
+	}
+	return v
+}
+
+// feedRow — поля строки фида по именам колонок CSV.
+type feedRow func(col string) string
+
+// feedItem проверяет строку и собирает CreateAdInput; продавец проставляется позже.
+func feedItem(line int, get feedRow) (FeedItem, error) {
+	it := FeedItem{Line: line, ExternalID: get("external_id")}
+	if it.ExternalID == "" {
+		return it, errors.New("external_id required")
+	}
+
+	in := CreateAdInput{
+		Brand:       get("brand"),
+		Model:       get("model"),
+		VIN:         get("vin"),
+		City:        get("city"),
+		Description: get("description"),
+		Spec: SpecInput{
+			Transmission: feedValue(get("transmission")),
+			Fuel:         feedValue(get("fuel")),
+			Drive:        feedValue(get("drive")),
+			BodyType:     get("body_type"),
+			Color:        feedValue(get("color")),
+			Steering:     feedValue(get("steering")),
+		},
+	}
+	if in.Brand == "" || in.Model == "" {
+		return it, errors.New("brand and model required")
+	}
+
+	ints := []struct {
+		col      string
+		dst      *int
+		required bool
+	}{
+		{"year", &in.Year, true},
+		{"price", &in.Price, true},
+		{"mileage", &in.Mileage, false},
+	}
+	for _, f := range ints {
+		raw := strings.ReplaceAll(get(f.col), " ", "")
+		if raw == "" {
+			if f.required {
+				return it, errors.New(f.col + " required")
+			}
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 0 {
+			return it, errors.New("invalid " + f.col)
+		}
+		*f.dst = v
+	}
+	if in.Price <= 0 {
+		return it, errors.New("price must be positive")
+	}
+
+	if raw := get("engine_volume"); raw != "" {
+		v, err := parseEngineVolume(raw)
+		if err != nil {
+			return it, err
+		}
+		in.Spec.EngineVolume = &v
+	}
+	if raw := get("customs_cleared"); raw != "" {
+		v, err := parseFeedBool(raw)
+		if err != nil {
+			return it, errors.New("invalid customs_cleared")
+		}
+		in.Spec.CustomsCleared = &v
+	}
+
+	it.Ad = in
+	return it, nil
+}
+
+// parseEngineVolume принимает см³ (1998) или литры (2.0, "2,0").
+func parseEngineVolume(raw string) (int, error) {
+	raw = strings.ReplaceAll(strings.TrimSpace(raw), ",", ".")
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f <= 0 {
+		return 0, errors.New("invalid engine_volume")
+	}
+	if f < 100 {
+		f *= 1000
+	}
+	return int(f + 0.5), nil
+}
+
+func parseFeedBool(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "да", "yes":
+		return true, nil
+	case "нет", "no":
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
+
+// parseFeed разбирает фид целиком. Ошибки строк возвращаются отдельно,
+// ошибка — только если фид не читается вовсе.
+func parseFeed(format domain.FeedFormat, r io.Reader) ([]FeedItem, []domain.FeedRowError, error) {
+	switch format {
+	case domain.FeedCSV:
+		return parseCSVFeed(r)
+	case domain.FeedXML:
+		return parseXMLFeed(r)
+	default:
+		return nil, nil, errors.New("unknown feed format: " + string(format))
+	}
+}
+
+// parseCSVFeed: CSV с заголовком. Колонки: external_id, brand, model, year,
+// mileage, price, vin, city, description, transmission, fuel, drive, body_type,
+// engine_volume, color, steering, customs_cleared.
+func parseCSVFeed(r io.Reader) ([]FeedItem, []domain.FeedRowError, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, errors.New("cannot read header: " + err.Error())
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, c := range []string{"external_id", "brand", "model", "year", "price"} {
+		if _, ok := cols[c]; !ok {
+			return nil, nil, errors.New("missing column: " + c)
+		}
+	}
+
+	var items []FeedItem
+	var rowErrs []domain.FeedRowError
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(items)+len(rowErrs) >= maxFeedRows {
+			return items, rowErrs, errors.New("too many rows")
+		}
+		// битая строка (кавычки) — ошибка строки, а не всего фида;
+		// FieldPos после ошибки Read вызывать нельзя
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			rowErrs = append(rowErrs, domain.FeedRowError{Line: perr.StartLine, Error: perr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return items, rowErrs, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		it, err := feedItem(line, func(col string) string {
+			if i, ok := cols[col]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		})
+		if err != nil {
+			rowErrs = append(rowErrs, domain.FeedRowError{Line: line, ExternalID: it.ExternalID, Error: err.Error()})
+			continue
+		}
+		items = append(items, it)
+	}
+	return items, rowErrs, nil
+}
+
+// xmlFeedAd — объявление в распространённом формате автозагрузки
+// (<Ads><Ad><Id/><Make/><Model/>…</Ad></Ads>).
+type xmlFeedAd struct {
+	ID           string `xml:"Id"`
+	Make         string `xml:"Make"`
+	Model        string `xml:"Model"`
+	Year         string `xml:"Year"`
+	Kilometrage  string `xml:"Kilometrage"`
+	Price        string `xml:"Price"`
+	VIN          string `xml:"VIN"`
+	City         string `xml:"City"`
+	Address      string `xml:"Address"`
+	Description  string `xml:"Description"`
+	Transmission string `xml:"Transmission"`
+	FuelType     string `xml:"FuelType"`
+	DriveType    string `xml:"DriveType"`
+	BodyType     string `xml:"BodyType"`
+	EngineSize   string `xml:"EngineSize"`
+	Color        string `xml:"Color"`
+	WheelType    string `xml:"WheelType"`
+	Customs      string `xml:"Customs"`
+}
+
+func (a *xmlFeedAd) get(col string) string {
+	var v string
+	switch col {
+	case "external_id":
+		v = a.ID
+	case "brand":
+		v = a.Make
+	case "model":
+		v = a.Model
+	case "year":
+		v = a.Year
+	case "mileage":
+		v = a.Kilometrage
+	case "price":
+		v = a.Price
+	case "vin":
+		v = a.VIN
+	case "city":
+		v = a.City
+		if v == "" {
+			v = a.Address
+		}
+	case "description":
+		v = a.Description
+	case "transmission":
+		v = a.Transmission
+	case "fuel":
+		v = a.FuelType
+	case "drive":
+		v = a.DriveType
+	case "body_type":
+		v = a.BodyType
+	case "engine_volume":
+		v = a.EngineSize
+	case "color":
+		v = a.Color
+	case "steering":
+		v = a.WheelType
+	case "customs_cleared":
+		v = a.Customs
+	}
+	return strings.TrimSpace(v)
+}
+
+func parseXMLFeed(r io.Reader) ([]FeedItem, []domain.FeedRowError, error) {
+	dec := xml.NewDecoder(r)
+
+	var items []FeedItem
+	var rowErrs []domain.FeedRowError
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return items, rowErrs, errors.New("invalid xml: " + err.Error())
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "Ad" {
+			continue
+		}
+		if len(items)+len(rowErrs) >= maxFeedRows {
+			return items, rowErrs, errors.New("too many rows")
+		}
+
+		line, _ := dec.InputPos()
+		var ad xmlFeedAd
+		if err := dec.DecodeElement(&ad, &start); err != nil {
+			return items, rowErrs, errors.New("invalid xml: " + err.Error())
+		}
+		it, err := feedItem(line, ad.get)
+		if err != nil {
+			rowErrs = append(rowErrs, domain.FeedRowError{Line: line, ExternalID: it.ExternalID, Error: err.Error()})
+			continue
+		}
+		items = append(items, it)
+	}
+	return items, rowErrs, nil
+}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func TestParseCSVFeed(t *testing.T) {
+	feed := "\ufeffexternal_id,brand,model,year,price,mileage,transmission,engine_volume,customs_cleared\n" +
+		"a1,Toyota,Camry,2018,\"1 950 000\",85000,автомат,\"2,5\",да\n" +
+		"a2,Kia,Rio,,900000,,,,\n" +
+		",Lada,Vesta,2020,800000,,,,\n"
+
+	items, rowErrs, err := parseFeed(domain.FeedCSV, strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("parseFeed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d items, want 1", len(items))
+	}
+	it := items[0]
+	if it.ExternalID != "a1" || it.Line != 2 {
+		t.Errorf("item = %q line %d, want a1 line 2", it.ExternalID, it.Line)
+	}
+	if it.Ad.Price != 1950000 || it.Ad.Year != 2018 || it.Ad.Mileage != 85000 {
+		t.Errorf("numbers = %d/%d/%d", it.Ad.Price, it.Ad.Year, it.Ad.Mileage)
+	}
+	if it.Ad.Spec.Transmission != "automatic" {
+		t.Errorf("transmission = %q, want automatic", it.Ad.Spec.Transmission)
+	}
+	if it.Ad.Spec.EngineVolume == nil || *it.Ad.Spec.EngineVolume != 2500 {
+		t.Errorf("engine_volume = %v, want 2500", it.Ad.Spec.EngineVolume)
+	}
+	if it.Ad.Spec.CustomsCleared == nil || !*it.Ad.Spec.CustomsCleared {
+		t.Errorf("customs_cleared = %v, want true", it.Ad.Spec.CustomsCleared)
+	}
+
+	if len(rowErrs) != 2 {
+		t.Fatalf("got %d row errors, want 2: %+v", len(rowErrs), rowErrs)
+	}
+	if rowErrs[0].ExternalID != "a2" || rowErrs[0].Error != "year required" {
+		t.Errorf("row error 0 = %+v", rowErrs[0])
+	}
+	if rowErrs[1].Error != "external_id required" {
+		t.Errorf("row error 1 = %+v", rowErrs[1])
+	}
+}
+
+func TestParseCSVFeedMissingColumn(t *testing.T) {
+	_, _, err := parseFeed(domain.FeedCSV, strings.NewReader("external_id,brand,model,year\n"))
+	if err == nil || !strings.Contains(err.Error(), "price") {
+		t.Fatalf("err = %v, want missing column price", err)
+	}
+}
+
+func TestParseXMLFeed(t *testing.T) {
+	feed := `<?xml version="1.0" encoding="UTF-8"?>
+<Ads formatVersion="3">
+  <Ad>
+    <Id>x-1</Id>
+    <Make>BMW</Make>
+    <Model>X5</Model>
+    <Year>2019</Year>
+    <Kilometrage>60000</Kilometrage>
+    <Price>5500000</Price>
+    <Address>Москва</Address>
+    <DriveType>Полный</DriveType>
+    <EngineSize>3.0</EngineSize>
+  </Ad>
+  <Ad>
+    <Id>x-2</Id>
+    <Make>BMW</Make>
+  </Ad>
+</Ads>`
+
+	items, rowErrs, err := parseFeed(domain.FeedXML, strings.NewReader(feed))
+	if err != nil {
+		t.Fatalf("parseFeed: %v", err)
+	}
+	if len(items) != 1 || len(rowErrs) != 1 {
+		t.Fatalf("got %d items, %d errors; want 1, 1", len(items), len(rowErrs))
+	}
+	ad := items[0].Ad
+	if items[0].ExternalID != "x-1" || ad.City != "Москва" || ad.Spec.Drive != "awd" {
+		t.Errorf("item = %+v", items[0])
+	}
+	if ad.Spec.EngineVolume == nil || *ad.Spec.EngineVolume != 3000 {
+		t.Errorf("engine_volume = %v, want 3000", ad.Spec.EngineVolume)
+	}
+	if rowErrs[0].ExternalID != "x-2" {
+		t.Errorf("row error = %+v", rowErrs[0])
+	}
+}
+
+func TestParseXMLFeedBroken(t *testing.T) {
+	// обрезанный фид — ошибка целиком, иначе синхронизация заархивирует хвост
+	_, _, err := parseFeed(domain.FeedXML, strings.NewReader(`<Ads><Ad><Id>1</Id>`))
+	if err == nil {
+		t.Fatal("want error for truncated xml")
+	}
+}
+
+func TestParseEngineVolume(t *testing.T) {
+	tests := map[string]int{"1998": 1998, "2.0": 2000, "1,6": 1600, " 3.5 ": 3500}
+	for raw, want := range tests {
+		got, err := parseEngineVolume(raw)
+		if err != nil || got != want {
+			t.Errorf("parseEngineVolume(%q) = %d, %v; want %d", raw, got, err, want)
+		}
+	}
+	if _, err := parseEngineVolume("abc"); err == nil {
+		t.Error("parseEngineVolume(abc): want error")
+	}
+}
+
+func TestParseCSVFeedMalformedRow(t *testing.T) {
+	tests := []struct {
+		name      string
+		feed      string
+		wantItems int
+		wantLines []int
+	}{
+		{
+			name: "bare quote",
+			feed: "external_id,brand,model,year,price\n" +
+				"a1,Toyota,Camry,2018,1000000\n" +
+				"a2,Kia,Ri\"o,2019,900000\n" +
+				"a3,Lada,Vesta,2020,800000\n",
+			wantItems: 2,
+			wantLines: []int{3},
+		},
+		{
+			name: "unterminated quote",
+			feed: "external_id,brand,model,year,price\n" +
+				"a1,Toyota,Camry,2018,1000000\n" +
+				"a2,\"Kia,Rio,2019,900000\n",
+			wantItems: 1,
+			wantLines: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, rowErrs, err := parseFeed(domain.FeedCSV, strings.NewReader(tt.feed))
+			if err != nil {
+				t.Fatalf("parseFeed: %v", err)
+			}
+			if len(items) != tt.wantItems {
+				t.Fatalf("got %d items, want %d", len(items), tt.wantItems)
+			}
+			if len(rowErrs) != len(tt.wantLines) {
+				t.Fatalf("row errors = %+v, want lines %v", rowErrs, tt.wantLines)
+			}
+			for i, line := range tt.wantLines {
+				if rowErrs[i].Line != line || rowErrs[i].Error == "" {
+					t.Errorf("row error %d = %+v, want line %d", i, rowErrs[i], line)
+				}
+			}
+		})
+	}
+}
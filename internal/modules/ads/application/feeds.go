@@ -0,0 +1,404 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// Accounts — сведения об аккаунтах (модуль users), подключается при сборке.
+type Accounts interface {
+	IsCompany(ctx context.Context, userID int64) (bool, error)
+}
+
+const maxFeedErrors = 200
+
+func (s *Service) checkCompany(ctx context.Context, sellerID int64) error {
+	if s.accounts == nil {
+		return domain.ErrNotCompany
+	}
+	ok, err := s.accounts.IsCompany(ctx, sellerID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrNotCompany
+	}
+	return nil
+}
+
+// ImportFeed ставит загруженный продавцом фид в очередь фоновой обработки:
+// объявления создаются или обновляются по внешнему ID, новые сразу уходят на
+// модерацию. archiveMissing — режим синхронизации: объявления из прошлых
+// загрузок, которых нет в фиде, архивируются.
+func (s *Service) ImportFeed(ctx context.Context, sellerID int64, format domain.FeedFormat, r io.Reader, archiveMissing bool) (*domain.FeedImport, error) {
+	if err := s.checkCompany(ctx, sellerID); err != nil {
+		return nil, err
+	}
+	if format != domain.FeedCSV && format != domain.FeedXML {
+		return nil, errors.New("invalid feed format")
+	}
+	data, err := io.ReadAll(io.LimitReader(r, domain.MaxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > domain.MaxFeedSize {
+		return nil, errors.New("feed too large")
+	}
+
+	imp := &domain.FeedImport{SellerID: sellerID, Format: format, ArchiveMissing: archiveMissing, Status: domain.FeedImportPending}
+	if err := s.repo.CreateFeedImport(ctx, imp, data); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// FeedImport — состояние загрузки продавца.
+func (s *Service) FeedImport(ctx context.Context, id, sellerID int64) (*domain.FeedImport, error) {
+	imp, err := s.repo.GetFeedImport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if imp.SellerID != sellerID {
+		return nil, errors.New("import not found")
+	}
+	return imp, nil
+}
+
+// ProcessFeedImports разбирает очередь загрузок, пока она не опустеет.
+func (s *Service) ProcessFeedImports(ctx context.Context) (int, error) {
+	n := 0
+	for ctx.Err() == nil {
+		imp, payload, err := s.repo.ClaimFeedImport(ctx, time.Now().Add(-domain.FeedImportStale))
+		if err != nil {
+			return n, err
+		}
+		if imp == nil {
+			return n, nil
+		}
+		stop := s.keepFeedImportAlive(ctx, imp.ID)
+		res, err := s.runFeedImport(ctx, imp, payload)
+		stop()
+		errText := ""
+		if err != nil {
+			errText = err.Error()
+		}
+		if err := s.repo.FinishFeedImport(ctx, imp.ID, res, errText); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, ctx.Err()
+}
+
+// feedImportHeartbeat — как часто продлевается started_at выполняющегося импорта.
+var feedImportHeartbeat = domain.FeedImportStale / 3
+
+// keepFeedImportAlive продлевает захват импорта, пока он выполняется; stop
+// дожидается остановки, чтобы продление не пришло после FinishFeedImport.
+func (s *Service) keepFeedImportAlive(ctx context.Context, id int64) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := time.NewTicker(feedImportHeartbeat)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				_ = s.repo.TouchFeedImport(ctx, id)
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (s *Service) runFeedImport(ctx context.Context, imp *domain.FeedImport, payload []byte) (*domain.FeedResult, error) {
+	if imp.FeedID != nil {
+		f, err := s.repo.GetFeed(ctx, *imp.FeedID)
+		if err != nil {
+			return nil, err
+		}
+		return s.syncFeed(ctx, f)
+	}
+	// права компании проверяются и при обработке: между загрузкой и разбором аккаунт мог смениться
+	if err := s.checkCompany(ctx, imp.SellerID); err != nil {
+		return nil, err
+	}
+	return s.importFeed(ctx, imp.SellerID, imp.Format, bytes.NewReader(payload), imp.ArchiveMissing)
+}
+
+func (s *Service) importFeed(ctx context.Context, sellerID int64, format domain.FeedFormat, r io.Reader, archiveMissing bool) (*domain.FeedResult, error) {
+	items, rowErrs, err := parseFeed(format, r)
+	if err != nil {
+		// фид прочитан не полностью — архивировать по нему нельзя
+		return nil, err
+	}
+	known, err := s.repo.FeedAds(ctx, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.FeedResult{Rows: len(items) + len(rowErrs), Errors: []domain.FeedRowError{}}
+	addErr := func(e domain.FeedRowError) {
+		if len(res.Errors) < maxFeedErrors {
+			res.Errors = append(res.Errors, e)
+		}
+	}
+	for _, e := range rowErrs {
+		addErr(e)
+	}
+
+	// строки с ошибками тоже считаются присутствующими: из-за опечатки
+	// в фиде объявление не должно уходить в архив
+	seen := make(map[string]bool, res.Rows)
+	for _, e := range rowErrs {
+		seen[e.ExternalID] = true
+	}
+
+	for _, it := range items {
+		if seen[it.ExternalID] {
+			addErr(domain.FeedRowError{Line: it.Line, ExternalID: it.ExternalID, Error: "duplicate external_id"})
+			continue
+		}
+		seen[it.ExternalID] = true
+
+		it.Ad.SellerID = sellerID
+		outcome, err := s.applyFeedItem(ctx, sellerID, it, known)
+		switch outcome {
+		case feedCreated:
+			res.Created++
+		case feedUpdated:
+			res.Updated++
+		case feedSkipped:
+			res.Skipped++
+		}
+		if err != nil {
+			addErr(domain.FeedRowError{Line: it.Line, ExternalID: it.ExternalID, Error: err.Error()})
+		}
+	}
+
+	if archiveMissing {
+		for ext, adID := range known {
+			if seen[ext] {
+				continue
+			}
+			archived, err := s.archiveFeedAd(ctx, adID, sellerID)
+			if err != nil {
+				addErr(domain.FeedRowError{ExternalID: ext, Error: "archive: " + err.Error()})
+				continue
+			}
+			if archived {
+				res.Archived++
+			}
+		}
+	}
+	return res, nil
+}
+
+type feedOutcome int
+
+const (
+	feedFailed feedOutcome = iota
+	feedCreated
+	feedUpdated
+	feedSkipped
+)
+
+// applyFeedItem — upsert одной строки. Архивное объявление, вернувшееся
+// в фид, возвращается в черновики и заново идёт на модерацию.
+func (s *Service) applyFeedItem(ctx context.Context, sellerID int64, it FeedItem, known map[string]int64) (feedOutcome, error) {
+	adID, ok := known[it.ExternalID]
+	if !ok {
+		ad, err := s.newAd(ctx, it.Ad)
+		if err != nil {
+			return feedFailed, err
+		}
+		// объявление и привязка к внешнему ID — атомарно, иначе следующая
+		// синхронизация создала бы дубль
+		id, err := s.repo.CreateFeedAd(ctx, ad, it.ExternalID)
+		if err != nil {
+			return feedFailed, err
+		}
+		_, _ = s.detectDuplicates(ctx, id)
+		if err := s.SubmitToModeration(ctx, id, sellerID); err != nil {
+			return feedCreated, errors.New("created as draft: " + err.Error())
+		}
+		return feedCreated, nil
+	}
+
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return feedFailed, err
+	}
+	// на модерации объявление не правится — изменения подхватит следующая синхронизация
+	if ad.Status == domain.AdSold || ad.Status == domain.AdModeration {
+		return feedSkipped, nil
+	}
+	if ad.Status == domain.AdArchived {
+		if _, err := s.Relist(ctx, adID, sellerID); err != nil {
+			return feedFailed, err
+		}
+	}
+
+	in := it.Ad
+	upd := UpdateAdInput{
+		Brand:       &in.Brand,
+		Model:       &in.Model,
+		Year:        &in.Year,
+		Mileage:     &in.Mileage,
+		Price:       &in.Price,
+		VIN:         &in.VIN,
+		City:        &in.City,
+		Description: &in.Description,
+		Spec:        in.Spec.patch(),
+	}
+	updated, err := s.Update(ctx, adID, sellerID, upd)
+	if err != nil {
+		return feedFailed, err
+	}
+	if updated.Status == domain.AdDraft {
+		if err := s.SubmitToModeration(ctx, adID, sellerID); err != nil {
+			return feedUpdated, errors.New("left as draft: " + err.Error())
+		}
+	}
+	return feedUpdated, nil
+}
+
+func (s *Service) archiveFeedAd(ctx context.Context, adID, sellerID int64) (bool, error) {
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return false, err
+	}
+	if ad.Status == domain.AdArchived || ad.Status == domain.AdSold {
+		return false, nil
+	}
+	return true, s.transition(ctx, ad, domain.AdArchived)
+}
+
+// RegisterFeed подключает фид по URL для регулярной синхронизации.
+func (s *Service) RegisterFeed(ctx context.Context, sellerID int64, rawURL string, format domain.FeedFormat) (*domain.Feed, error) {
+	if err := s.checkCompany(ctx, sellerID); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.New("invalid feed url")
+	}
+	if err := checkFeedURL(ctx, u); err != nil {
+		return nil, err
+	}
+	if format != domain.FeedCSV && format != domain.FeedXML {
+		return nil, errors.New("invalid feed format")
+	}
+
+	f := &domain.Feed{SellerID: sellerID, URL: u.String(), Format: format, Active: true}
+	if _, err := s.repo.CreateFeed(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *Service) Feeds(ctx context.Context, sellerID int64) ([]domain.Feed, error) {
+	return s.repo.ListFeeds(ctx, sellerID)
+}
+
+func (s *Service) DeleteFeed(ctx context.Context, id, sellerID int64) error {
+	return s.repo.DeleteFeed(ctx, id, sellerID)
+}
+
+// SyncFeed ставит синхронизацию фида продавца в очередь вне расписания.
+func (s *Service) SyncFeed(ctx context.Context, id, sellerID int64) (*domain.FeedImport, error) {
+	f, err := s.repo.GetFeed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f.SellerID != sellerID {
+		return nil, errors.New("feed not found")
+	}
+	imp := &domain.FeedImport{SellerID: sellerID, FeedID: &f.ID, Format: f.Format, ArchiveMissing: true, Status: domain.FeedImportPending}
+	if err := s.repo.CreateFeedImport(ctx, imp, nil); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// SyncFeeds ставит в очередь синхронизацию всех активных фидов по расписанию.
+// Выполняет их ProcessFeedImports: очередь с захватом строки не даёт
+// нескольким экземплярам синхронизировать один фид одновременно.
+func (s *Service) SyncFeeds(ctx context.Context) (int, error) {
+	feeds, err := s.repo.ActiveFeeds(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, f := range feeds {
+		if ctx.Err() != nil {
+			return n, ctx.Err()
+		}
+		imp := &domain.FeedImport{SellerID: f.SellerID, FeedID: &f.ID, Format: f.Format, ArchiveMissing: true, Status: domain.FeedImportPending}
+		err := s.repo.CreateFeedImport(ctx, imp, nil)
+		if errors.Is(err, domain.ErrFeedSyncQueued) {
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// syncFeed перед загрузкой заново проверяет, что продавец всё ещё компания.
+func (s *Service) syncFeed(ctx context.Context, f *domain.Feed) (*domain.FeedResult, error) {
+	err := s.checkCompany(ctx, f.SellerID)
+	var res *domain.FeedResult
+	if err == nil {
+		res, err = s.fetchAndImport(ctx, f)
+	}
+	if err != nil {
+		res = &domain.FeedResult{Errors: []domain.FeedRowError{{Error: err.Error()}}}
+	}
+	if serr := s.repo.SaveFeedResult(ctx, f.ID, res); serr != nil && err == nil {
+		err = serr
+	}
+	return res, err
+}
+
+func (s *Service) fetchAndImport(ctx context.Context, f *domain.Feed) (*domain.FeedResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := feedClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("feed url returned " + strconv.Itoa(resp.StatusCode))
+	}
+
+	// читаем целиком до разбора: обрезанный фид нельзя применять, иначе
+	// синхронизация заархивирует хвост
+	data, err := io.ReadAll(io.LimitReader(resp.Body, domain.MaxFeedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > domain.MaxFeedSize {
+		return nil, errors.New("feed too large")
+	}
+	return s.importFeed(ctx, f.SellerID, f.Format, bytes.NewReader(data), true)
+}
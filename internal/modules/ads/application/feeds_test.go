@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// feedsRepo — очередь загрузок в памяти: одна незавершённая синхронизация на фид.
+type feedsRepo struct {
+	domain.Repository
+	feeds   []domain.Feed
+	queued  map[int64]bool
+	created []domain.FeedImport
+
+	mu      sync.Mutex
+	touched int
+}
+
+func (r *feedsRepo) ActiveFeeds(_ context.Context) ([]domain.Feed, error) {
+	return r.feeds, nil
+}
+
+func (r *feedsRepo) CreateFeedImport(_ context.Context, imp *domain.FeedImport, _ []byte) error {
+	if imp.FeedID != nil && r.queued[*imp.FeedID] {
+		return domain.ErrFeedSyncQueued
+	}
+	r.queued[*imp.FeedID] = true
+	imp.ID = int64(len(r.created) + 1)
+	r.created = append(r.created, *imp)
+	return nil
+}
+
+func (r *feedsRepo) TouchFeedImport(_ context.Context, _ int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.touched++
+	return nil
+}
+
+func TestSyncFeedsEnqueues(t *testing.T) {
+	repo := &feedsRepo{
+		feeds: []domain.Feed{
+			{ID: 1, SellerID: 7, Format: domain.FeedXML},
+			{ID: 2, SellerID: 8, Format: domain.FeedCSV},
+		},
+		queued: map[int64]bool{2: true},
+	}
+	s := &Service{repo: repo}
+
+	n, err := s.SyncFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("SyncFeeds: %v", err)
+	}
+	if n != 1 || len(repo.created) != 1 {
+		t.Fatalf("queued %d (%+v), want only feed 1", n, repo.created)
+	}
+	imp := repo.created[0]
+	if *imp.FeedID != 1 || imp.SellerID != 7 || imp.Format != domain.FeedXML || !imp.ArchiveMissing || imp.Status != domain.FeedImportPending {
+		t.Fatalf("import = %+v", imp)
+	}
+
+	// второй экземпляр по тому же расписанию ничего не добавляет
+	if n, err := s.SyncFeeds(context.Background()); err != nil || n != 0 {
+		t.Fatalf("second run: n = %d, err = %v", n, err)
+	}
+}
+
+func TestKeepFeedImportAlive(t *testing.T) {
+	old := feedImportHeartbeat
+	feedImportHeartbeat = 5 * time.Millisecond
+	defer func() { feedImportHeartbeat = old }()
+
+	repo := &feedsRepo{}
+	s := &Service{repo: repo}
+
+	stop := s.keepFeedImportAlive(context.Background(), 1)
+	time.Sleep(30 * time.Millisecond)
+	stop()
+
+	repo.mu.Lock()
+	touched := repo.touched
+	repo.mu.Unlock()
+	if touched == 0 {
+		t.Fatal("started_at was never renewed")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if repo.touched != touched {
+		t.Fatal("renewed after stop")
+	}
+}
+
+func TestApplyFeedItemSkipsModeration(t *testing.T) {
+	repo := &updateRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdModeration, Price: 100}}
+	s := &Service{repo: repo}
+
+	it := FeedItem{ExternalID: "x1", Ad: CreateAdInput{Price: 200}}
+	got, err := s.applyFeedItem(context.Background(), 7, it, map[string]int64{"x1": 1})
+	if err != nil || got != feedSkipped {
+		t.Fatalf("outcome = %v, err = %v, want skipped", got, err)
+	}
+	if repo.saved != nil {
+		t.Fatal("ad in moderation must not be updated")
+	}
+}
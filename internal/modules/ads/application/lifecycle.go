@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// transitions — машина состояний объявления: из какого статуса в какие можно перейти.
+var transitions = map[domain.AdStatus][]domain.AdStatus{
+	domain.AdDraft:      {domain.AdModeration, domain.AdArchived},
+	domain.AdModeration: {domain.AdPublished, domain.AdRejected, domain.AdArchived},
+	domain.AdPublished:  {domain.AdModeration, domain.AdSold, domain.AdArchived, domain.AdExpired},
+	domain.AdRejected:   {domain.AdModeration, domain.AdArchived},
+	domain.AdExpired:    {domain.AdPublished, domain.AdModeration, domain.AdArchived},
+	domain.AdSold:       {domain.AdArchived},
+	domain.AdArchived:   {domain.AdDraft},
+}
+
+func canTransition(from, to domain.AdStatus) bool {
+	for _, st := range transitions[from] {
+		if st == to {
+			return true
+		}
+	}
+	return false
+}
+
+var ErrIllegalTransition = errors.New("illegal status transition")
+
+func checkTransition(from, to domain.AdStatus) error {
+	if !canTransition(from, to) {
+		return errors.New(ErrIllegalTransition.Error() + ": " + string(from) + " -> " + string(to))
+	}
+	return nil
+}
+
+// transition проверяет переход по машине состояний и применяет его как
+// compare-and-set, чтобы параллельный переход не затёрся.
+func (s *Service) transition(ctx context.Context, ad *domain.Ad, to domain.AdStatus) error {
+	if err := checkTransition(ad.Status, to); err != nil {
+		return err
+	}
+	if err := s.repo.Transition(ctx, ad.ID, ad.Status, to); err != nil {
+		return err
+	}
+	ad.Status = to
+	return nil
+}
+
+func (s *Service) ownedAd(ctx context.Context, adID, sellerID int64) (*domain.Ad, error) {
+	ad, err := s.repo.Get(ctx, adID)
+	if err != nil {
+		return nil, err
+	}
+	if ad.SellerID != sellerID {
+		return nil, errors.New("not your ad")
+	}
+	return ad, nil
+}
+
+// SubmitToModeration отправляет объявление на модерацию и сразу прогоняет
+// правила премодерации (они могут одобрить или отклонить его автоматически).
+func (s *Service) SubmitToModeration(ctx context.Context, adID, sellerID int64) error {
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return err
+	}
+	if err := s.transition(ctx, ad, domain.AdModeration); err != nil {
+		return err
+	}
+	return s.premoderate(ctx, ad)
+}
+
+func (s *Service) MarkSold(ctx context.Context, adID, sellerID int64, finalPrice int) error {
+	if finalPrice <= 0 {
+		return errors.New("final_price must be positive")
+	}
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return err
+	}
+	if err := checkTransition(ad.Status, domain.AdSold); err != nil {
+		return err
+	}
+	return s.repo.MarkSold(ctx, ad.ID, ad.Status, finalPrice)
+}
+
+func (s *Service) Archive(ctx context.Context, adID, sellerID int64) error {
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return err
+	}
+	return s.transition(ctx, ad, domain.AdArchived)
+}
+
+// Relist возвращает истёкшее объявление в витрину с новым сроком публикации,
+// а архивное — в черновики (дальше обычная модерация). Истёкшее объявление,
+// которое правили после снятия с публикации, сначала проходит модерацию.
+func (s *Service) Relist(ctx context.Context, adID, sellerID int64) (domain.AdStatus, error) {
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return "", err
+	}
+	switch ad.Status {
+	case domain.AdExpired:
+		edited, err := s.repo.EditedSinceStatusChange(ctx, ad.ID)
+		if err != nil {
+			return "", err
+		}
+		if !edited {
+			return domain.AdPublished, s.transition(ctx, ad, domain.AdPublished)
+		}
+		if err := s.transition(ctx, ad, domain.AdModeration); err != nil {
+			return "", err
+		}
+		return domain.AdModeration, s.premoderate(ctx, ad)
+	case domain.AdArchived:
+		return domain.AdDraft, s.transition(ctx, ad, domain.AdDraft)
+	default:
+		return "", errors.New("only expired or archived ads can be relisted")
+	}
+}
+
+// ExpireOld снимает с публикации объявления старше period.
+func (s *Service) ExpireOld(ctx context.Context, period time.Duration) ([]int64, error) {
+	return s.repo.ExpirePublished(ctx, time.Now().Add(-period))
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// lifecycleRepo — одно объявление и признак правок после смены статуса.
+type lifecycleRepo struct {
+	domain.Repository
+	ad           domain.Ad
+	edited       bool
+	transitions  []domain.AdStatus
+	premoderated bool
+}
+
+func (r *lifecycleRepo) Get(_ context.Context, _ int64) (*domain.Ad, error) {
+	ad := r.ad
+	return &ad, nil
+}
+
+func (r *lifecycleRepo) EditedSinceStatusChange(_ context.Context, _ int64) (bool, error) {
+	return r.edited, nil
+}
+
+func (r *lifecycleRepo) Transition(_ context.Context, _ int64, _, to domain.AdStatus) error {
+	r.transitions = append(r.transitions, to)
+	return nil
+}
+
+func (r *lifecycleRepo) SaveRuleHits(_ context.Context, _ int64, _ []domain.RuleHit) error {
+	r.premoderated = true
+	return nil
+}
+
+func (r *lifecycleRepo) FindDuplicates(_ context.Context, _ int64) ([]domain.Duplicate, error) {
+	return nil, nil
+}
+
+func (r *lifecycleRepo) SaveDuplicates(_ context.Context, _ int64, _ []domain.Duplicate) error {
+	return nil
+}
+
+// allRulesOff — премодерация без правил: проверяем только маршрут объявления.
+var allRulesOff = RulesConfig{DisabledRules: []string{
+	RuleVINChecksum, RuleVINMismatch, RulePriceBelowMedian, RuleBannedWords, RuleYearRange, RuleSellerDailyLimit,
+}}
+
+func TestRelist(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     domain.AdStatus
+		edited     bool
+		want       domain.AdStatus
+		wantPremod bool
+		wantErr    bool
+	}{
+		{name: "expired untouched", status: domain.AdExpired, want: domain.AdPublished},
+		{name: "expired and edited", status: domain.AdExpired, edited: true, want: domain.AdModeration, wantPremod: true},
+		{name: "archived", status: domain.AdArchived, want: domain.AdDraft},
+		{name: "published", status: domain.AdPublished, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &lifecycleRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: tt.status}, edited: tt.edited}
+			s := &Service{repo: repo, rules: allRulesOff}
+
+			got, err := s.Relist(context.Background(), 1, 7)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if len(repo.transitions) > 0 {
+					t.Fatalf("transitions = %v", repo.transitions)
+				}
+				return
+			}
+			if got != tt.want || len(repo.transitions) != 1 || repo.transitions[0] != tt.want {
+				t.Fatalf("status = %q, transitions %v, want %q", got, repo.transitions, tt.want)
+			}
+			if repo.premoderated != tt.wantPremod {
+				t.Fatalf("premoderated = %v, want %v", repo.premoderated, tt.wantPremod)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+)
+
+type ModerateInput struct {
+	Decision string `json:"decision"` // approve / reject
+	Reason   string `json:"reason"`   // код из каталога, обязателен для reject
+	Comment  string `json:"comment"`  // свободный текст для продавца
+}
+
+// ModerationQueue — очередь с пометками сработавших правил премодерации,
+// подозрениями на дубли и числом жалоб покупателей.
+func (s *Service) ModerationQueue(ctx context.Context, f domain.QueueFilter) ([]domain.QueueItem, int64, error) {
+	items, total, err := s.repo.ModerationQueue(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]int64, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.ID)
+	}
+	hits, err := s.repo.RuleHitsByAds(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	dups, err := s.repo.DuplicatesByAds(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	complaints, err := s.repo.OpenComplaintCounts(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range items {
+		items[i].Flags = hits[items[i].ID]
+		items[i].Duplicates = dups[items[i].ID]
+		items[i].Complaints = complaints[items[i].ID]
+	}
+	return items, total, nil
+}
+
+func (s *Service) ClaimForModeration(ctx context.Context, adID, moderatorID int64) error {
+	ok, err := s.repo.ClaimForModeration(ctx, adID, moderatorID, domain.ClaimTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("ad is claimed by another moderator")
+	}
+	return nil
+}
+
+func (s *Service) ReleaseClaim(ctx context.Context, adID, moderatorID int64) error {
+	return s.repo.ReleaseClaim(ctx, adID, moderatorID)
+}
+
+// Moderate фиксирует решение модератора. Одобрение публикует объявление,
+// отклонение требует причину из каталога (для "other" — ещё и комментарий).
+func (s *Service) Moderate(ctx context.Context, adID, moderatorID int64, in ModerateInput) (*domain.ModerationDecision, error) {
+	d := &domain.ModerationDecision{
+		AdID:        adID,
+		ModeratorID: moderatorID,
+		Decision:    domain.ModerationDecisionType(in.Decision),
+		Reason:      domain.RejectionReason(in.Reason),
+		Comment:     strings.TrimSpace(in.Comment),
+	}
+
+	var to domain.AdStatus
+	switch d.Decision {
+	case domain.DecisionApprove:
+		to = domain.AdPublished
+		d.Reason = ""
+	case domain.DecisionReject:
+		to = domain.AdRejected
+		if err := validateRejection(d); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("decision must be approve or reject")
+	}
+
+	if err := checkTransition(domain.AdModeration, to); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Decide(ctx, d, to); err != nil {
+		return nil, err
+	}
+	d.ReasonText = domain.RejectionReasons[d.Reason]
+	return d, nil
+}
+
+// validateRejection: причина из каталога, для "other" — ещё и комментарий.
+func validateRejection(d *domain.ModerationDecision) error {
+	if _, ok := domain.RejectionReasons[d.Reason]; !ok {
+		return errors.New("unknown rejection reason")
+	}
+	if d.Reason == domain.ReasonOther && d.Comment == "" {
+		return errors.New("comment required for reason other")
+	}
+	return nil
+}
+
+// ModerationHistory — решения по объявлению; продавцу только по своим.
+// sellerID == 0 — доступ администратора.
+func (s *Service) ModerationHistory(ctx context.Context, adID, sellerID int64) ([]domain.ModerationDecision, error) {
+	if sellerID != 0 {
+		if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.ListDecisions(ctx, adID)
+}
@@ -0,0 +1,229 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strconv"
+
+	"autera/internal/modules/ads/domain"
+	"autera/pkg/imaging"
+)
+
+const (
+	MaxPhotoSize = 10 << 20 // 10 MB на файл
+
+	maxPhotoPixels  = 40_000_000 // защита от «бомб» с огромным разрешением
+	mediumSide      = 1280
+	thumbSide       = 320
+	variantQuality  = 85
+	originalQuality = 92 // оригинал перекодируется только при повороте по EXIF
+)
+
+var allowedPhotoTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+type PhotoUpload struct {
+	Filename string
+	Data     io.Reader
+}
+
+// AddPhotos валидирует и сохраняет фото продавца: оригинал (без метаданных)
+// + medium/thumb в JPEG.
+// Ошибка на любом файле прерывает загрузку, уже сохранённые файлы остаются.
+func (s *Service) AddPhotos(ctx context.Context, adID, sellerID int64, files []PhotoUpload) ([]domain.Photo, error) {
+	if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no files")
+	}
+
+	existing, err := s.repo.ListPhotos(ctx, adID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing)+len(files) > domain.MaxPhotosPerAd {
+		return nil, errors.New("too many photos")
+	}
+
+	out := make([]domain.Photo, 0, len(files))
+	for _, f := range files {
+		p, err := s.storePhoto(ctx, adID, f)
+		if err != nil {
+			return out, errors.New(f.Filename + ": " + err.Error())
+		}
+		out = append(out, *p)
+	}
+	return out, nil
+}
+
+func (s *Service) storePhoto(ctx context.Context, adID int64, f PhotoUpload) (*domain.Photo, error) {
+	data, err := io.ReadAll(io.LimitReader(f.Data, MaxPhotoSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxPhotoSize {
+		return nil, errors.New("file too large")
+	}
+
+	// тип определяем по содержимому, а не по заголовку клиента
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedPhotoTypes[contentType]
+	if !ok {
+		return nil, errors.New("unsupported content type: " + contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid image")
+	}
+	if cfg.Width*cfg.Height > maxPhotoPixels {
+		return nil, errors.New("image resolution too large")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("invalid image")
+	}
+
+	// поворот снимка хранится в EXIF, который вырезаем ниже: применяем его к
+	// пикселям, иначе оригинал и превью окажутся лёжа или вверх ногами
+	if o := imaging.Orientation(data); o != 1 {
+		img = imaging.Orient(img, o)
+		if data, err = encodeOriginal(img, contentType); err != nil {
+			return nil, err
+		}
+	}
+
+	// оригинал раздаётся публично — EXIF с координатами съёмки в нём не нужен
+	data, err = imaging.StripMetadata(data)
+	if err != nil {
+		return nil, errors.New("invalid image")
+	}
+
+	prefix, err := photoKeyPrefix(adID)
+	if err != nil {
+		return nil, err
+	}
+	p := &domain.Photo{
+		AdID: adID,
+		Hash: imaging.DHash(img),
+		Keys: map[domain.PhotoVariant]string{
+			domain.PhotoOriginal: prefix + "original." + ext,
+			domain.PhotoMedium:   prefix + "medium.jpg",
+			domain.PhotoThumb:    prefix + "thumb.jpg",
+		},
+	}
+
+	if err := s.media.Put(ctx, p.Keys[domain.PhotoOriginal], bytes.NewReader(data), contentType); err != nil {
+		return nil, err
+	}
+	for variant, side := range map[domain.PhotoVariant]int{domain.PhotoMedium: mediumSide, domain.PhotoThumb: thumbSide} {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, imaging.Fit(img, side), &jpeg.Options{Quality: variantQuality}); err != nil {
+			return nil, err
+		}
+		if err := s.media.Put(ctx, p.Keys[variant], &buf, "image/jpeg"); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.repo.AddPhoto(ctx, p); err != nil {
+		s.deletePhotoFiles(ctx, p)
+		return nil, err
+	}
+	s.fillPhotoURLs(p)
+	return p, nil
+}
+
+func encodeOriginal(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: originalQuality})
+	}
+	return buf.Bytes(), err
+}
+
+func (s *Service) DeletePhoto(ctx context.Context, adID, sellerID, photoID int64) error {
+	if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+		return err
+	}
+	p, err := s.repo.DeletePhoto(ctx, adID, photoID)
+	if err != nil {
+		return err
+	}
+	s.deletePhotoFiles(ctx, p)
+	return nil
+}
+
+func (s *Service) ReorderPhotos(ctx context.Context, adID, sellerID int64, photoIDs []int64) error {
+	if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+		return err
+	}
+	return s.repo.ReorderPhotos(ctx, adID, photoIDs)
+}
+
+func (s *Service) SetCoverPhoto(ctx context.Context, adID, sellerID, photoID int64) error {
+	if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+		return err
+	}
+	return s.repo.SetCoverPhoto(ctx, adID, photoID)
+}
+
+func (s *Service) checkOwner(ctx context.Context, adID, sellerID int64) error {
+	_, err := s.ownedAd(ctx, adID, sellerID)
+	return err
+}
+
+// deletePhotoFiles — best effort: запись уже удалена, хвосты в хранилище не критичны.
+func (s *Service) deletePhotoFiles(ctx context.Context, p *domain.Photo) {
+	for _, key := range p.Keys {
+		_ = s.media.Delete(ctx, key)
+	}
+}
+
+func (s *Service) fillPhotoURLs(p *domain.Photo) {
+	p.URLs = make(map[domain.PhotoVariant]string, len(p.Keys))
+	for variant, key := range p.Keys {
+		p.URLs[variant] = s.media.URL(key)
+	}
+}
+
+// attachPhotos подгружает фото для набора объявлений одним запросом.
+func (s *Service) attachPhotos(ctx context.Context, ads []*domain.Ad) error {
+	ids := make([]int64, 0, len(ads))
+	for _, ad := range ads {
+		ids = append(ids, ad.ID)
+	}
+	byAd, err := s.repo.PhotosByAds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, ad := range ads {
+		ad.Photos = byAd[ad.ID]
+		for i := range ad.Photos {
+			s.fillPhotoURLs(&ad.Photos[i])
+		}
+	}
+	return nil
+}
+
+func photoKeyPrefix(adID int64) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "ads/" + strconv.FormatInt(adID, 10) + "/" + hex.EncodeToString(b) + "/", nil
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// memMedia — хранилище файлов в памяти.
+type memMedia map[string][]byte
+
+func (m memMedia) Put(_ context.Context, key string, r io.Reader, _ string) error {
+	data, err := io.ReadAll(r)
+	m[key] = data
+	return err
+}
+
+func (m memMedia) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m memMedia) URL(key string) string { return "/media/" + key }
+
+type photosRepo struct {
+	domain.Repository
+}
+
+func (photosRepo) AddPhoto(_ context.Context, p *domain.Photo) (int64, error) {
+	p.ID = 1
+	return p.ID, nil
+}
+
+// orientedJPEG — JPEG 40×20 (левая половина красная) с EXIF Orientation.
+func orientedJPEG(t *testing.T, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < 20 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var clean bytes.Buffer
+	if err := jpeg.Encode(&clean, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// TIFF little endian: IFD0 с одним тегом Orientation и координатами в «комментарии»
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00\x12\x01\x03\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00GPS")
+	binary.LittleEndian.PutUint16(tiff[18:], orientation)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	seg := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+
+	data := append([]byte{0xFF, 0xD8}, seg...)
+	data = append(data, payload...)
+	return append(data, clean.Bytes()[2:]...)
+}
+
+func TestStorePhotoAppliesOrientation(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation uint16
+		w, h        int
+		redAt       image.Point // где после поворота оказывается левая (красная) половина
+	}{
+		{name: "upright", orientation: 1, w: 40, h: 20, redAt: image.Pt(5, 10)},
+		{name: "rotate 90 cw", orientation: 6, w: 20, h: 40, redAt: image.Pt(10, 5)},
+		{name: "rotate 180", orientation: 3, w: 40, h: 20, redAt: image.Pt(35, 10)},
+		{name: "rotate 90 ccw", orientation: 8, w: 20, h: 40, redAt: image.Pt(10, 35)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			media := memMedia{}
+			s := &Service{repo: photosRepo{}, media: media}
+			p, err := s.storePhoto(context.Background(), 7, PhotoUpload{Filename: "car.jpg", Data: bytes.NewReader(orientedJPEG(t, tt.orientation))})
+			if err != nil {
+				t.Fatalf("storePhoto: %v", err)
+			}
+
+			for _, variant := range []domain.PhotoVariant{domain.PhotoOriginal, domain.PhotoMedium, domain.PhotoThumb} {
+				data := media[p.Keys[variant]]
+				if bytes.Contains(data, []byte("Exif")) || bytes.Contains(data, []byte("GPS")) {
+					t.Fatalf("%s: metadata left in stored file", variant)
+				}
+				img, err := jpeg.Decode(bytes.NewReader(data))
+				if err != nil {
+					t.Fatalf("%s: decode: %v", variant, err)
+				}
+				if b := img.Bounds(); b.Dx() != tt.w || b.Dy() != tt.h {
+					t.Fatalf("%s: size %dx%d, want %dx%d", variant, b.Dx(), b.Dy(), tt.w, tt.h)
+				}
+				if r, _, b, _ := img.At(tt.redAt.X, tt.redAt.Y).RGBA(); r < b {
+					t.Fatalf("%s: pixel at %v is not red", variant, tt.redAt)
+				}
+			}
+			if !strings.HasSuffix(p.Keys[domain.PhotoOriginal], "original.jpg") {
+				t.Fatalf("original key = %s", p.Keys[domain.PhotoOriginal])
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// BuyPromotion заказывает продвижение опубликованного объявления продавца.
+// Заказ создаётся в статусе pending и начинает действовать только после
+// оплаты (ActivatePromotion); сама оплата проходит вне модуля.
+func (s *Service) BuyPromotion(ctx context.Context, adID, sellerID int64, productCode string) (*domain.Promotion, error) {
+	product, err := domain.FindPromotionProduct(productCode)
+	if err != nil {
+		return nil, err
+	}
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if ad.Status != domain.AdPublished {
+		return nil, domain.ErrPromotionAdNotPublished
+	}
+
+	p := &domain.Promotion{
+		AdID:     ad.ID,
+		SellerID: sellerID,
+		Kind:     product.Kind,
+		Product:  product.Code,
+		Price:    product.Price,
+		Days:     product.Days,
+	}
+	if product.Kind == domain.PromotionPinCity {
+		if ad.City == "" {
+			return nil, errors.New("ad has no city to pin in")
+		}
+		p.City = ad.City
+	}
+	if err := s.repo.AddPromotion(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ActivatePromotion — подтверждение оплаты (биллинг или администратор):
+// продвижение получает срок и начинает действовать. Если объявление уже не
+// опубликовано, заказ остаётся pending и возвращается ErrPromotionAdNotPublished.
+func (s *Service) ActivatePromotion(ctx context.Context, id int64) (*domain.Promotion, error) {
+	return s.repo.ActivatePromotion(ctx, id)
+}
+
+func (s *Service) Promotions(ctx context.Context, adID, sellerID int64) ([]domain.Promotion, error) {
+	if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListPromotions(ctx, adID)
+}
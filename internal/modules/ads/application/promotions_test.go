@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// promotionsRepo сохраняет заказы в памяти.
+type promotionsRepo struct {
+	domain.Repository
+	ad    domain.Ad
+	added []domain.Promotion
+}
+
+func (r *promotionsRepo) Get(_ context.Context, _ int64) (*domain.Ad, error) {
+	ad := r.ad
+	return &ad, nil
+}
+
+func (r *promotionsRepo) AddPromotion(_ context.Context, p *domain.Promotion) error {
+	p.ID = int64(len(r.added) + 1)
+	p.Status = domain.PromotionPending
+	r.added = append(r.added, *p)
+	return nil
+}
+
+func TestBuyPromotionIsPending(t *testing.T) {
+	repo := &promotionsRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished, City: "Казань"}}
+	s := &Service{repo: repo}
+
+	p, err := s.BuyPromotion(context.Background(), 1, 7, "pin_city_14")
+	if err != nil {
+		t.Fatalf("BuyPromotion: %v", err)
+	}
+	if p.Status != domain.PromotionPending || p.StartsAt != nil || p.EndsAt != nil {
+		t.Fatalf("new promotion must wait for payment: %+v", p)
+	}
+	if p.Days != 14 || p.Price != 1500 || p.City != "Казань" || p.Kind != domain.PromotionPinCity {
+		t.Fatalf("promotion = %+v", p)
+	}
+}
+
+func TestBuyPromotionValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		ad      domain.Ad
+		seller  int64
+		product string
+	}{
+		{name: "unknown product", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished}, seller: 7, product: "nope"},
+		{name: "foreign ad", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished}, seller: 8, product: "top_3"},
+		{name: "not published", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdDraft}, seller: 7, product: "top_3"},
+		{name: "pin without city", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished}, seller: 7, product: "pin_city_7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &promotionsRepo{ad: tt.ad}
+			s := &Service{repo: repo}
+			if _, err := s.BuyPromotion(context.Background(), 1, tt.seller, tt.product); err == nil {
+				t.Fatal("expected error")
+			}
+			if len(repo.added) != 0 {
+				t.Fatal("promotion must not be saved")
+			}
+		})
+	}
+}
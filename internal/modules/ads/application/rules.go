@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+	"autera/pkg/vin"
+)
+
+const (
+	RuleVINChecksum      = "vin_checksum"
+	RuleVINMismatch      = "vin_mismatch"
+	RulePriceBelowMedian = "price_below_median"
+	RuleBannedWords      = "banned_words"
+	RuleYearRange        = "year_range"
+	RuleSellerDailyLimit = "seller_daily_limit"
+
+	// ruleEngineError — правила не отработали; объявление уходит модератору.
+	ruleEngineError = "engine_error"
+)
+
+// RulesConfig — настройки премодерации. Правила из RejectRules отклоняют
+// объявление автоматически, остальные сработавшие только помечают его.
+type RulesConfig struct {
+	AutoApprove      bool // публиковать без модератора, если ни одно правило не сработало
+	RejectRules      []string
+	DisabledRules    []string
+	BannedWords      []string
+	MinYear          int
+	MaxAdsPerDay     int
+	PriceMedianRatio float64 // цена ниже ratio*медианы — подозрительно
+	MinMedianSample  int     // меньше выборка — медиане не доверяем
+
+	// ComplaintThreshold — сколько жалоб разных пользователей возвращает
+	// опубликованное объявление на модерацию; 0 — не возвращать.
+	ComplaintThreshold int
+}
+
+func (c RulesConfig) enabled(rule string) bool {
+	for _, r := range c.DisabledRules {
+		if r == rule {
+			return false
+		}
+	}
+	return true
+}
+
+func (c RulesConfig) action(rule string) domain.RuleAction {
+	for _, r := range c.RejectRules {
+		if r == rule {
+			return domain.RuleReject
+		}
+	}
+	return domain.RuleFlag
+}
+
+// ruleRejectReasons — какой причиной из каталога закрывать автоотклонение.
+var ruleRejectReasons = map[string]domain.RejectionReason{
+	RuleVINChecksum:      domain.ReasonInvalidVIN,
+	RulePriceBelowMedian: domain.ReasonWrongPrice,
+	RuleBannedWords:      domain.ReasonProhibitedText,
+	RuleYearRange:        domain.ReasonIncompleteInfo,
+}
+
+type ruleCheck func(ctx context.Context, ad *domain.Ad) (msg string, fired bool, err error)
+
+func (s *Service) ruleChecks() map[string]ruleCheck {
+	return map[string]ruleCheck{
+		RuleVINChecksum:      s.checkVIN,
+		RuleVINMismatch:      s.checkVINMismatch,
+		RulePriceBelowMedian: s.checkPriceMedian,
+		RuleBannedWords:      s.checkBannedWords,
+		RuleYearRange:        s.checkYearRange,
+		RuleSellerDailyLimit: s.checkSellerDailyLimit,
+	}
+}
+
+// runRules прогоняет все включённые правила; ошибка правила превращается
+// в пометку, чтобы объявление не застряло.
+func (s *Service) runRules(ctx context.Context, ad *domain.Ad) []domain.RuleHit {
+	var hits []domain.RuleHit
+	checks := s.ruleChecks()
+	for _, name := range []string{RuleVINChecksum, RuleVINMismatch, RulePriceBelowMedian, RuleBannedWords, RuleYearRange, RuleSellerDailyLimit} {
+		if !s.rules.enabled(name) {
+			continue
+		}
+		msg, fired, err := checks[name](ctx, ad)
+		if err != nil {
+			hits = append(hits, domain.RuleHit{AdID: ad.ID, Rule: ruleEngineError, Action: domain.RuleFlag, Message: name + ": " + err.Error()})
+			continue
+		}
+		if fired {
+			hits = append(hits, domain.RuleHit{AdID: ad.ID, Rule: name, Action: s.rules.action(name), Message: msg})
+		}
+	}
+	return hits
+}
+
+// premoderate вызывается после перевода в moderation: сохраняет сработавшие
+// правила и подозрения на дубли и, если можно, принимает решение без модератора.
+// Объявление с подозрением на дубль автоматически не одобряется.
+func (s *Service) premoderate(ctx context.Context, ad *domain.Ad) error {
+	hits := s.runRules(ctx, ad)
+	if err := s.repo.SaveRuleHits(ctx, ad.ID, hits); err != nil {
+		return err
+	}
+	dups, err := s.detectDuplicates(ctx, ad.ID)
+	if err != nil {
+		return err
+	}
+
+	var rejects []string
+	reason := domain.ReasonOther
+	for _, h := range hits {
+		if h.Action == domain.RuleReject {
+			rejects = append(rejects, h.Message)
+			if rr, ok := ruleRejectReasons[h.Rule]; ok && len(rejects) == 1 {
+				reason = rr
+			}
+		}
+	}
+
+	switch {
+	case len(rejects) > 0:
+		return s.repo.Decide(ctx, &domain.ModerationDecision{
+			AdID:     ad.ID,
+			Decision: domain.DecisionReject,
+			Reason:   reason,
+			Comment:  strings.Join(rejects, "; "),
+		}, domain.AdRejected)
+	case len(hits) == 0 && dups == 0 && s.rules.AutoApprove:
+		return s.repo.Decide(ctx, &domain.ModerationDecision{
+			AdID:     ad.ID,
+			Decision: domain.DecisionApprove,
+		}, domain.AdPublished)
+	}
+	return nil
+}
+
+func (s *Service) checkVIN(_ context.Context, ad *domain.Ad) (string, bool, error) {
+	if ad.VIN == "" {
+		return "", false, nil
+	}
+	info, err := vin.Decode(vin.Normalize(ad.VIN))
+	if err != nil {
+		return "VIN " + ad.VIN + ": " + err.Error(), true, nil
+	}
+	// для не-NA VIN контрольная цифра не обязательна, но модератору это полезно знать
+	if !info.CheckDigitValid {
+		return "VIN " + ad.VIN + ": " + vin.ErrCheckDigit.Error(), true, nil
+	}
+	return "", false, nil
+}
+
+// checkVINMismatch — марка или год не совпадают с расшифровкой VIN.
+func (s *Service) checkVINMismatch(_ context.Context, ad *domain.Ad) (string, bool, error) {
+	msg := vinMismatch(ad.VIN, ad.Brand, ad.Year)
+	return msg, msg != "", nil
+}
+
+func (s *Service) checkPriceMedian(ctx context.Context, ad *domain.Ad) (string, bool, error) {
+	if s.rules.PriceMedianRatio <= 0 {
+		return "", false, nil
+	}
+	st, err := s.repo.PriceStats(ctx, ad.Brand, ad.Model, ad.Year-1, ad.Year+1)
+	if err != nil {
+		return "", false, err
+	}
+	if st.Sample < s.rules.MinMedianSample || st.Median <= 0 {
+		return "", false, nil
+	}
+	if float64(ad.Price) < s.rules.PriceMedianRatio*float64(st.Median) {
+		return fmt.Sprintf("price %d is below %.0f%% of model median %d (n=%d)",
+			ad.Price, s.rules.PriceMedianRatio*100, st.Median, st.Sample), true, nil
+	}
+	return "", false, nil
+}
+
+func (s *Service) checkBannedWords(_ context.Context, ad *domain.Ad) (string, bool, error) {
+	text := strings.ToLower(ad.Brand + " " + ad.Model + " " + ad.City + " " + ad.Description)
+	var found []string
+	for _, w := range s.rules.BannedWords {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w != "" && strings.Contains(text, w) {
+			found = append(found, w)
+		}
+	}
+	if len(found) > 0 {
+		return "banned words: " + strings.Join(found, ", "), true, nil
+	}
+	return "", false, nil
+}
+
+func (s *Service) checkYearRange(_ context.Context, ad *domain.Ad) (string, bool, error) {
+	maxYear := time.Now().Year() + 1 // модельный год может опережать календарный
+	if ad.Year < s.rules.MinYear || ad.Year > maxYear {
+		return fmt.Sprintf("year %d outside %d..%d", ad.Year, s.rules.MinYear, maxYear), true, nil
+	}
+	return "", false, nil
+}
+
+func (s *Service) checkSellerDailyLimit(ctx context.Context, ad *domain.Ad) (string, bool, error) {
+	if s.rules.MaxAdsPerDay <= 0 {
+		return "", false, nil
+	}
+	n, err := s.repo.CountSellerAdsSince(ctx, ad.SellerID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return "", false, err
+	}
+	if n > s.rules.MaxAdsPerDay {
+		return fmt.Sprintf("seller created %d ads in 24h (limit %d)", n, s.rules.MaxAdsPerDay), true, nil
+	}
+	return "", false, nil
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+)
+
+const (
+	matchQueueBatch   = 100
+	savedSearchBatch  = 500
+	notificationLimit = 100
+)
+
+func (s *Service) AddFavorite(ctx context.Context, userID, adID int64) error {
+	ad, err := s.repo.Get(ctx, adID)
+	if err != nil {
+		return errors.New("ad not found")
+	}
+	if ad.Status != domain.AdPublished {
+		return errors.New("only published ads can be added to favorites")
+	}
+	if err := s.repo.AddFavorite(ctx, userID, adID); err != nil {
+		return err
+	}
+	_ = s.trackAd(ad, domain.StatFavorite, "user:"+strconv.FormatInt(userID, 10))
+	return nil
+}
+
+func (s *Service) RemoveFavorite(ctx context.Context, userID, adID int64) error {
+	return s.repo.RemoveFavorite(ctx, userID, adID)
+}
+
+func (s *Service) Favorites(ctx context.Context, userID int64, limit, offset int) ([]domain.Ad, int64, error) {
+	items, total, err := s.repo.ListFavorites(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	ptrs := make([]*domain.Ad, 0, len(items))
+	for i := range items {
+		ptrs = append(ptrs, &items[i])
+	}
+	if err := s.attachPhotos(ctx, ptrs); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// SaveSearch сохраняет поиск покупателя. Марка сразу привязывается к справочнику,
+// чтобы матчер сравнивал по id, как витрина.
+func (s *Service) SaveSearch(ctx context.Context, userID int64, name string, q domain.SearchQuery, f domain.ListFilter) (*domain.SavedSearch, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name required")
+	}
+	if err := s.resolveFilter(ctx, &f); err != nil {
+		return nil, err
+	}
+
+	saved := &domain.SavedSearch{UserID: userID, Name: name, Query: q.Raw, Filter: f.Criteria()}
+	if _, err := s.repo.CreateSavedSearch(ctx, saved, domain.MaxSavedSearches); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *Service) SavedSearches(ctx context.Context, userID int64) ([]domain.SavedSearch, error) {
+	return s.repo.ListSavedSearches(ctx, userID)
+}
+
+func (s *Service) DeleteSavedSearch(ctx context.Context, id, userID int64) error {
+	return s.repo.DeleteSavedSearch(ctx, id, userID)
+}
+
+func (s *Service) Notifications(ctx context.Context, userID int64) ([]domain.Notification, error) {
+	return s.repo.ListNotifications(ctx, userID, notificationLimit)
+}
+
+// MatchSavedSearches разбирает очередь опубликованных объявлений и ставит
+// уведомления владельцам подходящих сохранённых поисков: каждый поиск
+// проверяется одним запросом сразу по всей пачке. Возвращает число уведомлений.
+func (s *Service) MatchSavedSearches(ctx context.Context) (int, error) {
+	ids, err := s.repo.QueuedForMatching(ctx, matchQueueBatch)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	var ns []domain.Notification
+	var after int64
+	for {
+		batch, err := s.repo.SavedSearchesAfter(ctx, after, savedSearchBatch)
+		if err != nil {
+			return 0, err
+		}
+		for _, ss := range batch {
+			matched, err := s.repo.MatchSavedSearch(ctx, ss, ids)
+			if err != nil {
+				return 0, err
+			}
+			for _, adID := range matched {
+				id := ss.ID
+				ns = append(ns, domain.Notification{
+					UserID:        ss.UserID,
+					Kind:          domain.NotificationSavedSearchMatch,
+					AdID:          adID,
+					SavedSearchID: &id,
+				})
+			}
+		}
+		if len(batch) < savedSearchBatch {
+			break
+		}
+		after = batch[len(batch)-1].ID
+	}
+
+	if err := s.repo.AddNotifications(ctx, ns); err != nil {
+		return 0, err
+	}
+	if err := s.repo.DequeueMatching(ctx, ids); err != nil {
+		return 0, err
+	}
+	return len(ns), nil
+}
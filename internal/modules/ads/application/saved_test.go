@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// matcherRepo: очередь, поиски и заранее известные совпадения по id поиска.
+type matcherRepo struct {
+	domain.Repository
+	queued   []int64
+	searches []domain.SavedSearch
+	matches  map[int64][]int64
+
+	calls    int
+	notified []domain.Notification
+	dequeued []int64
+}
+
+func (r *matcherRepo) QueuedForMatching(_ context.Context, _ int) ([]int64, error) {
+	return r.queued, nil
+}
+
+func (r *matcherRepo) SavedSearchesAfter(_ context.Context, after int64, limit int) ([]domain.SavedSearch, error) {
+	var out []domain.SavedSearch
+	for _, ss := range r.searches {
+		if ss.ID > after && len(out) < limit {
+			out = append(out, ss)
+		}
+	}
+	return out, nil
+}
+
+func (r *matcherRepo) MatchSavedSearch(_ context.Context, ss domain.SavedSearch, adIDs []int64) ([]int64, error) {
+	r.calls++
+	var out []int64
+	for _, id := range r.matches[ss.ID] {
+		if slices.Contains(adIDs, id) {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (r *matcherRepo) AddNotifications(_ context.Context, ns []domain.Notification) error {
+	r.notified = append(r.notified, ns...)
+	return nil
+}
+
+func (r *matcherRepo) DequeueMatching(_ context.Context, adIDs []int64) error {
+	r.dequeued = append(r.dequeued, adIDs...)
+	return nil
+}
+
+func TestMatchSavedSearchesBatch(t *testing.T) {
+	repo := &matcherRepo{
+		queued: []int64{10, 11, 12},
+		searches: []domain.SavedSearch{
+			{ID: 1, UserID: 100},
+			{ID: 2, UserID: 200},
+			{ID: 3, UserID: 300},
+		},
+		matches: map[int64][]int64{1: {10, 12}, 3: {11}},
+	}
+	s := &Service{repo: repo}
+
+	n, err := s.MatchSavedSearches(context.Background())
+	if err != nil {
+		t.Fatalf("MatchSavedSearches: %v", err)
+	}
+	// один запрос на сохранённый поиск, а не на пару «поиск × объявление»
+	if repo.calls != len(repo.searches) {
+		t.Fatalf("match queries = %d, want %d", repo.calls, len(repo.searches))
+	}
+	if n != 3 || len(repo.notified) != 3 {
+		t.Fatalf("notifications = %d (%+v), want 3", n, repo.notified)
+	}
+	for _, ntf := range repo.notified {
+		if ntf.Kind != domain.NotificationSavedSearchMatch || ntf.SavedSearchID == nil {
+			t.Fatalf("bad notification %+v", ntf)
+		}
+		if ntf.UserID != *ntf.SavedSearchID*100 {
+			t.Fatalf("notification for wrong user: %+v", ntf)
+		}
+	}
+	if !slices.Equal(repo.dequeued, repo.queued) {
+		t.Fatalf("dequeued = %v, want %v", repo.dequeued, repo.queued)
+	}
+}
+
+func TestMatchSavedSearchesEmptyQueue(t *testing.T) {
+	repo := &matcherRepo{searches: []domain.SavedSearch{{ID: 1, UserID: 100}}}
+	s := &Service{repo: repo}
+
+	n, err := s.MatchSavedSearches(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("MatchSavedSearches = %d, %v", n, err)
+	}
+	if repo.calls != 0 || repo.dequeued != nil {
+		t.Fatal("empty queue must not query saved searches")
+	}
+}
+
+func TestSaveSearchRequiresName(t *testing.T) {
+	s := &Service{repo: &matcherRepo{}}
+	if _, err := s.SaveSearch(context.Background(), 1, "  ", domain.SearchQuery{}, domain.ListFilter{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// SellerAds — кабинет продавца: страница объявлений и счётчики по статусам
+// (счётчики не зависят от фильтра статуса, чтобы рисовать вкладки).
+func (s *Service) SellerAds(ctx context.Context, f domain.SellerAdsFilter) ([]domain.SellerAd, int64, map[domain.AdStatus]int64, error) {
+	items, total, err := s.repo.SellerAds(ctx, f)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	counts, err := s.repo.SellerStatusCounts(ctx, f.SellerID)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	ptrs := make([]*domain.Ad, 0, len(items))
+	for i := range items {
+		ptrs = append(ptrs, &items[i].Ad)
+	}
+	if err := s.attachPhotos(ctx, ptrs); err != nil {
+		return nil, 0, nil, err
+	}
+	return items, total, counts, nil
+}
@@ -4,15 +4,33 @@ import (
 	"context"
 
 	"autera/internal/modules/ads/domain"
+	"autera/pkg/events"
+	"autera/pkg/storage"
 )
 
 type Service struct {
-	repo domain.Repository
+	repo     domain.Repository
+	media    storage.MediaStorage
+	catalog  Catalog
+	accounts Accounts
+	events   *events.Bus
+	rules    RulesConfig
+	similar  SimilarConfig
+	stats    *statsBuffer
+	sitemap  *sitemapCache
 }
 
-func NewService(repo domain.Repository) *Service {
+func NewService(repo domain.Repository, media storage.MediaStorage, catalog Catalog, accounts Accounts, bus *events.Bus, rules RulesConfig, similar SimilarConfig) *Service {
 	return &Service{
-		repo: repo,
+		repo:     repo,
+		media:    media,
+		catalog:  catalog,
+		accounts: accounts,
+		events:   bus,
+		rules:    rules,
+		similar:  similar,
+		stats:    newStatsBuffer(),
+		sitemap:  newSitemapCache(),
 	}
 }
 
@@ -25,9 +43,33 @@ type CreateAdInput struct {
 	Price    int    `json:"price"`
 	VIN      string `json:"vin"`
 	City     string `json:"city"`
+
+	Description string `json:"description"`
+
+	Spec SpecInput `json:"spec"`
 }
 
 func (s *Service) Create(ctx context.Context, in CreateAdInput) (int64, error) {
+	ad, err := s.newAd(ctx, in)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.Create(ctx, ad)
+	if err != nil {
+		return 0, err
+	}
+	// best effort: при отправке на модерацию дубли ищутся повторно, уже с фото
+	_, _ = s.detectDuplicates(ctx, id)
+	return id, nil
+}
+
+// newAd проверяет ввод и собирает черновик объявления.
+func (s *Service) newAd(ctx context.Context, in CreateAdInput) (*domain.Ad, error) {
+	spec, err := s.buildSpec(ctx, in.Spec)
+	if err != nil {
+		return nil, err
+	}
+
 	ad := &domain.Ad{
 		SellerID:        in.SellerID,
 		Brand:           in.Brand,
@@ -35,26 +77,89 @@ func (s *Service) Create(ctx context.Context, in CreateAdInput) (int64, error) {
 		Year:            in.Year,
 		Mileage:         in.Mileage,
 		Price:           in.Price,
-		VIN:             in.VIN,
 		City:            in.City,
+		Description:     in.Description,
+		Spec:            spec,
 		Status:          domain.AdDraft,
 		InspectionState: domain.InspectionNone,
 	}
-	return s.repo.Create(ctx, ad)
+	// сначала справочник: VIN сверяется уже с каноническим названием марки
+	if err := s.applyCatalog(ctx, ad); err != nil {
+		return nil, err
+	}
+	brand, year := ad.Brand, ad.Year
+	if ad.VIN, err = resolveVIN(in.VIN, &ad.Brand, &ad.Year); err != nil {
+		return nil, err
+	}
+	if ad.Brand != brand || ad.Year != year {
+		// марку или год подставил VIN — привязываем заново
+		if err := s.applyCatalog(ctx, ad); err != nil {
+			return nil, err
+		}
+	}
+	return ad, nil
 }
 
 func (s *Service) Get(ctx context.Context, id int64) (*domain.Ad, error) {
-	return s.repo.Get(ctx, id)
+	ad, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachPhotos(ctx, []*domain.Ad{ad}); err != nil {
+		return nil, err
+	}
+	return ad, nil
+}
+
+func (s *Service) PriceHistory(ctx context.Context, adID int64) ([]domain.PriceChange, error) {
+	return s.repo.PriceHistory(ctx, adID)
 }
 
 func (s *Service) List(ctx context.Context, f domain.ListFilter) ([]domain.Ad, int64, error) {
-	return s.repo.List(ctx, f)
+	if err := s.resolveFilter(ctx, &f); err != nil {
+		return nil, 0, err
+	}
+	items, total, err := s.repo.List(ctx, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	ptrs := make([]*domain.Ad, 0, len(items))
+	for i := range items {
+		ptrs = append(ptrs, &items[i])
+	}
+	if err := s.attachPhotos(ctx, ptrs); err != nil {
+		return nil, 0, err
+	}
+	if err := s.assessPrices(ctx, ptrs); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
 }
 
-func (s *Service) SubmitToModeration(ctx context.Context, adID, sellerID int64) error {
-	return s.repo.SubmitToModeration(ctx, adID, sellerID)
+func (s *Service) Search(ctx context.Context, q domain.SearchQuery, f domain.ListFilter) ([]domain.SearchHit, int64, error) {
+	if err := s.resolveFilter(ctx, &f); err != nil {
+		return nil, 0, err
+	}
+	hits, total, err := s.repo.Search(ctx, q, f)
+	if err != nil {
+		return nil, 0, err
+	}
+	ptrs := make([]*domain.Ad, 0, len(hits))
+	for i := range hits {
+		ptrs = append(ptrs, &hits[i].Ad)
+	}
+	if err := s.attachPhotos(ctx, ptrs); err != nil {
+		return nil, 0, err
+	}
+	if err := s.assessPrices(ctx, ptrs); err != nil {
+		return nil, 0, err
+	}
+	return hits, total, nil
 }
 
-func (s *Service) Moderate(ctx context.Context, adID int64, decision string) error {
-	return s.repo.Moderate(ctx, adID, decision)
+func (s *Service) Facets(ctx context.Context, q domain.SearchQuery, f domain.ListFilter) (*domain.Facets, error) {
+	if err := s.resolveFilter(ctx, &f); err != nil {
+		return nil, err
+	}
+	return s.repo.Facets(ctx, q, f)
 }
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+
+	"autera/internal/modules/ads/domain"
+)
+
+const (
+	DefaultSimilarLimit = 6
+	MaxSimilarLimit     = 20
+)
+
+// SimilarConfig — настройки рекомендаций «похожие объявления».
+type SimilarConfig struct {
+	Query   domain.SimilarQuery
+	Weights domain.SimilarWeights
+}
+
+// Similar возвращает до limit опубликованных объявлений, похожих на adID.
+func (s *Service) Similar(ctx context.Context, adID int64, limit int) ([]domain.Ad, error) {
+	base, err := s.repo.Get(ctx, adID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultSimilarLimit
+	}
+	limit = min(limit, MaxSimilarLimit)
+
+	q := s.similar.Query
+	q.Candidates = max(q.Candidates, limit)
+	cands, err := s.repo.SimilarCandidates(ctx, base, q)
+	if err != nil {
+		return nil, err
+	}
+	items := s.similar.Weights.RankSimilar(base, cands, q, limit)
+
+	ptrs := make([]*domain.Ad, 0, len(items))
+	for i := range items {
+		ptrs = append(ptrs, &items[i])
+	}
+	if err := s.attachPhotos(ctx, ptrs); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// SpecInput — характеристики автомобиля во входных данных продавца.
+type SpecInput struct {
+	Transmission   string `json:"transmission"`
+	Fuel           string `json:"fuel"`
+	Drive          string `json:"drive"`
+	BodyType       string `json:"body_type"` // код, название или синоним из справочника
+	EngineVolume   *int   `json:"engine_volume"`
+	Color          string `json:"color"`
+	Steering       string `json:"steering"`
+	CustomsCleared *bool  `json:"customs_cleared"`
+}
+
+// SpecPatch — частичное обновление характеристик: nil — «не менять»,
+// пустая строка и engine_volume = 0 — «не указано».
+type SpecPatch struct {
+	Transmission   *string `json:"transmission"`
+	Fuel           *string `json:"fuel"`
+	Drive          *string `json:"drive"`
+	BodyType       *string `json:"body_type"`
+	EngineVolume   *int    `json:"engine_volume"`
+	Color          *string `json:"color"`
+	Steering       *string `json:"steering"`
+	CustomsCleared *bool   `json:"customs_cleared"`
+}
+
+// patch заменяет все характеристики значениями in (фид присылает их целиком);
+// не переданный признак растаможки остаётся прежним.
+func (in SpecInput) patch() *SpecPatch {
+	volume := 0
+	if in.EngineVolume != nil {
+		volume = *in.EngineVolume
+	}
+	return &SpecPatch{
+		Transmission:   &in.Transmission,
+		Fuel:           &in.Fuel,
+		Drive:          &in.Drive,
+		BodyType:       &in.BodyType,
+		EngineVolume:   &volume,
+		Color:          &in.Color,
+		Steering:       &in.Steering,
+		CustomsCleared: in.CustomsCleared,
+	}
+}
+
+// mergeSpec накладывает patch на текущие характеристики и проверяет результат
+// целиком (например, объём двигателя при смене топлива на электро).
+func (s *Service) mergeSpec(ctx context.Context, cur domain.Spec, p SpecPatch) (domain.Spec, error) {
+	in := SpecInput{
+		Transmission:   string(cur.Transmission),
+		Fuel:           string(cur.Fuel),
+		Drive:          string(cur.Drive),
+		EngineVolume:   cur.EngineVolume,
+		Color:          cur.Color,
+		Steering:       string(cur.Steering),
+		CustomsCleared: cur.CustomsCleared,
+	}
+	set := func(dst *string, v *string) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+	set(&in.Transmission, p.Transmission)
+	set(&in.Fuel, p.Fuel)
+	set(&in.Drive, p.Drive)
+	set(&in.BodyType, p.BodyType)
+	set(&in.Color, p.Color)
+	set(&in.Steering, p.Steering)
+	if p.EngineVolume != nil {
+		in.EngineVolume = p.EngineVolume
+		if *p.EngineVolume == 0 {
+			in.EngineVolume = nil
+		}
+	}
+	if p.CustomsCleared != nil {
+		in.CustomsCleared = p.CustomsCleared
+	}
+
+	spec, err := s.buildSpec(ctx, in)
+	if err != nil {
+		return spec, err
+	}
+	// тип кузова уже хранится кодом справочника — повторно не сопоставляем
+	if p.BodyType == nil {
+		spec.BodyType = cur.BodyType
+	}
+	return spec, nil
+}
+
+// buildSpec нормализует и проверяет характеристики; тип кузова
+// сопоставляется со справочником catalog.
+func (s *Service) buildSpec(ctx context.Context, in SpecInput) (domain.Spec, error) {
+	norm := func(v string) string { return strings.ToLower(strings.TrimSpace(v)) }
+	spec := domain.Spec{
+		Transmission:   domain.Transmission(norm(in.Transmission)),
+		Fuel:           domain.Fuel(norm(in.Fuel)),
+		Drive:          domain.Drive(norm(in.Drive)),
+		EngineVolume:   in.EngineVolume,
+		Color:          norm(in.Color),
+		Steering:       domain.Steering(norm(in.Steering)),
+		CustomsCleared: in.CustomsCleared,
+	}
+	if err := spec.Validate(); err != nil {
+		return spec, err
+	}
+
+	if body := strings.TrimSpace(in.BodyType); body != "" {
+		if s.catalog == nil {
+			spec.BodyType = norm(body)
+			return spec, nil
+		}
+		code, err := s.catalog.BodyType(ctx, body)
+		if err != nil {
+			return spec, err
+		}
+		if code == "" {
+			return spec, domain.ErrUnknownBodyType
+		}
+		spec.BodyType = code
+	}
+	return spec, nil
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func TestMergeSpec(t *testing.T) {
+	volume, cleared := 2000, true
+	// "sedan" fakeCatalog не знает: если тип кузова не трогают, справочник не спрашивается
+	cur := domain.Spec{
+		Transmission:   domain.TransmissionAutomatic,
+		Fuel:           domain.FuelPetrol,
+		BodyType:       "sedan",
+		EngineVolume:   &volume,
+		Color:          "black",
+		Steering:       domain.SteeringLeft,
+		CustomsCleared: &cleared,
+	}
+	str := func(v string) *string { return &v }
+	num := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		patch   SpecPatch
+		check   func(t *testing.T, got domain.Spec)
+		wantErr bool
+	}{
+		{
+			name:  "only color",
+			patch: SpecPatch{Color: str(" White ")},
+			check: func(t *testing.T, got domain.Spec) {
+				want := cur
+				want.Color = "white"
+				if got.Color != want.Color || got.Transmission != want.Transmission || got.BodyType != "sedan" ||
+					got.EngineVolume == nil || *got.EngineVolume != 2000 || got.CustomsCleared == nil || !*got.CustomsCleared {
+					t.Fatalf("spec = %+v", got)
+				}
+			},
+		},
+		{
+			name:  "body type through catalog",
+			patch: SpecPatch{BodyType: str("Внедорожник")},
+			check: func(t *testing.T, got domain.Spec) {
+				if got.BodyType != "suv" || got.Color != "black" {
+					t.Fatalf("spec = %+v", got)
+				}
+			},
+		},
+		{
+			name:  "clear field",
+			patch: SpecPatch{Steering: str("")},
+			check: func(t *testing.T, got domain.Spec) {
+				if got.Steering != "" || got.Fuel != domain.FuelPetrol {
+					t.Fatalf("spec = %+v", got)
+				}
+			},
+		},
+		{
+			name:  "electric with volume cleared",
+			patch: SpecPatch{Fuel: str("electric"), EngineVolume: num(0)},
+			check: func(t *testing.T, got domain.Spec) {
+				if got.Fuel != domain.FuelElectric || got.EngineVolume != nil {
+					t.Fatalf("spec = %+v", got)
+				}
+			},
+		},
+		{name: "electric keeps stored volume", patch: SpecPatch{Fuel: str("electric")}, wantErr: true},
+		{name: "invalid value", patch: SpecPatch{Drive: str("4x4")}, wantErr: true},
+		{name: "unknown body type", patch: SpecPatch{BodyType: str("танк")}, wantErr: true},
+	}
+	s := &Service{catalog: fakeCatalog{}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.mergeSpec(context.Background(), cur, tt.patch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestUpdateSpecPatchRevisions(t *testing.T) {
+	repo := &updateRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdDraft,
+		Spec: domain.Spec{Fuel: domain.FuelDiesel, Color: "black", Steering: domain.SteeringRight}}}
+	s := &Service{repo: repo}
+
+	color := "red"
+	if _, err := s.Update(context.Background(), 1, 7, UpdateAdInput{Spec: &SpecPatch{Color: &color}}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(repo.revs) != 1 || repo.revs[0].Field != "spec.color" || repo.revs[0].NewValue != "red" {
+		t.Fatalf("revs = %+v", repo.revs)
+	}
+	if repo.saved.Spec.Fuel != domain.FuelDiesel || repo.saved.Spec.Steering != domain.SteeringRight {
+		t.Fatalf("spec = %+v", repo.saved.Spec)
+	}
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+const (
+	// maxBufferedHits — при переполнении новые события отбрасываются,
+	// чтобы сбой БД не съел память.
+	maxBufferedHits = 100_000
+	statsFlushBatch = 1000
+
+	MaxStatsPeriod = 90 * 24 * time.Hour
+)
+
+// ErrAdNotPublished — события принимаются только по опубликованным объявлениям.
+var ErrAdNotPublished = errors.New("ad is not published")
+
+// statsBuffer копит события между сбросами; повторы с того же устройства
+// за день схлопываются уже здесь.
+type statsBuffer struct {
+	mu   sync.Mutex
+	hits map[domain.StatHit]struct{}
+}
+
+func newStatsBuffer() *statsBuffer {
+	return &statsBuffer{hits: make(map[domain.StatHit]struct{})}
+}
+
+func (b *statsBuffer) add(h domain.StatHit) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.hits) < maxBufferedHits {
+		b.hits[h] = struct{}{}
+	}
+}
+
+func (b *statsBuffer) take() []domain.StatHit {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]domain.StatHit, 0, len(b.hits))
+	for h := range b.hits {
+		out = append(out, h)
+	}
+	b.hits = make(map[domain.StatHit]struct{})
+	return out
+}
+
+// Track учитывает событие клиента по объявлению; запись в БД — при FlushStats.
+func (s *Service) Track(ctx context.Context, adID int64, event domain.StatEvent, device string) error {
+	if !domain.OneOf(string(event), domain.StatEvents) {
+		return errors.New("invalid event")
+	}
+	if adID <= 0 || device == "" {
+		return errors.New("ad and device required")
+	}
+	ad, err := s.repo.Get(ctx, adID)
+	if err != nil {
+		return err
+	}
+	return s.trackAd(ad, event, device)
+}
+
+// TrackView учитывает просмотр уже загруженной карточки объявления.
+func (s *Service) TrackView(ad *domain.Ad, device string) error {
+	return s.trackAd(ad, domain.StatView, device)
+}
+
+func (s *Service) trackAd(ad *domain.Ad, event domain.StatEvent, device string) error {
+	if ad.Status != domain.AdPublished {
+		return ErrAdNotPublished
+	}
+	s.stats.add(domain.NewStatHit(ad.ID, event, device, time.Now()))
+	return nil
+}
+
+// FlushStats пишет накопленные события пачками. Не записанное из-за ошибки
+// возвращается в буфер до следующего сброса.
+func (s *Service) FlushStats(ctx context.Context) (int, error) {
+	hits := s.stats.take()
+	for i := 0; i < len(hits); i += statsFlushBatch {
+		batch := hits[i:min(i+statsFlushBatch, len(hits))]
+		if err := s.repo.AddStatHits(ctx, batch); err != nil {
+			for _, h := range hits[i:] {
+				s.stats.add(h)
+			}
+			return i, err
+		}
+	}
+
+	// вчерашние устройства ещё нужны для событий, сброшенных после полуночи
+	if _, err := s.repo.PurgeStatDevices(ctx, time.Now().AddDate(0, 0, -1)); err != nil {
+		return len(hits), err
+	}
+	return len(hits), nil
+}
+
+// SellerStats — дневная статистика и конверсии по объявлениям продавца.
+func (s *Service) SellerStats(ctx context.Context, sellerID int64, from, to time.Time) ([]domain.AdStats, error) {
+	if to.Before(from) {
+		return nil, errors.New("invalid period")
+	}
+	if to.Sub(from) > MaxStatsPeriod {
+		return nil, errors.New("period too long")
+	}
+	items, err := s.repo.SellerStats(ctx, sellerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Complete(from, to)
+	}
+	return items, nil
+}
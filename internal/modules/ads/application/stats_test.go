@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// statsRepo — объявления в памяти по id.
+type statsRepo struct {
+	domain.Repository
+	ads map[int64]domain.Ad
+}
+
+func (r *statsRepo) Get(_ context.Context, id int64) (*domain.Ad, error) {
+	ad, ok := r.ads[id]
+	if !ok {
+		return nil, errors.New("ad not found")
+	}
+	return &ad, nil
+}
+
+func TestTrackOnlyPublished(t *testing.T) {
+	repo := &statsRepo{ads: map[int64]domain.Ad{
+		1: {ID: 1, Status: domain.AdPublished},
+		2: {ID: 2, Status: domain.AdDraft},
+		3: {ID: 3, Status: domain.AdSold},
+	}}
+	tests := []struct {
+		name    string
+		adID    int64
+		event   domain.StatEvent
+		wantErr error
+		wantHit bool
+	}{
+		{name: "published", adID: 1, event: domain.StatPhone, wantHit: true},
+		{name: "draft", adID: 2, event: domain.StatPhone, wantErr: ErrAdNotPublished},
+		{name: "sold", adID: 3, event: domain.StatReport, wantErr: ErrAdNotPublished},
+		{name: "missing", adID: 404, event: domain.StatPhone, wantErr: errors.New("any")},
+		{name: "unknown event", adID: 1, event: "click", wantErr: errors.New("any")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{repo: repo, stats: newStatsBuffer()}
+			err := s.Track(context.Background(), tt.adID, tt.event, "h:device")
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Track: %v", err)
+			case tt.wantErr != nil && err == nil:
+				t.Fatal("expected error")
+			case errors.Is(tt.wantErr, ErrAdNotPublished) && !errors.Is(err, ErrAdNotPublished):
+				t.Fatalf("err = %v, want ErrAdNotPublished", err)
+			}
+			if got := len(s.stats.take()) == 1; got != tt.wantHit {
+				t.Fatalf("hit buffered = %v, want %v", got, tt.wantHit)
+			}
+		})
+	}
+}
+
+func TestTrackViewDedup(t *testing.T) {
+	s := &Service{stats: newStatsBuffer()}
+	ad := &domain.Ad{ID: 1, Status: domain.AdPublished}
+	for _, device := range []string{"h:a", "h:a", "h:b"} {
+		if err := s.TrackView(ad, device); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits := s.stats.take(); len(hits) != 2 {
+		t.Fatalf("hits = %+v, want 2 devices", hits)
+	}
+}
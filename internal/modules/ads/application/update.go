@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// UpdateAdInput — частичное обновление: nil означает «не менять».
+type UpdateAdInput struct {
+	Brand       *string `json:"brand"`
+	Model       *string `json:"model"`
+	Year        *int    `json:"year"`
+	Mileage     *int    `json:"mileage"`
+	Price       *int    `json:"price"`
+	VIN         *string `json:"vin"`
+	City        *string `json:"city"`
+	Description *string `json:"description"`
+
+	// Spec меняет только переданные характеристики.
+	Spec *SpecPatch `json:"spec"`
+}
+
+type changeSet struct {
+	revs    []domain.Revision
+	actorID int64
+}
+
+func (c *changeSet) str(field string, dst *string, v *string) {
+	if v == nil || *v == *dst {
+		return
+	}
+	c.revs = append(c.revs, domain.Revision{Field: field, OldValue: *dst, NewValue: *v, ActorID: c.actorID})
+	*dst = *v
+}
+
+func (c *changeSet) num(field string, dst *int, v *int) {
+	if v == nil || *v == *dst {
+		return
+	}
+	c.revs = append(c.revs, domain.Revision{Field: field, OldValue: strconv.Itoa(*dst), NewValue: strconv.Itoa(*v), ActorID: c.actorID})
+	*dst = *v
+}
+
+// spec пишет ревизию на каждую изменённую характеристику.
+func (c *changeSet) spec(dst *domain.Spec, v *domain.Spec) {
+	if v == nil {
+		return
+	}
+	old, next := dst.Fields(), v.Fields()
+	for _, field := range slices.Sorted(maps.Keys(next)) {
+		if old[field] != next[field] {
+			c.revs = append(c.revs, domain.Revision{Field: field, OldValue: old[field], NewValue: next[field], ActorID: c.actorID})
+		}
+	}
+	*dst = *v
+}
+
+func (c *changeSet) significant() bool {
+	for _, rv := range c.revs {
+		if domain.SignificantFields[rv.Field] {
+			return true
+		}
+	}
+	return false
+}
+
+// Update меняет объявление владельца и пишет ревизию на каждое изменённое поле.
+// Смена цены попадает в историю цен и публикуется событием PriceChanged.
+// Опубликованное объявление со сменой VIN/года/пробега уходит на повторную модерацию.
+// На модерации объявление не редактируется: модератор проверяет ровно то, что видит.
+func (s *Service) Update(ctx context.Context, adID, sellerID int64, in UpdateAdInput) (*domain.Ad, error) {
+	ad, err := s.ownedAd(ctx, adID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	if ad.Status == domain.AdSold || ad.Status == domain.AdArchived || ad.Status == domain.AdModeration {
+		return nil, errors.New("ad cannot be edited in status " + string(ad.Status))
+	}
+	if (in.Price != nil && *in.Price <= 0) || (in.Mileage != nil && *in.Mileage < 0) {
+		return nil, errors.New("invalid price or mileage")
+	}
+
+	var spec *domain.Spec
+	if in.Spec != nil {
+		sp, err := s.mergeSpec(ctx, ad.Spec, *in.Spec)
+		if err != nil {
+			return nil, err
+		}
+		spec = &sp
+	}
+
+	// марку/модель приводим к справочнику до записи ревизий
+	if in.Brand != nil || in.Model != nil || in.Year != nil {
+		next := *ad
+		if in.Brand != nil {
+			next.Brand = *in.Brand
+		}
+		if in.Model != nil {
+			next.Model = *in.Model
+		}
+		if in.Year != nil {
+			next.Year = *in.Year
+		}
+		if err := s.applyCatalog(ctx, &next); err != nil {
+			return nil, err
+		}
+		if in.Brand != nil {
+			in.Brand = &next.Brand
+		}
+		if in.Model != nil {
+			in.Model = &next.Model
+		}
+		ad.BrandID, ad.ModelID, ad.GenerationID = next.BrandID, next.ModelID, next.GenerationID
+	}
+
+	// VIN проверяется после справочника, как и при создании
+	if in.VIN != nil {
+		brand, year := ad.Brand, ad.Year
+		if in.Brand != nil {
+			brand = *in.Brand
+		}
+		if in.Year != nil {
+			year = *in.Year
+		}
+		v, err := resolveVIN(*in.VIN, &brand, &year)
+		if err != nil {
+			return nil, err
+		}
+		in.VIN = &v
+	}
+
+	oldPrice := ad.Price
+	c := &changeSet{actorID: sellerID}
+	c.str("brand", &ad.Brand, in.Brand)
+	c.str("model", &ad.Model, in.Model)
+	c.num("year", &ad.Year, in.Year)
+	c.num("mileage", &ad.Mileage, in.Mileage)
+	c.num("price", &ad.Price, in.Price)
+	c.str("vin", &ad.VIN, in.VIN)
+	c.str("city", &ad.City, in.City)
+	c.str("description", &ad.Description, in.Description)
+	c.spec(&ad.Spec, spec)
+
+	if len(c.revs) == 0 {
+		return ad, nil
+	}
+
+	from := ad.Status
+	remoderate := ad.Status == domain.AdPublished && c.significant() && canTransition(ad.Status, domain.AdModeration)
+	if remoderate {
+		moderation := string(domain.AdModeration)
+		status := string(ad.Status)
+		c.str("status", &status, &moderation)
+		ad.Status = domain.AdStatus(status)
+	}
+
+	if err := s.repo.Update(ctx, ad, from, c.revs); err != nil {
+		return nil, err
+	}
+	if ad.Price != oldPrice {
+		s.events.Publish(ctx, domain.PriceChanged{
+			AdID: ad.ID, SellerID: ad.SellerID,
+			OldPrice: oldPrice, NewPrice: ad.Price,
+			ChangedAt: time.Now(),
+		})
+	}
+	if remoderate {
+		// правка уже сохранена и объявление в очереди модерации; без
+		// автопроверки его разберёт модератор, ошибкой правку не отменить
+		_ = s.premoderate(ctx, ad)
+	}
+	return ad, nil
+}
+
+// Revisions — история изменений; продавцу только по своим объявлениям.
+// sellerID == 0 — доступ администратора.
+func (s *Service) Revisions(ctx context.Context, adID, sellerID int64) ([]domain.Revision, error) {
+	if sellerID != 0 {
+		if err := s.checkOwner(ctx, adID, sellerID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.ListRevisions(ctx, adID)
+}
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// updateRepo запоминает, с каким ожидаемым статусом сохранялось объявление.
+type updateRepo struct {
+	domain.Repository
+	ad    domain.Ad
+	from  domain.AdStatus
+	saved *domain.Ad
+	revs  []domain.Revision
+
+	ruleHitsErr error
+}
+
+func (r *updateRepo) Get(_ context.Context, _ int64) (*domain.Ad, error) {
+	ad := r.ad
+	return &ad, nil
+}
+
+func (r *updateRepo) Update(_ context.Context, ad *domain.Ad, from domain.AdStatus, revs []domain.Revision) error {
+	r.saved, r.from, r.revs = ad, from, revs
+	return nil
+}
+
+func (r *updateRepo) SaveRuleHits(_ context.Context, _ int64, _ []domain.RuleHit) error {
+	return r.ruleHitsErr
+}
+
+func (r *updateRepo) FindDuplicates(_ context.Context, _ int64) ([]domain.Duplicate, error) {
+	return nil, nil
+}
+
+func (r *updateRepo) SaveDuplicates(_ context.Context, _ int64, _ []domain.Duplicate) error {
+	return nil
+}
+
+func TestUpdatePassesLoadedStatus(t *testing.T) {
+	repo := &updateRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished, Description: "old"}}
+	s := &Service{repo: repo}
+
+	desc := "new"
+	if _, err := s.Update(context.Background(), 1, 7, UpdateAdInput{Description: &desc}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if repo.from != domain.AdPublished {
+		t.Fatalf("from = %q, want published", repo.from)
+	}
+	if repo.saved.Status != domain.AdPublished {
+		t.Fatalf("status = %q, want published", repo.saved.Status)
+	}
+	if len(repo.revs) != 1 || repo.revs[0].Field != "description" || repo.revs[0].ActorID != 7 {
+		t.Fatalf("revs = %+v", repo.revs)
+	}
+}
+
+func TestUpdateNoChanges(t *testing.T) {
+	repo := &updateRepo{ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdDraft, Price: 100}}
+	s := &Service{repo: repo}
+
+	price := 100
+	if _, err := s.Update(context.Background(), 1, 7, UpdateAdInput{Price: &price}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatal("unchanged ad must not be saved")
+	}
+}
+
+func TestUpdateRejectsForeignAndClosed(t *testing.T) {
+	tests := []struct {
+		name   string
+		ad     domain.Ad
+		seller int64
+	}{
+		{name: "foreign ad", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdDraft}, seller: 8},
+		{name: "sold", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdSold}, seller: 7},
+		{name: "archived", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdArchived}, seller: 7},
+		{name: "moderation", ad: domain.Ad{ID: 1, SellerID: 7, Status: domain.AdModeration}, seller: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &updateRepo{ad: tt.ad}
+			s := &Service{repo: repo}
+			desc := "x"
+			if _, err := s.Update(context.Background(), 1, tt.seller, UpdateAdInput{Description: &desc}); err == nil {
+				t.Fatal("expected error")
+			}
+			if repo.saved != nil {
+				t.Fatal("ad must not be saved")
+			}
+		})
+	}
+}
+
+func TestUpdateRemoderation(t *testing.T) {
+	tests := []struct {
+		name        string
+		ruleHitsErr error
+	}{
+		{name: "premoderation ok"},
+		{name: "premoderation failed", ruleHitsErr: errors.New("db down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &updateRepo{
+				ad:          domain.Ad{ID: 1, SellerID: 7, Status: domain.AdPublished, Mileage: 1000},
+				ruleHitsErr: tt.ruleHitsErr,
+			}
+			s := &Service{repo: repo, rules: allRulesOff}
+
+			mileage := 2000
+			ad, err := s.Update(context.Background(), 1, 7, UpdateAdInput{Mileage: &mileage})
+			// правка уже сохранена: сбой премодерации её не отменяет
+			if err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			if ad == nil || ad.Status != domain.AdModeration || repo.saved == nil || repo.from != domain.AdPublished {
+				t.Fatalf("ad = %+v, saved = %+v, from = %q", ad, repo.saved, repo.from)
+			}
+		})
+	}
+}
+
+func TestChangeSetSignificant(t *testing.T) {
+	ad := domain.Ad{City: "Москва", Mileage: 1000}
+	city, mileage := "Казань", 2000
+
+	c := &changeSet{actorID: 1}
+	c.str("city", &ad.City, &city)
+	if c.significant() {
+		t.Fatal("city change must not be significant")
+	}
+	c.num("mileage", &ad.Mileage, &mileage)
+	if !c.significant() {
+		t.Fatal("mileage change must be significant")
+	}
+	if ad.City != city || ad.Mileage != mileage || len(c.revs) != 2 {
+		t.Fatalf("ad = %+v, revs = %+v", ad, c.revs)
+	}
+	if c.revs[1].OldValue != "1000" || c.revs[1].NewValue != "2000" {
+		t.Fatalf("mileage rev = %+v", c.revs[1])
+	}
+}
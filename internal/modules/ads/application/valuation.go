@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+)
+
+type ValuationInput struct {
+	Brand   string
+	Model   string
+	Year    int
+	Mileage *int
+	City    string
+}
+
+// Valuation оценивает рыночную цену автомобиля по похожим объявлениям.
+// Сначала ищем в городе покупателя, при малой выборке — по всей стране.
+func (s *Service) Valuation(ctx context.Context, in ValuationInput) (*domain.Valuation, error) {
+	if strings.TrimSpace(in.Brand) == "" || strings.TrimSpace(in.Model) == "" || in.Year <= 0 {
+		return nil, errors.New("brand, model and year required")
+	}
+
+	ad := &domain.Ad{Brand: in.Brand, Model: in.Model, Year: in.Year}
+	if err := s.applyCatalog(ctx, ad); err != nil {
+		return nil, err
+	}
+	seg := domain.Segment{
+		Brand:    ad.Brand,
+		Model:    ad.Model,
+		ModelID:  ad.ModelID,
+		YearFrom: in.Year - domain.ValuationYearSpread,
+		YearTo:   in.Year + domain.ValuationYearSpread,
+		City:     strings.TrimSpace(in.City),
+	}
+
+	for {
+		st, err := s.repo.ValuationStats(ctx, seg)
+		if err != nil {
+			return nil, err
+		}
+		v, err := st.Valuation(in.Mileage)
+		if errors.Is(err, domain.ErrNotEnoughData) && seg.City != "" {
+			seg.City = ""
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		v.YearFrom, v.YearTo, v.City = seg.YearFrom, seg.YearTo, seg.City
+		return v, nil
+	}
+}
+
+// assessPrices проставляет PriceAssessment объявлениям страницы витрины одним запросом:
+// по городу объявления, при малой выборке — по стране. Без данных оценка остаётся пустой.
+func (s *Service) assessPrices(ctx context.Context, ads []*domain.Ad) error {
+	ids := make([]int64, 0, len(ads))
+	for _, ad := range ads {
+		ids = append(ids, ad.ID)
+	}
+	stats, err := s.repo.SegmentStatsByAds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, ad := range ads {
+		v, err := stats[ad.ID].Valuation(&ad.Mileage)
+		if err != nil {
+			continue
+		}
+		ad.PriceAssessment = v.Assess(ad.Price)
+	}
+	return nil
+}
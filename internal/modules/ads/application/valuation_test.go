@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// valuationRepo отдаёт заранее заданные агрегаты сегментов.
+type valuationRepo struct {
+	domain.Repository
+	stats map[int64]domain.SegmentStats
+}
+
+func (r *valuationRepo) SegmentStatsByAds(_ context.Context, _ []int64) (map[int64]domain.SegmentStats, error) {
+	return r.stats, nil
+}
+
+func segment(sample int, median float64) domain.ValuationStats {
+	return domain.ValuationStats{Sample: sample, Percentiles: []float64{median * 0.8, median * 0.9, median, median * 1.1, median * 1.2}}
+}
+
+func TestAssessPricesByCity(t *testing.T) {
+	repo := &valuationRepo{stats: map[int64]domain.SegmentStats{
+		// в городе машина дешевле, чем по стране: 1.1 млн там уже дорого
+		1: {City: segment(10, 900_000), Country: segment(100, 1_200_000)},
+		// в городе мало объявлений — сравниваем со страной
+		2: {City: segment(2, 900_000), Country: segment(100, 1_200_000)},
+		// данных нет
+		3: {City: segment(0, 0), Country: segment(1, 1_000_000)},
+	}}
+	s := &Service{repo: repo}
+
+	ads := []*domain.Ad{{ID: 1, Price: 1_100_000}, {ID: 2, Price: 1_100_000}, {ID: 3, Price: 1_100_000}}
+	if err := s.assessPrices(context.Background(), ads); err != nil {
+		t.Fatalf("assessPrices: %v", err)
+	}
+	want := []domain.PriceAssessment{domain.PriceAboveMarket, domain.PriceFair, ""}
+	for i, ad := range ads {
+		if ad.PriceAssessment != want[i] {
+			t.Errorf("ad %d: assessment = %q, want %q", ad.ID, ad.PriceAssessment, want[i])
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package application
+
+import (
+	"context"
+
+	"autera/internal/modules/ads/domain"
+	"autera/pkg/vin"
+)
+
+// VehicleHistory возвращает историю автомобиля по VIN с проверкой скрутки пробега.
+func (s *Service) VehicleHistory(ctx context.Context, raw string) (*domain.VehicleHistory, error) {
+	h, err := s.repo.VehicleHistory(ctx, vin.Normalize(raw))
+	if err != nil {
+		return nil, err
+	}
+	h.Rollbacks = domain.DetectRollbacks(h.Mileage)
+	return h, nil
+}
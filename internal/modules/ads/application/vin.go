@@ -0,0 +1,79 @@
+package application
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"autera/pkg/vin"
+)
+
+func (s *Service) DecodeVIN(raw string) (*vin.Info, error) {
+	return vin.Decode(vin.Normalize(raw))
+}
+
+// resolveVIN нормализует VIN и отклоняет только заведомо невалидный: неверная
+// структура или контрольная цифра там, где она обязательна. Пустые марку и год
+// дозаполняет из расшифровки; расхождение с заполненными — не ошибка, его
+// помечает правило премодерации vin_mismatch. Пустой VIN допустим.
+func resolveVIN(raw string, brand *string, year *int) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+	v := vin.Normalize(raw)
+	info, err := vin.Decode(v)
+	if err != nil {
+		return "", errors.New("invalid vin: " + err.Error())
+	}
+	if info.CheckDigitRequired && !info.CheckDigitValid {
+		return "", errors.New("invalid vin: " + vin.ErrCheckDigit.Error())
+	}
+
+	if *brand == "" && len(info.Makes) > 0 {
+		*brand = info.Makes[0]
+	}
+	if *year == 0 && info.ModelYearCoded {
+		*year = info.ModelYear
+	}
+	return v, nil
+}
+
+// vinMismatch описывает расхождение марки и года с расшифровкой VIN; "" — расхождений нет.
+// Неизвестный декодеру WMI и регионы без кодированного года не сверяются.
+func vinMismatch(raw, brand string, year int) string {
+	if raw == "" {
+		return ""
+	}
+	info, err := vin.Decode(vin.Normalize(raw))
+	if err != nil {
+		return ""
+	}
+	var msgs []string
+	if len(info.Makes) > 0 && brand != "" && !containsFold(info.Makes, brand) {
+		msgs = append(msgs, "vin belongs to "+strings.Join(info.Makes, "/")+", not "+brand)
+	}
+	if info.ModelYearCoded && year != 0 && !yearMatches(info.CandidateYears, year) {
+		msgs = append(msgs, "year "+strconv.Itoa(year)+" does not match vin model year "+strconv.Itoa(info.ModelYear))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, it := range list {
+		if strings.EqualFold(it, strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// yearMatches допускает ±1: модельный год и год выпуска/регистрации
+// часто расходятся.
+func yearMatches(candidates []int, year int) bool {
+	for _, y := range candidates {
+		if year >= y-1 && year <= y+1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package application
+
+import "testing"
+
+func TestResolveVIN(t *testing.T) {
+	tests := []struct {
+		name      string
+		vin       string
+		brand     string
+		year      int
+		wantErr   bool
+		wantBrand string
+		wantYear  int
+	}{
+		{name: "empty vin", vin: "  "},
+		{name: "prefill from north american vin", vin: "1hgcm82633a004352", wantBrand: "Honda", wantYear: 2003},
+		{name: "mismatch is not an error", vin: "1HGCM82633A004352", brand: "Toyota", year: 2015, wantBrand: "Toyota", wantYear: 2015},
+		{name: "bad check digit in north america", vin: "1HGCM82643A004352", wantErr: true},
+		// у европейских VIN контрольная цифра и год не обязательны
+		{name: "european vin with any check digit", vin: "WBAPH5C55BA123456", wantBrand: "BMW"},
+		{name: "bad structure", vin: "WBAPH5C55BA12345", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brand, year := tt.brand, tt.year
+			_, err := resolveVIN(tt.vin, &brand, &year)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if brand != tt.wantBrand || year != tt.wantYear {
+				t.Errorf("brand/year = %q/%d, want %q/%d", brand, year, tt.wantBrand, tt.wantYear)
+			}
+		})
+	}
+}
+
+func TestVINMismatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		vin   string
+		brand string
+		year  int
+		flag  bool
+	}{
+		{"match", "1HGCM82633A004352", "honda", 2003, false},
+		{"year within one", "1HGCM82633A004352", "Honda", 2004, false},
+		{"other brand", "1HGCM82633A004352", "Toyota", 2003, true},
+		{"other year", "1HGCM82633A004352", "Honda", 2015, true},
+		// год в позиции 10 у европейских VIN не кодируется
+		{"european year not checked", "WBAPH5C55BA123456", "BMW", 1999, false},
+		{"unknown wmi", "XXXPH5C55BA123456", "Lada", 2020, false},
+		{"no vin", "", "BMW", 2020, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := vinMismatch(tt.vin, tt.brand, tt.year)
+			if (msg != "") != tt.flag {
+				t.Errorf("vinMismatch = %q, want flag %v", msg, tt.flag)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type InspectionStatus string
 
 const (
@@ -17,6 +19,9 @@ const (
 	AdModeration AdStatus = "moderation"
 	AdPublished  AdStatus = "published"
 	AdRejected   AdStatus = "rejected"
+	AdSold       AdStatus = "sold"
+	AdArchived   AdStatus = "archived"
+	AdExpired    AdStatus = "expired"
 )
 
 type Ad struct {
@@ -24,11 +29,27 @@ type Ad struct {
 	SellerID        int64
 	Brand           string
 	Model           string
+	BrandID         *int64 // ссылки на справочник catalog; nil — текст не сопоставлен
+	ModelID         *int64
+	GenerationID    *int64
 	Year            int
 	Mileage         int
 	Price           int
 	VIN             string
 	City            string
+	Description     string
+	Spec            Spec
 	Status          AdStatus
 	InspectionState InspectionStatus
+	InspectionScore *int // итоговый балл последнего отчёта, nil — отчёта нет
+	Photos          []Photo
+	PublishedAt     *time.Time
+	SoldPrice       *int
+	PriceDrop       *PriceDrop // nil — цена не снижалась за PriceDropBadgePeriod
+	Highlighted     bool       // активно выделение в списке (продвижение highlight)
+
+	// PriceAssessment — положение цены относительно рынка (только в списке витрины).
+	PriceAssessment PriceAssessment
+	// Promoted — объявление из блока продвигаемых над выдачей.
+	Promoted bool
 }
@@ -0,0 +1,11 @@
+package domain
+
+// CatalogMatch — сопоставление свободного текста марки/модели со справочником.
+// Brand/Model — канонические названия; пустые, если уровень не найден.
+type CatalogMatch struct {
+	BrandID      *int64
+	ModelID      *int64
+	GenerationID *int64
+	Brand        string
+	Model        string
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+type ComplaintReason string
+
+const (
+	ComplaintFraud      ComplaintReason = "fraud"
+	ComplaintSold       ComplaintReason = "already_sold"
+	ComplaintWrongInfo  ComplaintReason = "wrong_info"
+	ComplaintDuplicate  ComplaintReason = "duplicate"
+	ComplaintProhibited ComplaintReason = "prohibited"
+	ComplaintOther      ComplaintReason = "other" // требует комментария
+)
+
+// ComplaintReasons — каталог причин жалобы с текстом для покупателя.
+var ComplaintReasons = map[ComplaintReason]string{
+	ComplaintFraud:      "Похоже на мошенничество",
+	ComplaintSold:       "Автомобиль уже продан",
+	ComplaintWrongInfo:  "Неверные сведения об автомобиле",
+	ComplaintDuplicate:  "Повтор объявления",
+	ComplaintProhibited: "Запрещённое содержание",
+	ComplaintOther:      "Другое",
+}
+
+type ComplaintOutcome string
+
+const (
+	ComplaintPending   ComplaintOutcome = ""
+	ComplaintDismissed ComplaintOutcome = "dismissed" // нарушения нет
+	ComplaintConfirmed ComplaintOutcome = "confirmed" // нарушение продавца, идёт в его историю
+)
+
+// MaxComplaintComment — предел длины комментария к жалобе.
+const MaxComplaintComment = 1000
+
+type Complaint struct {
+	ID         int64            `json:"id"`
+	AdID       int64            `json:"ad_id"`
+	SellerID   int64            `json:"seller_id"`
+	UserID     int64            `json:"user_id"`
+	Reason     ComplaintReason  `json:"reason"`
+	Comment    string           `json:"comment,omitempty"`
+	Outcome    ComplaintOutcome `json:"outcome,omitempty"`
+	ResolvedBy *int64           `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time       `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ComplaintGroup — нерешённые жалобы по объявлению для очереди администратора.
+type ComplaintGroup struct {
+	AdID     int64                     `json:"ad_id"`
+	SellerID int64                     `json:"seller_id"`
+	Status   AdStatus                  `json:"ad_status"`
+	Count    int64                     `json:"count"`
+	Reasons  map[ComplaintReason]int64 `json:"reasons"`
+	FirstAt  time.Time                 `json:"first_at"`
+	LastAt   time.Time                 `json:"last_at"`
+	// SellerConfirmed — подтверждённые ранее жалобы на продавца.
+	SellerConfirmed int64 `json:"seller_confirmed"`
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+type SortOrder string
+
+const (
+	SortNewest      SortOrder = "newest"
+	SortPriceAsc    SortOrder = "price_asc"
+	SortPriceDesc   SortOrder = "price_desc"
+	SortYearDesc    SortOrder = "year_desc"
+	SortYearAsc     SortOrder = "year_asc"
+	SortMileageAsc  SortOrder = "mileage_asc"
+	SortMileageDesc SortOrder = "mileage_desc"
+	SortScoreDesc   SortOrder = "score_desc"
+
+	// SortRelevance — только для поиска (q), пагинация по offset.
+	SortRelevance SortOrder = "relevance"
+)
+
+func (s SortOrder) Valid() bool {
+	switch s {
+	case SortNewest, SortPriceAsc, SortPriceDesc, SortYearDesc, SortYearAsc,
+		SortMileageAsc, SortMileageDesc, SortScoreDesc, SortRelevance:
+		return true
+	}
+	return false
+}
+
+// Cursor — позиция keyset-пагинации: значение ключа сортировки и id последнего
+// объявления на странице. id разрешает равенство ключей.
+type Cursor struct {
+	Sort SortOrder `json:"s"`
+	Key  int64     `json:"k"`
+	ID   int64     `json:"i"`
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Encode возвращает непрозрачный токен для клиента.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor разбирает токен; курсор от другой сортировки считается невалидным,
+// иначе ключ сравнивался бы не с той колонкой.
+func DecodeCursor(token string, sort SortOrder) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort || c.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// SortKey — значение ключа сортировки объявления (то же, что использует SQL).
+func SortKey(sort SortOrder, ad Ad) int64 {
+	switch sort {
+	case SortPriceAsc, SortPriceDesc:
+		return int64(ad.Price)
+	case SortYearAsc, SortYearDesc:
+		return int64(ad.Year)
+	case SortMileageAsc, SortMileageDesc:
+		return int64(ad.Mileage)
+	case SortScoreDesc:
+		if ad.InspectionScore == nil {
+			return -1
+		}
+		return int64(*ad.InspectionScore)
+	default:
+		return ad.ID
+	}
+}
+
+// NextCursor возвращает токен следующей страницы или "", если страница неполная.
+func NextCursor(sort SortOrder, items []Ad, limit int) string {
+	// блок продвигаемых идёт сверх страницы и в курсор не входит
+	var organic int
+	var last *Ad
+	for i := range items {
+		if !items[i].Promoted {
+			organic++
+			last = &items[i]
+		}
+	}
+	if sort == SortRelevance || limit <= 0 || organic < limit {
+		return ""
+	}
+	return Cursor{Sort: sort, Key: SortKey(sort, *last), ID: last.ID}.Encode()
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// DuplicateReason — по какому признаку объявление похоже на другое.
+type DuplicateReason string
+
+const (
+	DuplicateVIN        DuplicateReason = "vin"
+	DuplicatePhoto      DuplicateReason = "photo"
+	DuplicateAttributes DuplicateReason = "attributes" // марка/модель/год/город и близкий пробег
+)
+
+type DuplicateResolution string
+
+const (
+	DuplicatePending   DuplicateResolution = "pending"
+	DuplicateMerged    DuplicateResolution = "merged"
+	DuplicateRejected  DuplicateResolution = "rejected"
+	DuplicateDismissed DuplicateResolution = "dismissed"
+)
+
+const (
+	// PhotoHashMaxDistance — сколько бит dHash могут различаться у «той же» фотографии.
+	PhotoHashMaxDistance = 6
+	// DuplicateMileageDelta — допуск пробега для совпадения по характеристикам.
+	DuplicateMileageDelta = 3000
+)
+
+// Duplicate — подозрение, что AdID повторяет DuplicateOf.
+type Duplicate struct {
+	AdID        int64
+	DuplicateOf int64
+	Reason      DuplicateReason
+	SameSeller  bool
+	Resolution  DuplicateResolution
+	CreatedAt   time.Time
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+const EventPriceChanged = "ads.price_changed"
+
+// PriceChanged публикуется после сохранения новой цены объявления.
+type PriceChanged struct {
+	AdID      int64
+	SellerID  int64
+	OldPrice  int
+	NewPrice  int
+	ChangedAt time.Time
+}
+
+func (PriceChanged) Name() string { return EventPriceChanged }
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	// SitemapSegmentSize — объявлений на файл sitemap (лимит протокола — 50 000).
+	// Сегмент n покрывает id в [n*size, (n+1)*size), поэтому состав сегментов
+	// стабилен и меняются только затронутые.
+	SitemapSegmentSize = 10000
+	// ExportBatch — размер пачки при потоковой выгрузке.
+	ExportBatch = 500
+)
+
+// SitemapSegment — сводка сегмента: по ней видно, изменился ли он.
+type SitemapSegment struct {
+	Index   int64
+	Count   int64
+	LastMod time.Time
+}
+
+type SitemapEntry struct {
+	AdID    int64
+	LastMod time.Time
+}
@@ -0,0 +1,48 @@
+package domain
+
+// Границы корзин гистограмм. Корзина i — [Edges[i-1], Edges[i]),
+// первая открыта снизу, последняя — сверху.
+var (
+	PriceBucketEdges   = []int{3000, 5000, 8000, 12000, 20000, 30000, 50000}
+	YearBucketEdges    = []int{2000, 2005, 2010, 2015, 2020}
+	MileageBucketEdges = []int{30000, 60000, 100000, 150000, 200000, 300000}
+)
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type RangeBucket struct {
+	From  *int  `json:"from,omitempty"` // включительно
+	To    *int  `json:"to,omitempty"`   // не включительно
+	Count int64 `json:"count"`
+}
+
+// Facets — счётчики для панели фильтров. Каждый фасет считается без собственного
+// фильтра (выбрав Toyota, покупатель по-прежнему видит сколько Honda).
+type Facets struct {
+	Total      int64         `json:"total"`
+	Brands     []FacetCount  `json:"brands"`
+	Cities     []FacetCount  `json:"cities"`
+	Inspection []FacetCount  `json:"inspection"`
+	Price      []RangeBucket `json:"price"`
+	Year       []RangeBucket `json:"year"`
+	Mileage    []RangeBucket `json:"mileage"`
+}
+
+// NewRangeBuckets строит пустые корзины по границам (len(edges)+1 штук).
+func NewRangeBuckets(edges []int) []RangeBucket {
+	out := make([]RangeBucket, len(edges)+1)
+	for i := range out {
+		if i > 0 {
+			from := edges[i-1]
+			out[i].From = &from
+		}
+		if i < len(edges) {
+			to := edges[i]
+			out[i].To = &to
+		}
+	}
+	return out
+}
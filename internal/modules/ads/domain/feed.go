@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type FeedFormat string
+
+const (
+	FeedCSV FeedFormat = "csv"
+	FeedXML FeedFormat = "xml"
+)
+
+// MaxFeedSize — предел размера файла или ответа по URL фида.
+const MaxFeedSize = 20 << 20
+
+var ErrNotCompany = errors.New("feeds are available for company accounts only")
+
+// ErrFeedSyncQueued — синхронизация фида уже ждёт в очереди или выполняется.
+var ErrFeedSyncQueued = errors.New("feed sync already queued")
+
+// Feed — фид дилера, который периодически синхронизируется по URL.
+type Feed struct {
+	ID         int64       `json:"id"`
+	SellerID   int64       `json:"seller_id"`
+	URL        string      `json:"url"`
+	Format     FeedFormat  `json:"format"`
+	Active     bool        `json:"active"`
+	LastSyncAt *time.Time  `json:"last_sync_at,omitempty"`
+	LastResult *FeedResult `json:"last_result,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// FeedRowError — ошибка строки фида; Line == 0 — ошибка не привязана к строке
+// (например, архивирование пропавшего объявления).
+type FeedRowError struct {
+	Line       int    `json:"line"`
+	ExternalID string `json:"external_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+type FeedResult struct {
+	Rows     int            `json:"rows"`
+	Created  int            `json:"created"`
+	Updated  int            `json:"updated"`
+	Skipped  int            `json:"skipped"` // проданные объявления фид не трогает
+	Archived int            `json:"archived"`
+	Errors   []FeedRowError `json:"errors"`
+}
+
+type FeedImportStatus string
+
+const (
+	FeedImportPending FeedImportStatus = "pending"
+	FeedImportRunning FeedImportStatus = "running"
+	FeedImportDone    FeedImportStatus = "done"
+	FeedImportFailed  FeedImportStatus = "failed"
+)
+
+// FeedImportStale — импорт, зависший в running дольше этого (например, после
+// перезапуска), берётся в работу заново. Пока импорт идёт, обработчик
+// продлевает started_at, так что долгая загрузка зависшей не считается.
+const FeedImportStale = 15 * time.Minute
+
+// FeedImport — загрузка фида в очереди фоновой обработки: файл от продавца
+// или синхронизация фида по URL (FeedID).
+type FeedImport struct {
+	ID             int64            `json:"id"`
+	SellerID       int64            `json:"seller_id"`
+	FeedID         *int64           `json:"feed_id,omitempty"`
+	Format         FeedFormat       `json:"format"`
+	ArchiveMissing bool             `json:"archive_missing"`
+	Status         FeedImportStatus `json:"status"`
+	Result         *FeedResult      `json:"result,omitempty"`
+	Error          string           `json:"error,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	FinishedAt     *time.Time       `json:"finished_at,omitempty"`
+}
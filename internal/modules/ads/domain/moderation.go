@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+type ModerationDecisionType string
+
+const (
+	DecisionApprove ModerationDecisionType = "approve"
+	DecisionReject  ModerationDecisionType = "reject"
+	DecisionMerge   ModerationDecisionType = "merge" // повтор своего же объявления, уходит в архив
+)
+
+type RejectionReason string
+
+const (
+	ReasonBadPhotos        RejectionReason = "bad_photos"
+	ReasonIncompleteInfo   RejectionReason = "incomplete_info"
+	ReasonWrongPrice       RejectionReason = "wrong_price"
+	ReasonInvalidVIN       RejectionReason = "invalid_vin"
+	ReasonProhibitedText   RejectionReason = "prohibited_content"
+	ReasonDuplicate        RejectionReason = "duplicate"
+	ReasonFraudSuspected   RejectionReason = "fraud_suspected"
+	ReasonContactsInFields RejectionReason = "contacts_in_text"
+	ReasonOther            RejectionReason = "other" // требует комментария
+)
+
+// RejectionReasons — фиксированный каталог причин с текстом для продавца.
+var RejectionReasons = map[RejectionReason]string{
+	ReasonBadPhotos:        "Фотографии низкого качества или не соответствуют автомобилю",
+	ReasonIncompleteInfo:   "Не заполнены обязательные сведения об автомобиле",
+	ReasonWrongPrice:       "Цена указана некорректно",
+	ReasonInvalidVIN:       "VIN указан неверно",
+	ReasonProhibitedText:   "Описание содержит запрещённую информацию",
+	ReasonDuplicate:        "Такое объявление уже размещено",
+	ReasonFraudSuspected:   "Объявление похоже на мошенническое",
+	ReasonContactsInFields: "Контакты в тексте объявления запрещены",
+	ReasonOther:            "Другая причина (см. комментарий модератора)",
+}
+
+// ModerationDecision — запись истории модерации; видна продавцу.
+// ModeratorID == 0 — решение принято автоматически правилами.
+type ModerationDecision struct {
+	ID          int64
+	AdID        int64
+	ModeratorID int64
+	Decision    ModerationDecisionType
+	Reason      RejectionReason
+	ReasonText  string
+	Comment     string
+	CreatedAt   time.Time
+}
+
+// ClaimTTL — сколько модератор держит объявление за собой без решения.
+const ClaimTTL = 15 * time.Minute
+
+type QueueItem struct {
+	Ad
+	WaitingSince   time.Time
+	ClaimedBy      *int64
+	ClaimExpiresAt *time.Time
+	Flags          []RuleHit
+	Duplicates     []Duplicate
+	Complaints     int64 // нерешённые жалобы покупателей
+}
+
+type QueueFilter struct {
+	UnclaimedOnly bool
+	ModeratorID   int64 // для UnclaimedOnly: свои захваты тоже показываются
+	Limit, Offset int
+}
@@ -0,0 +1,25 @@
+package domain
+
+type PhotoVariant string
+
+const (
+	PhotoOriginal PhotoVariant = "original"
+	PhotoMedium   PhotoVariant = "medium"
+	PhotoThumb    PhotoVariant = "thumb"
+)
+
+const MaxPhotosPerAd = 20
+
+type Photo struct {
+	ID       int64
+	AdID     int64
+	Position int
+	IsCover  bool
+
+	// Keys — ключи объектов в хранилище по вариантам, наружу не отдаются.
+	Keys map[PhotoVariant]string `json:"-"`
+	// Hash — перцептивный хэш для поиска дублей, наружу не отдаётся.
+	Hash uint64 `json:"-"`
+	// URLs — публичные адреса вариантов, заполняются сервисом.
+	URLs map[PhotoVariant]string
+}
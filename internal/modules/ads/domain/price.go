@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// PriceDropBadgePeriod — сколько дней после снижения цены показывается плашка.
+const PriceDropBadgePeriod = 30 * 24 * time.Hour
+
+// PriceChange — запись истории цены.
+type PriceChange struct {
+	OldPrice  int
+	NewPrice  int
+	ChangedAt time.Time
+}
+
+// PriceDrop — плашка «цена снижена»: цена до первого из подряд идущих снижений.
+type PriceDrop struct {
+	PreviousPrice int
+	DroppedAt     time.Time
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type PromotionKind string
+
+const (
+	PromotionTop       PromotionKind = "top"       // блок продвигаемых над выдачей
+	PromotionHighlight PromotionKind = "highlight" // выделение в списке
+	PromotionPinCity   PromotionKind = "pin_city"  // закрепление в выдаче своего города
+)
+
+// PromotedSlots — сколько мест в блоке продвигаемых на первой странице выдачи.
+const PromotedSlots = 3
+
+// PromotionProduct — услуга продвижения, которую можно купить для объявления.
+type PromotionProduct struct {
+	Code  string        `json:"code"`
+	Kind  PromotionKind `json:"kind"`
+	Days  int           `json:"days"`
+	Price int           `json:"price"`
+}
+
+// PromotionProducts — прайс продвижения.
+var PromotionProducts = []PromotionProduct{
+	{Code: "top_3", Kind: PromotionTop, Days: 3, Price: 300},
+	{Code: "top_7", Kind: PromotionTop, Days: 7, Price: 600},
+	{Code: "highlight_7", Kind: PromotionHighlight, Days: 7, Price: 200},
+	{Code: "pin_city_7", Kind: PromotionPinCity, Days: 7, Price: 900},
+	{Code: "pin_city_14", Kind: PromotionPinCity, Days: 14, Price: 1500},
+}
+
+var (
+	ErrUnknownPromotion = errors.New("unknown promotion product")
+	// ErrPromotionAdNotPublished — продвигать можно только опубликованное объявление.
+	ErrPromotionAdNotPublished = errors.New("only published ads can be promoted")
+)
+
+func FindPromotionProduct(code string) (PromotionProduct, error) {
+	for _, p := range PromotionProducts {
+		if p.Code == code {
+			return p, nil
+		}
+	}
+	return PromotionProduct{}, ErrUnknownPromotion
+}
+
+type PromotionStatus string
+
+const (
+	PromotionPending PromotionStatus = "pending" // ждёт оплаты, срока ещё нет
+	PromotionActive  PromotionStatus = "active"  // оплачено, действует в [StartsAt, EndsAt)
+)
+
+// Promotion — заказанное продвижение. Срок назначается при оплате; повторная
+// покупка того же вида продлевает срок: новая начинается с конца текущей.
+type Promotion struct {
+	ID        int64           `json:"id"`
+	AdID      int64           `json:"ad_id"`
+	SellerID  int64           `json:"seller_id"`
+	Kind      PromotionKind   `json:"kind"`
+	Product   string          `json:"product"`
+	City      string          `json:"city,omitempty"` // для pin_city
+	Price     int             `json:"price"`
+	Days      int             `json:"days"`
+	Status    PromotionStatus `json:"status"`
+	StartsAt  *time.Time      `json:"starts_at,omitempty"`
+	EndsAt    *time.Time      `json:"ends_at,omitempty"`
+	PaidAt    *time.Time      `json:"paid_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (p Promotion) Active(now time.Time) bool {
+	return p.Status == PromotionActive && p.StartsAt != nil && p.EndsAt != nil &&
+		!now.Before(*p.StartsAt) && now.Before(*p.EndsAt)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPromotionActive(t *testing.T) {
+	now := time.Now()
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+	past := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name string
+		p    Promotion
+		want bool
+	}{
+		{name: "pending has no period", p: Promotion{Status: PromotionPending}, want: false},
+		{name: "pending with period", p: Promotion{Status: PromotionPending, StartsAt: &start, EndsAt: &end}, want: false},
+		{name: "active in period", p: Promotion{Status: PromotionActive, StartsAt: &start, EndsAt: &end}, want: true},
+		{name: "active expired", p: Promotion{Status: PromotionActive, StartsAt: &past, EndsAt: &start}, want: false},
+		{name: "active not started", p: Promotion{Status: PromotionActive, StartsAt: &end, EndsAt: &end}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Active(now); got != tt.want {
+				t.Fatalf("Active = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPromotionProduct(t *testing.T) {
+	p, err := FindPromotionProduct("top_7")
+	if err != nil || p.Kind != PromotionTop || p.Days != 7 {
+		t.Fatalf("FindPromotionProduct(top_7) = %+v, %v", p, err)
+	}
+	if _, err := FindPromotionProduct("free_forever"); !errors.Is(err, ErrUnknownPromotion) {
+		t.Fatalf("err = %v, want ErrUnknownPromotion", err)
+	}
+}
@@ -1,18 +1,40 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ListFilter struct {
-	VerifiedOnly  *bool
-	Brand         string
-	City          string
-	YearFrom      *int
-	YearTo        *int
-	PriceFrom     *int
-	PriceTo       *int
-	MileageFrom   *int
-	MileageTo     *int
-	Inspection    string
+	VerifiedOnly *bool
+	Brand        string
+	BrandID      *int64 // вместе с Brand: объявления без привязки ищутся по тексту
+	ModelID      *int64
+	GenerationID *int64
+	City         string
+	YearFrom     *int
+	YearTo       *int
+	PriceFrom    *int
+	PriceTo      *int
+	MileageFrom  *int
+	MileageTo    *int
+	Inspection   string
+
+	// характеристики: списки — любое из значений
+	Transmissions    []string
+	Fuels            []string
+	Drives           []string
+	BodyTypes        []string
+	Colors           []string
+	Steering         string
+	EngineVolumeFrom *int
+	EngineVolumeTo   *int
+	CustomsCleared   *bool
+
+	PriceDroppedSince *time.Time
+
+	Sort          SortOrder
+	Cursor        *Cursor // если задан, Offset игнорируется
 	Limit, Offset int
 }
 
@@ -20,7 +42,148 @@ type Repository interface {
 	Create(ctx context.Context, ad *Ad) (int64, error)
 	Get(ctx context.Context, id int64) (*Ad, error)
 	List(ctx context.Context, f ListFilter) ([]Ad, int64, error)
+	Search(ctx context.Context, q SearchQuery, f ListFilter) ([]SearchHit, int64, error)
+	Facets(ctx context.Context, q SearchQuery, f ListFilter) (*Facets, error)
+
+	// Update сохраняет ad и его ревизии в одной транзакции, если статус
+	// объявления всё ещё from (иначе — ошибка конкурентного изменения).
+	Update(ctx context.Context, ad *Ad, from AdStatus, revs []Revision) error
+	PriceHistory(ctx context.Context, adID int64) ([]PriceChange, error)
+	ListRevisions(ctx context.Context, adID int64) ([]Revision, error)
+	// EditedSinceStatusChange — были ли правки после последней смены статуса
+	// (для истёкшего — после снятия с публикации).
+	EditedSinceStatusChange(ctx context.Context, adID int64) (bool, error)
+
+	// photos
+	AddPhoto(ctx context.Context, p *Photo) (int64, error)
+	ListPhotos(ctx context.Context, adID int64) ([]Photo, error)
+	PhotosByAds(ctx context.Context, adIDs []int64) (map[int64][]Photo, error)
+	DeletePhoto(ctx context.Context, adID, photoID int64) (*Photo, error)
+	ReorderPhotos(ctx context.Context, adID int64, photoIDs []int64) error
+	SetCoverPhoto(ctx context.Context, adID, photoID int64) error
+
+	// lifecycle
+	Transition(ctx context.Context, adID int64, from, to AdStatus) error
+	MarkSold(ctx context.Context, adID int64, from AdStatus, finalPrice int) error
+	ExpirePublished(ctx context.Context, publishedBefore time.Time) ([]int64, error)
+
+	// moderation
+	ModerationQueue(ctx context.Context, f QueueFilter) ([]QueueItem, int64, error)
+	// ClaimForModeration захватывает объявление; false — держит другой модератор.
+	ClaimForModeration(ctx context.Context, adID, moderatorID int64, ttl time.Duration) (bool, error)
+	ReleaseClaim(ctx context.Context, adID, moderatorID int64) error
+	// Decide атомарно: проверяет захват, меняет статус из moderation в to,
+	// пишет решение в историю и снимает захват.
+	Decide(ctx context.Context, d *ModerationDecision, to AdStatus) error
+	ListDecisions(ctx context.Context, adID int64) ([]ModerationDecision, error)
+
+	// VehicleHistory собирает историю по нормализованному VIN без черновиков; Rollbacks не заполняет.
+	VehicleHistory(ctx context.Context, vin string) (*VehicleHistory, error)
+
+	// favourites
+	AddFavorite(ctx context.Context, userID, adID int64) error
+	RemoveFavorite(ctx context.Context, userID, adID int64) error
+	ListFavorites(ctx context.Context, userID int64, limit, offset int) ([]Ad, int64, error)
+
+	// saved searches
+	// CreateSavedSearch сохраняет поиск, если у пользователя их меньше limit,
+	// иначе — ErrTooManySavedSearches.
+	CreateSavedSearch(ctx context.Context, s *SavedSearch, limit int) (int64, error)
+	ListSavedSearches(ctx context.Context, userID int64) ([]SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, id, userID int64) error
+	// SavedSearchesAfter — пачка всех сохранённых поисков по возрастанию id (для матчера).
+	SavedSearchesAfter(ctx context.Context, afterID int64, limit int) ([]SavedSearch, error)
+	// MatchSavedSearch — какие из adIDs подходят под поиск (кроме объявлений его владельца).
+	MatchSavedSearch(ctx context.Context, s SavedSearch, adIDs []int64) ([]int64, error)
+
+	// очередь матчера: объявления попадают в неё при публикации
+	QueuedForMatching(ctx context.Context, limit int) ([]int64, error)
+	DequeueMatching(ctx context.Context, adIDs []int64) error
+
+	// notifications
+	AddNotifications(ctx context.Context, ns []Notification) error
+	ListNotifications(ctx context.Context, userID int64, limit int) ([]Notification, error)
+
+	// duplicates
+	// FindDuplicates ищет активные объявления, похожие на adID (по сохранённому состоянию).
+	FindDuplicates(ctx context.Context, adID int64) ([]Duplicate, error)
+	// SaveDuplicates заменяет нерешённые подозрения; решённые пары не переоткрываются.
+	SaveDuplicates(ctx context.Context, adID int64, dups []Duplicate) error
+	DuplicatesByAds(ctx context.Context, adIDs []int64) (map[int64][]Duplicate, error)
+	// ResolveDuplicates закрывает нерешённые подозрения; duplicateOf == 0 — все по объявлению.
+	ResolveDuplicates(ctx context.Context, adID, duplicateOf, moderatorID int64, res DuplicateResolution) (int64, error)
+
+	// valuation
+	ValuationStats(ctx context.Context, seg Segment) (ValuationStats, error)
+	// SegmentStatsByAds — агрегаты по сегменту каждого объявления (без него самого)
+	// в его городе и по всей стране.
+	SegmentStatsByAds(ctx context.Context, adIDs []int64) (map[int64]SegmentStats, error)
+
+	// SimilarCandidates — опубликованные объявления той же модели или кузова в окне цены/года.
+	SimilarCandidates(ctx context.Context, base *Ad, q SimilarQuery) ([]Ad, error)
+
+	// seller cabinet
+	SellerAds(ctx context.Context, f SellerAdsFilter) ([]SellerAd, int64, error)
+	SellerStatusCounts(ctx context.Context, sellerID int64) (map[AdStatus]int64, error)
+
+	// dealer feeds
+	// FeedAds — внешние ID дилера и объявления, созданные по ним.
+	FeedAds(ctx context.Context, sellerID int64) (map[string]int64, error)
+	// CreateFeedAd создаёт объявление и привязку к внешнему ID в одной транзакции.
+	CreateFeedAd(ctx context.Context, ad *Ad, externalID string) (int64, error)
+	CreateFeed(ctx context.Context, f *Feed) (int64, error)
+	GetFeed(ctx context.Context, id int64) (*Feed, error)
+	ListFeeds(ctx context.Context, sellerID int64) ([]Feed, error)
+	ActiveFeeds(ctx context.Context) ([]Feed, error)
+	DeleteFeed(ctx context.Context, id, sellerID int64) error
+	SaveFeedResult(ctx context.Context, id int64, res *FeedResult) error
+	// CreateFeedImport ставит загрузку в очередь; для фида по URL, у которого
+	// синхронизация уже в очереди, — ErrFeedSyncQueued.
+	CreateFeedImport(ctx context.Context, imp *FeedImport, payload []byte) error
+	// ClaimFeedImport берёт в работу самый старый ожидающий (или зависший) импорт; nil — очередь пуста.
+	ClaimFeedImport(ctx context.Context, staleBefore time.Time) (*FeedImport, []byte, error)
+	// TouchFeedImport продлевает started_at выполняющегося импорта.
+	TouchFeedImport(ctx context.Context, id int64) error
+	FinishFeedImport(ctx context.Context, id int64, res *FeedResult, errText string) error
+	GetFeedImport(ctx context.Context, id int64) (*FeedImport, error)
+
+	// complaints
+	// AddComplaint возвращает число нерешённых жалоб разных пользователей на объявление.
+	AddComplaint(ctx context.Context, c *Complaint) (int64, error)
+	ComplaintQueue(ctx context.Context, limit, offset int) ([]ComplaintGroup, int64, error)
+	OpenComplaints(ctx context.Context, adID int64) ([]Complaint, error)
+	// ResolveComplaints закрывает все нерешённые жалобы по объявлению; с reject
+	// в той же транзакции отклоняет опубликованное или ожидающее модерации объявление.
+	ResolveComplaints(ctx context.Context, adID, adminID int64, outcome ComplaintOutcome, reject *ModerationDecision) (int64, error)
+	OpenComplaintCounts(ctx context.Context, adIDs []int64) (map[int64]int64, error)
+
+	// promotions
+	// AddPromotion сохраняет заказ продвижения в статусе pending.
+	AddPromotion(ctx context.Context, p *Promotion) error
+	// ActivatePromotion отмечает оплату и назначает срок; начало сдвигается
+	// на конец активной промо того же вида. Объявление не в published —
+	// ErrPromotionAdNotPublished, заказ не меняется.
+	ActivatePromotion(ctx context.Context, id int64) (*Promotion, error)
+	ListPromotions(ctx context.Context, adID int64) ([]Promotion, error)
+
+	// export
+	// PublishedAfter — опубликованные объявления по возрастанию id (keyset).
+	PublishedAfter(ctx context.Context, afterID int64, limit int) ([]Ad, error)
+	SitemapSegments(ctx context.Context, size int64) ([]SitemapSegment, error)
+	// SitemapSegment — сводка одного сегмента; Count == 0 — сегмент пуст.
+	SitemapSegment(ctx context.Context, segment, size int64) (SitemapSegment, error)
+	SitemapEntries(ctx context.Context, segment, size int64) ([]SitemapEntry, error)
+
+	// stats
+	// AddStatHits засчитывает события, ещё не учтённые для устройства за день.
+	AddStatHits(ctx context.Context, hits []StatHit) error
+	PurgeStatDevices(ctx context.Context, before time.Time) (int64, error)
+	// SellerStats — дневные счётчики по всем объявлениям продавца (дни без событий не возвращаются).
+	SellerStats(ctx context.Context, sellerID int64, from, to time.Time) ([]AdStats, error)
 
-	SubmitToModeration(ctx context.Context, adID, sellerID int64) error
-	Moderate(ctx context.Context, adID int64, decision string) error
+	// pre-moderation rules
+	SaveRuleHits(ctx context.Context, adID int64, hits []RuleHit) error
+	RuleHitsByAds(ctx context.Context, adIDs []int64) (map[int64][]RuleHit, error)
+	PriceStats(ctx context.Context, brand, model string, yearFrom, yearTo int) (PriceStats, error)
+	CountSellerAdsSince(ctx context.Context, sellerID int64, since time.Time) (int, error)
 }
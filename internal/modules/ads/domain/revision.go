@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Revision — изменение одного поля объявления: кто, когда, что было и что стало.
+type Revision struct {
+	ID        int64
+	AdID      int64
+	Field     string
+	OldValue  string
+	NewValue  string
+	ActorID   int64
+	ChangedAt time.Time
+}
+
+// SignificantFields — поля, после изменения которых опубликованное объявление
+// заново проходит модерацию.
+var SignificantFields = map[string]bool{
+	"vin":     true,
+	"year":    true,
+	"mileage": true,
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type RuleAction string
+
+const (
+	RuleFlag   RuleAction = "flag"   // оставить на ручную модерацию с пометкой
+	RuleReject RuleAction = "reject" // отклонить автоматически
+)
+
+// RuleHit — сработавшее правило премодерации; показывается модератору в очереди.
+type RuleHit struct {
+	AdID      int64
+	Rule      string
+	Action    RuleAction
+	Message   string
+	CreatedAt time.Time
+}
+
+// PriceStats — статистика цен по похожим объявлениям.
+type PriceStats struct {
+	Median int
+	Sample int
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxSavedSearches — лимит сохранённых поисков на покупателя.
+const MaxSavedSearches = 20
+
+var ErrTooManySavedSearches = errors.New("too many saved searches")
+
+// SavedSearch — сохранённый покупателем поиск: строка q и фильтр витрины
+// (хранится сериализованным, без сортировки и пагинации).
+type SavedSearch struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Query     string
+	Filter    ListFilter
+	CreatedAt time.Time
+}
+
+// Criteria — фильтр без сортировки и пагинации: то, что сохраняется и сопоставляется.
+func (f ListFilter) Criteria() ListFilter {
+	f.Sort, f.Cursor, f.Limit, f.Offset = "", nil, 0, 0
+	return f
+}
+
+const NotificationSavedSearchMatch = "saved_search_match"
+
+// Notification — уведомление в очереди на отправку; SentAt заполняет доставка.
+type Notification struct {
+	ID            int64
+	UserID        int64
+	Kind          string
+	AdID          int64
+	SavedSearchID *int64
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
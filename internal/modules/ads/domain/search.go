@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const maxSearchTerms = 8
+
+// SearchQuery — разобранная строка поиска покупателя.
+// Четырёхзначные числа в диапазоне годов трактуются как год выпуска,
+// остальные слова ищутся по тексту (с морфологией и нечётко).
+type SearchQuery struct {
+	Raw   string
+	Terms []string
+	Years []int
+}
+
+func (q SearchQuery) Empty() bool { return len(q.Terms) == 0 && len(q.Years) == 0 }
+
+// Text — слова запроса без годов, для tsquery и подсветки.
+func (q SearchQuery) Text() string { return strings.Join(q.Terms, " ") }
+
+func ParseSearchQuery(raw string) SearchQuery {
+	q := SearchQuery{Raw: raw}
+	words := strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	maxYear := time.Now().Year() + 1
+	for _, w := range words {
+		if len(q.Terms)+len(q.Years) >= maxSearchTerms {
+			break
+		}
+		if y, err := strconv.Atoi(w); err == nil && len(w) == 4 && y >= 1900 && y <= maxYear {
+			q.Years = append(q.Years, y)
+			continue
+		}
+		if len([]rune(w)) < 2 {
+			continue
+		}
+		q.Terms = append(q.Terms, w)
+	}
+	return q
+}
+
+// SearchHit — объявление, найденное поиском, с релевантностью и подсветкой.
+type SearchHit struct {
+	Ad
+	Rank      float64
+	Highlight string // HTML: текст экранирован, совпадения в <b>
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// SellerAd — объявление в кабинете продавца: любой статус, с причиной
+// последнего отклонения.
+type SellerAd struct {
+	Ad
+	UpdatedAt       time.Time // последняя правка продавцом или модератором
+	StatusChangedAt time.Time
+	Rejection       *ModerationDecision // только для rejected
+}
+
+type SellerAdsFilter struct {
+	SellerID      int64
+	Status        AdStatus // "" — все статусы
+	Limit, Offset int
+}
+
+// AdStatuses — все статусы в порядке жизненного цикла (для счётчиков кабинета).
+var AdStatuses = []string{
+	string(AdDraft), string(AdModeration), string(AdPublished), string(AdRejected),
+	string(AdSold), string(AdArchived), string(AdExpired),
+}
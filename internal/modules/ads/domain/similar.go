@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// SimilarQuery — окно отбора кандидатов: та же модель или тот же кузов
+// в близком ценовом и возрастном диапазоне.
+type SimilarQuery struct {
+	PriceSpread float64 // доля от цены, ±
+	YearSpread  int
+	Candidates  int // сколько кандидатов ранжировать
+}
+
+// SimilarWeights — веса ранжирования похожих объявлений. Бонусы прибавляются,
+// штрафы за разницу цены/года/пробега вычитаются (разница нормирована на окно).
+type SimilarWeights struct {
+	SameModel float64
+	SameCity  float64
+	Inspected float64
+	Price     float64
+	Year      float64
+	Mileage   float64
+}
+
+// mileageScale — разница пробега, дающая полный штраф.
+const mileageScale = 50000
+
+// Score — чем больше, тем сильнее кандидат похож на base.
+func (w SimilarWeights) Score(base, c *Ad, q SimilarQuery) float64 {
+	var score float64
+	if sameModel(base, c) {
+		score += w.SameModel
+	}
+	if base.City != "" && strings.EqualFold(base.City, c.City) {
+		score += w.SameCity
+	}
+	if c.InspectionState == InspectionDone || c.InspectionState == InspectionCertified {
+		score += w.Inspected
+	}
+
+	if base.Price > 0 && q.PriceSpread > 0 {
+		d := math.Abs(float64(c.Price-base.Price)) / (float64(base.Price) * q.PriceSpread)
+		score -= w.Price * math.Min(d, 1)
+	}
+	if q.YearSpread > 0 {
+		d := math.Abs(float64(c.Year-base.Year)) / float64(q.YearSpread)
+		score -= w.Year * math.Min(d, 1)
+	}
+	d := math.Abs(float64(c.Mileage-base.Mileage)) / mileageScale
+	score -= w.Mileage * math.Min(d, 1)
+	return score
+}
+
+// RankSimilar сортирует кандидатов по убыванию Score и оставляет limit лучших.
+func (w SimilarWeights) RankSimilar(base *Ad, cands []Ad, q SimilarQuery, limit int) []Ad {
+	scores := make(map[int64]float64, len(cands))
+	for i := range cands {
+		scores[cands[i].ID] = w.Score(base, &cands[i], q)
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		return scores[cands[i].ID] > scores[cands[j].ID]
+	})
+	if len(cands) > limit {
+		cands = cands[:limit]
+	}
+	return cands
+}
+
+func sameModel(a, b *Ad) bool {
+	if a.ModelID != nil && b.ModelID != nil {
+		return *a.ModelID == *b.ModelID
+	}
+	return strings.EqualFold(a.Brand, b.Brand) && strings.EqualFold(a.Model, b.Model)
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+)
+
+type Transmission string
+
+const (
+	TransmissionManual    Transmission = "manual"
+	TransmissionAutomatic Transmission = "automatic"
+	TransmissionRobot     Transmission = "robot"
+	TransmissionCVT       Transmission = "cvt"
+)
+
+type Fuel string
+
+const (
+	FuelPetrol   Fuel = "petrol"
+	FuelDiesel   Fuel = "diesel"
+	FuelHybrid   Fuel = "hybrid"
+	FuelElectric Fuel = "electric"
+	FuelLPG      Fuel = "lpg"
+)
+
+type Drive string
+
+const (
+	DriveFront Drive = "fwd"
+	DriveRear  Drive = "rwd"
+	DriveAll   Drive = "awd"
+)
+
+type Steering string
+
+const (
+	SteeringLeft  Steering = "left"
+	SteeringRight Steering = "right"
+)
+
+// Colors — допустимые цвета кузова.
+var Colors = []string{
+	"white", "black", "silver", "grey", "blue", "red", "green", "brown",
+	"beige", "yellow", "orange", "purple", "gold", "other",
+}
+
+var (
+	Transmissions = []string{string(TransmissionManual), string(TransmissionAutomatic), string(TransmissionRobot), string(TransmissionCVT)}
+	Fuels         = []string{string(FuelPetrol), string(FuelDiesel), string(FuelHybrid), string(FuelElectric), string(FuelLPG)}
+	Drives        = []string{string(DriveFront), string(DriveRear), string(DriveAll)}
+	SteeringSides = []string{string(SteeringLeft), string(SteeringRight)}
+)
+
+// ErrUnknownBodyType — тип кузова не найден в справочнике.
+var ErrUnknownBodyType = errors.New("unknown body_type")
+
+// MaxEngineVolume — верхняя граница объёма двигателя, см³.
+const MaxEngineVolume = 10000
+
+// Spec — технические характеристики. Пустые строки и nil — «не указано»
+// (объявления, созданные до появления характеристик). BodyType — код из
+// справочника типов кузова.
+type Spec struct {
+	Transmission   Transmission
+	Fuel           Fuel
+	Drive          Drive
+	BodyType       string
+	EngineVolume   *int // см³; у электромобилей не указывается
+	Color          string
+	Steering       Steering
+	CustomsCleared *bool
+}
+
+func OneOf(v string, allowed []string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate проверяет значения перечислений и объём двигателя.
+func (s Spec) Validate() error {
+	enums := []struct {
+		name, value string
+		allowed     []string
+	}{
+		{"transmission", string(s.Transmission), Transmissions},
+		{"fuel", string(s.Fuel), Fuels},
+		{"drive", string(s.Drive), Drives},
+		{"color", s.Color, Colors},
+		{"steering", string(s.Steering), SteeringSides},
+	}
+	for _, e := range enums {
+		if e.value != "" && !OneOf(e.value, e.allowed) {
+			return errors.New("invalid " + e.name)
+		}
+	}
+	if s.EngineVolume != nil {
+		if s.Fuel == FuelElectric {
+			return errors.New("engine_volume is not applicable to electric cars")
+		}
+		if *s.EngineVolume <= 0 || *s.EngineVolume > MaxEngineVolume {
+			return errors.New("invalid engine_volume")
+		}
+	}
+	return nil
+}
+
+// Fields — строковые значения характеристик для ревизий ("spec.<поле>").
+func (s Spec) Fields() map[string]string {
+	out := map[string]string{
+		"spec.transmission":    string(s.Transmission),
+		"spec.fuel":            string(s.Fuel),
+		"spec.drive":           string(s.Drive),
+		"spec.body_type":       s.BodyType,
+		"spec.color":           s.Color,
+		"spec.steering":        string(s.Steering),
+		"spec.engine_volume":   "",
+		"spec.customs_cleared": "",
+	}
+	if s.EngineVolume != nil {
+		out["spec.engine_volume"] = strconv.Itoa(*s.EngineVolume)
+	}
+	if s.CustomsCleared != nil {
+		out["spec.customs_cleared"] = strconv.FormatBool(*s.CustomsCleared)
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package domain
+
+import "time"
+
+// StatEvent — что покупатель сделал с объявлением.
+type StatEvent string
+
+const (
+	StatView     StatEvent = "view"     // открыл карточку
+	StatPhone    StatEvent = "phone"    // показал телефон
+	StatFavorite StatEvent = "favorite" // добавил в избранное
+	StatReport   StatEvent = "report"   // открыл отчёт проверки
+)
+
+var StatEvents = []string{string(StatView), string(StatPhone), string(StatFavorite), string(StatReport)}
+
+// StatHit — одно событие; за день с одного устройства считается один раз.
+type StatHit struct {
+	AdID   int64
+	Day    string // 2006-01-02, UTC
+	Event  StatEvent
+	Device string
+}
+
+func NewStatHit(adID int64, event StatEvent, device string, at time.Time) StatHit {
+	return StatHit{AdID: adID, Day: at.UTC().Format(time.DateOnly), Event: event, Device: device}
+}
+
+type DailyStats struct {
+	Day       string `json:"day"`
+	Views     int64  `json:"views"`
+	Phones    int64  `json:"phones"`
+	Favorites int64  `json:"favorites"`
+	Reports   int64  `json:"reports"`
+}
+
+func (d *DailyStats) add(o DailyStats) {
+	d.Views += o.Views
+	d.Phones += o.Phones
+	d.Favorites += o.Favorites
+	d.Reports += o.Reports
+}
+
+// Conversion — доля просмотров, закончившихся действием.
+type Conversion struct {
+	Phone    float64 `json:"phone"`
+	Favorite float64 `json:"favorite"`
+	Report   float64 `json:"report"`
+}
+
+type AdStats struct {
+	AdID       int64        `json:"ad_id"`
+	Brand      string       `json:"brand"`
+	Model      string       `json:"model"`
+	Status     AdStatus     `json:"status"`
+	Days       []DailyStats `json:"days"`
+	Totals     DailyStats   `json:"totals"`
+	Conversion Conversion   `json:"conversion"`
+}
+
+// Complete дополняет ряд нулевыми днями в [from, to] и считает итоги.
+func (s *AdStats) Complete(from, to time.Time) {
+	byDay := make(map[string]DailyStats, len(s.Days))
+	for _, d := range s.Days {
+		byDay[d.Day] = d
+	}
+
+	s.Days = s.Days[:0]
+	s.Totals = DailyStats{}
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		d := byDay[key]
+		d.Day = key
+		s.Days = append(s.Days, d)
+		s.Totals.add(d)
+	}
+
+	s.Conversion = Conversion{}
+	if v := float64(s.Totals.Views); v > 0 {
+		s.Conversion = Conversion{
+			Phone:    float64(s.Totals.Phones) / v,
+			Favorite: float64(s.Totals.Favorites) / v,
+			Report:   float64(s.Totals.Reports) / v,
+		}
+	}
+}
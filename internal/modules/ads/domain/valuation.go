@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// ValuationYearSpread — годы выпуска ±N считаются одним сегментом.
+	ValuationYearSpread = 1
+	// MinValuationSample — меньше объявлений в сегменте — оценку не даём.
+	MinValuationSample = 5
+	// minMileageR2 — пробег учитывается, только если заметно объясняет цену.
+	minMileageR2 = 0.2
+)
+
+var ErrNotEnoughData = errors.New("not enough data for valuation")
+
+// Segment — похожие автомобили: та же модель (по справочнику или тексту) и близкий год.
+type Segment struct {
+	Brand    string
+	Model    string
+	ModelID  *int64
+	YearFrom int
+	YearTo   int
+	City     string // "" — по всем городам
+}
+
+// ValuationStats — сырые агрегаты по сегменту: перцентили цены (10/25/50/75/90),
+// медиана пробега и линейная зависимость цены от пробега.
+type ValuationStats struct {
+	Sample        int
+	Percentiles   []float64
+	MileageMedian float64
+	Slope         *float64 // изменение цены на 1 км
+	R2            *float64
+}
+
+// SegmentStats — агрегаты сегмента объявления в его городе и по всей стране.
+type SegmentStats struct {
+	City    ValuationStats
+	Country ValuationStats
+}
+
+// Valuation — оценка по городу, а при малой выборке в городе — по стране.
+func (s SegmentStats) Valuation(mileage *int) (*Valuation, error) {
+	v, err := s.City.Valuation(mileage)
+	if errors.Is(err, ErrNotEnoughData) {
+		return s.Country.Valuation(mileage)
+	}
+	return v, err
+}
+
+type PriceAssessment string
+
+const (
+	PriceBelowMarket PriceAssessment = "below"
+	PriceFair        PriceAssessment = "fair"
+	PriceAboveMarket PriceAssessment = "above"
+)
+
+// Valuation — рыночная оценка; Estimate учитывает пробег, если он задан и значим.
+type Valuation struct {
+	Sample          int    `json:"sample"`
+	YearFrom        int    `json:"year_from"`
+	YearTo          int    `json:"year_to"`
+	City            string `json:"city,omitempty"`
+	P10             int    `json:"p10"`
+	P25             int    `json:"p25"`
+	Median          int    `json:"median"`
+	P75             int    `json:"p75"`
+	P90             int    `json:"p90"`
+	Estimate        int    `json:"estimate"`
+	MileageAdjusted bool   `json:"mileage_adjusted"`
+}
+
+// Valuation строит оценку из агрегатов; ErrNotEnoughData — выборка мала.
+func (st ValuationStats) Valuation(mileage *int) (*Valuation, error) {
+	if st.Sample < MinValuationSample || len(st.Percentiles) != 5 {
+		return nil, ErrNotEnoughData
+	}
+	round := func(f float64) int { return int(math.Round(f)) }
+	v := &Valuation{
+		Sample: st.Sample,
+		P10:    round(st.Percentiles[0]),
+		P25:    round(st.Percentiles[1]),
+		Median: round(st.Percentiles[2]),
+		P75:    round(st.Percentiles[3]),
+		P90:    round(st.Percentiles[4]),
+	}
+	v.Estimate = v.Median
+
+	// цена падает с пробегом; сдвигаем медиану вдоль регрессии и не выходим за P10–P90
+	if mileage != nil && st.Slope != nil && st.R2 != nil && *st.Slope < 0 && *st.R2 >= minMileageR2 {
+		est := st.Percentiles[2] + *st.Slope*(float64(*mileage)-st.MileageMedian)
+		v.Estimate = min(max(round(est), v.P10), v.P90)
+		v.MileageAdjusted = true
+	}
+	return v, nil
+}
+
+// Assess сравнивает цену с межквартильным коридором, сдвинутым к оценке.
+func (v *Valuation) Assess(price int) PriceAssessment {
+	shift := 1.0
+	if v.Median > 0 {
+		shift = float64(v.Estimate) / float64(v.Median)
+	}
+	switch {
+	case float64(price) < float64(v.P25)*shift:
+		return PriceBelowMarket
+	case float64(price) > float64(v.P75)*shift:
+		return PriceAboveMarket
+	}
+	return PriceFair
+}
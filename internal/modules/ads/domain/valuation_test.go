@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func stats(sample int, median float64) ValuationStats {
+	return ValuationStats{Sample: sample, Percentiles: []float64{median * 0.8, median * 0.9, median, median * 1.1, median * 1.2}}
+}
+
+func TestSegmentStatsValuation(t *testing.T) {
+	tests := []struct {
+		name       string
+		stats      SegmentStats
+		wantMedian int
+		wantErr    error
+	}{
+		{name: "city sample is enough", stats: SegmentStats{City: stats(MinValuationSample, 900_000), Country: stats(50, 1_000_000)}, wantMedian: 900_000},
+		{name: "small city falls back to country", stats: SegmentStats{City: stats(MinValuationSample-1, 900_000), Country: stats(50, 1_000_000)}, wantMedian: 1_000_000},
+		{name: "no data anywhere", stats: SegmentStats{City: stats(1, 900_000), Country: stats(2, 1_000_000)}, wantErr: ErrNotEnoughData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.stats.Valuation(nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && v.Median != tt.wantMedian {
+				t.Fatalf("median = %d, want %d", v.Median, tt.wantMedian)
+			}
+		})
+	}
+}
+
+func TestValuationAssess(t *testing.T) {
+	v, err := stats(10, 1_000_000).Valuation(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for price, want := range map[int]PriceAssessment{
+		850_000:   PriceBelowMarket,
+		1_000_000: PriceFair,
+		1_150_000: PriceAboveMarket,
+	} {
+		if got := v.Assess(price); got != want {
+			t.Errorf("Assess(%d) = %q, want %q", price, got, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// RollbackTolerance — допуск на расхождение пробега между объявлениями (опечатки, округление).
+const RollbackTolerance = 1000
+
+// VehicleHistory — всё, что известно об автомобиле по VIN: все объявления,
+// проверки и отчёты, а также хронология пробега.
+type VehicleHistory struct {
+	VIN         string
+	Listings    []VehicleListing
+	Inspections []VehicleInspection
+	Reports     []VehicleReport
+	Mileage     []MileagePoint
+	Rollbacks   []MileageRollback
+}
+
+type VehicleListing struct {
+	AdID      int64
+	Status    AdStatus
+	Price     int
+	Mileage   int
+	City      string
+	CreatedAt time.Time
+}
+
+type VehicleInspection struct {
+	ID        int64
+	AdID      int64
+	Status    string
+	CreatedAt time.Time
+}
+
+type VehicleReport struct {
+	ID           int64
+	InspectionID int64
+	AdID         int64
+	TotalScore   int
+	Label        string
+	CreatedAt    time.Time
+}
+
+type MileagePoint struct {
+	AdID    int64
+	At      time.Time
+	Mileage int
+}
+
+// MileageRollback — пробег в объявлении меньше, чем уже был в другом объявлении.
+type MileageRollback struct {
+	AdID        int64
+	At          time.Time
+	Mileage     int
+	PreviousMax int
+	PreviousAd  int64
+}
+
+// DetectRollbacks ищет скрутку пробега между объявлениями; points отсортированы по времени.
+// Уменьшение внутри одного объявления считается исправлением опечатки.
+func DetectRollbacks(points []MileagePoint) []MileageRollback {
+	var out []MileageRollback
+	maxMileage, maxAd := -1, int64(0)
+	for _, p := range points {
+		if maxAd != 0 && p.AdID != maxAd && p.Mileage < maxMileage-RollbackTolerance {
+			out = append(out, MileageRollback{
+				AdID: p.AdID, At: p.At, Mileage: p.Mileage,
+				PreviousMax: maxMileage, PreviousAd: maxAd,
+			})
+		}
+		if p.Mileage > maxMileage {
+			maxMileage, maxAd = p.Mileage, p.AdID
+		}
+	}
+	return out
+}
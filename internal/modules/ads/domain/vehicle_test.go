@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectRollbacks(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return at.AddDate(0, 0, n) }
+
+	tests := []struct {
+		name   string
+		points []MileagePoint
+		want   []MileageRollback
+	}{
+		{
+			name: "growing mileage",
+			points: []MileagePoint{
+				{AdID: 1, At: day(0), Mileage: 50000},
+				{AdID: 2, At: day(30), Mileage: 60000},
+			},
+		},
+		{
+			name: "typo fixed within one ad",
+			points: []MileagePoint{
+				{AdID: 1, At: day(0), Mileage: 500000},
+				{AdID: 1, At: day(1), Mileage: 50000},
+			},
+		},
+		{
+			name: "rollback in a later ad",
+			points: []MileagePoint{
+				{AdID: 1, At: day(0), Mileage: 120000},
+				{AdID: 2, At: day(90), Mileage: 80000},
+			},
+			want: []MileageRollback{{AdID: 2, At: day(90), Mileage: 80000, PreviousMax: 120000, PreviousAd: 1}},
+		},
+		{
+			name: "drop within tolerance",
+			points: []MileagePoint{
+				{AdID: 1, At: day(0), Mileage: 120000},
+				{AdID: 2, At: day(90), Mileage: 120000 - RollbackTolerance},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectRollbacks(tt.points)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d rollbacks, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("rollback %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+// AddComplaint сохраняет жалобу; повторная нерешённая жалоба того же пользователя отклоняется.
+func (r *PostgresRepo) AddComplaint(ctx context.Context, c *domain.Complaint) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO ad_complaints (ad_id, seller_id, user_id, reason, comment)
+		SELECT a.id, a.seller_id, $2, $3, $4 FROM ads a WHERE a.id = $1
+		ON CONFLICT (ad_id, user_id) WHERE resolved_at IS NULL DO NOTHING
+		RETURNING id, seller_id, created_at
+	`, c.AdID, c.UserID, string(c.Reason), c.Comment).Scan(&c.ID, &c.SellerID, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.New("complaint already filed")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var open int64
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT user_id) FROM ad_complaints WHERE ad_id=$1 AND resolved_at IS NULL
+	`, c.AdID).Scan(&open)
+	return open, err
+}
+
+// ComplaintQueue — объявления с нерешёнными жалобами: больше жалоб — выше, при равенстве — дольше ждут.
+func (r *PostgresRepo) ComplaintQueue(ctx context.Context, limit, offset int) ([]domain.ComplaintGroup, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT ad_id) FROM ad_complaints WHERE resolved_at IS NULL
+	`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.ad_id, c.seller_id, a.status, COUNT(1), MIN(c.created_at), MAX(c.created_at),
+		       (SELECT jsonb_object_agg(rs.reason, rs.n)
+		        FROM (SELECT reason, COUNT(1) AS n FROM ad_complaints x
+		              WHERE x.ad_id = c.ad_id AND x.resolved_at IS NULL GROUP BY reason) rs),
+		       (SELECT COUNT(DISTINCT y.ad_id) FROM ad_complaints y
+		        WHERE y.seller_id = c.seller_id AND y.outcome = 'confirmed')
+		FROM ad_complaints c
+		JOIN ads a ON a.id = c.ad_id
+		WHERE c.resolved_at IS NULL
+		GROUP BY c.ad_id, c.seller_id, a.status
+		ORDER BY COUNT(1) DESC, MIN(c.created_at) ASC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.ComplaintGroup, 0)
+	for rows.Next() {
+		var g domain.ComplaintGroup
+		var status string
+		var reasons []byte
+		if err := rows.Scan(&g.AdID, &g.SellerID, &status, &g.Count, &g.FirstAt, &g.LastAt, &reasons, &g.SellerConfirmed); err != nil {
+			return nil, 0, err
+		}
+		g.Status = domain.AdStatus(status)
+		if err := json.Unmarshal(reasons, &g.Reasons); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *PostgresRepo) OpenComplaints(ctx context.Context, adID int64) ([]domain.Complaint, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, ad_id, seller_id, user_id, reason, comment, created_at
+		FROM ad_complaints
+		WHERE ad_id=$1 AND resolved_at IS NULL
+		ORDER BY id
+	`, adID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.Complaint, 0)
+	for rows.Next() {
+		var c domain.Complaint
+		var reason string
+		if err := rows.Scan(&c.ID, &c.AdID, &c.SellerID, &c.UserID, &reason, &c.Comment, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.Reason = domain.ComplaintReason(reason)
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+func (r *PostgresRepo) ResolveComplaints(ctx context.Context, adID, adminID int64, outcome domain.ComplaintOutcome, reject *domain.ModerationDecision) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE ad_complaints
+		SET outcome=$3, resolved_by=$2, resolved_at=now()
+		WHERE ad_id=$1 AND resolved_at IS NULL
+	`, adID, adminID, string(outcome))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return 0, err
+	}
+
+	if reject != nil {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE ads
+			SET status = 'rejected', status_changed_at = now()
+			WHERE id=$1 AND status IN ('published', 'moderation')
+		`, adID)
+		if err != nil {
+			return 0, err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return 0, errors.New("ad status changed concurrently")
+		}
+		if err := saveDecision(ctx, tx, reject); err != nil {
+			return 0, err
+		}
+	}
+	return n, tx.Commit()
+}
+
+func (r *PostgresRepo) OpenComplaintCounts(ctx context.Context, adIDs []int64) (map[int64]int64, error) {
+	out := make(map[int64]int64, len(adIDs))
+	if len(adIDs) == 0 {
+		return out, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ad_id, COUNT(1) FROM ad_complaints
+		WHERE ad_id = ANY($1) AND resolved_at IS NULL
+		GROUP BY ad_id
+	`, pq.Array(adIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, n int64
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		out[id] = n
+	}
+	return out, rows.Err()
+}
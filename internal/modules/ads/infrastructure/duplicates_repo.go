@@ -0,0 +1,124 @@
+package infrastructure
+
+import (
+	"context"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+// FindDuplicates сравнивает объявление с активными (на модерации и опубликованными):
+// тот же автомобиль по VIN, похожие фото (расстояние Хэмминга dHash) и совпадение
+// марки/модели/года/города при близком пробеге.
+// Фото сравниваются только у объявлений той же марки и модели: кандидаты берутся
+// по ix_ads_duplicate_attrs, а не перебором всех фото в базе.
+func (r *PostgresRepo) FindDuplicates(ctx context.Context, adID int64) ([]domain.Duplicate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH me AS (
+			SELECT id, seller_id, vehicle_id, brand, model, year, mileage, city FROM ads WHERE id=$1
+		), cand AS NOT MATERIALIZED (
+			SELECT c.* FROM ads c, me
+			WHERE c.id <> me.id AND c.status IN ('moderation', 'published')
+		)
+		SELECT c.id, 'vin', c.seller_id = me.seller_id
+		FROM cand c, me
+		WHERE me.vehicle_id IS NOT NULL AND c.vehicle_id = me.vehicle_id
+		UNION
+		SELECT c.id, 'photo', c.seller_id = me.seller_id
+		FROM me
+		JOIN cand c ON lower(c.brand) = lower(me.brand) AND lower(c.model) = lower(me.model)
+		JOIN ad_photos cp ON cp.ad_id = c.id AND cp.phash IS NOT NULL
+		JOIN ad_photos mp ON mp.ad_id = me.id AND mp.phash IS NOT NULL
+		WHERE bit_count((mp.phash # cp.phash)::bit(64)) <= $2
+		UNION
+		SELECT c.id, 'attributes', c.seller_id = me.seller_id
+		FROM cand c, me
+		WHERE lower(c.brand) = lower(me.brand)
+		  AND lower(c.model) = lower(me.model)
+		  AND c.year = me.year
+		  AND lower(c.city) = lower(me.city)
+		  AND abs(c.mileage - me.mileage) <= $3
+		ORDER BY 1, 2
+	`, adID, domain.PhotoHashMaxDistance, domain.DuplicateMileageDelta)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.Duplicate
+	for rows.Next() {
+		d := domain.Duplicate{AdID: adID, Resolution: domain.DuplicatePending}
+		var reason string
+		if err := rows.Scan(&d.DuplicateOf, &reason, &d.SameSeller); err != nil {
+			return nil, err
+		}
+		d.Reason = domain.DuplicateReason(reason)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) SaveDuplicates(ctx context.Context, adID int64, dups []domain.Duplicate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ad_duplicates WHERE ad_id=$1 AND resolution='pending'`, adID); err != nil {
+		return err
+	}
+	for _, d := range dups {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO ad_duplicates (ad_id, duplicate_of, reason, same_seller)
+			VALUES ($1,$2,$3,$4)
+			ON CONFLICT (ad_id, duplicate_of, reason) DO NOTHING
+		`, adID, d.DuplicateOf, string(d.Reason), d.SameSeller); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) DuplicatesByAds(ctx context.Context, adIDs []int64) (map[int64][]domain.Duplicate, error) {
+	out := make(map[int64][]domain.Duplicate, len(adIDs))
+	if len(adIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ad_id, duplicate_of, reason, same_seller, resolution, created_at
+		FROM ad_duplicates
+		WHERE ad_id = ANY($1) AND resolution = 'pending'
+		ORDER BY ad_id, duplicate_of, reason
+	`, pq.Array(adIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d domain.Duplicate
+		var reason, res string
+		if err := rows.Scan(&d.AdID, &d.DuplicateOf, &reason, &d.SameSeller, &res, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		d.Reason = domain.DuplicateReason(reason)
+		d.Resolution = domain.DuplicateResolution(res)
+		out[d.AdID] = append(out[d.AdID], d)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) ResolveDuplicates(ctx context.Context, adID, duplicateOf, moderatorID int64, res domain.DuplicateResolution) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE ad_duplicates
+		SET resolution=$3, resolved_by=NULLIF($4, 0), resolved_at=now()
+		WHERE ad_id=$1 AND ($2::bigint = 0 OR duplicate_of=$2) AND resolution='pending'
+	`, adID, duplicateOf, string(res), moderatorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicatesPhotoCandidates(t *testing.T) {
+	repo, rec := newRecRepo(t)
+	if _, err := repo.FindDuplicates(context.Background(), 1); err != nil {
+		t.Fatalf("FindDuplicates: %v", err)
+	}
+	if len(rec.queries) != 1 {
+		t.Fatalf("got %d queries, want 1", len(rec.queries))
+	}
+	q := rec.queries[0]
+
+	// ветка фото — между 'photo' и следующим UNION
+	i := strings.Index(q, "'photo'")
+	if i < 0 {
+		t.Fatalf("no photo branch:\n%s", q)
+	}
+	photo := q[i:]
+	photo = photo[:strings.Index(photo, "UNION")]
+
+	space := regexp.MustCompile(`\s+`)
+	photo = space.ReplaceAllString(photo, " ")
+	for _, want := range []string{
+		"lower(c.brand) = lower(me.brand) AND lower(c.model) = lower(me.model)",
+		"cp.ad_id = c.id",
+		"bit_count((mp.phash # cp.phash)::bit(64)) <= $2",
+	} {
+		if !strings.Contains(photo, want) {
+			t.Errorf("photo branch has no %q:\n%s", want, photo)
+		}
+	}
+	if strings.Contains(photo, "replace(") {
+		t.Errorf("photo branch still counts bits via text:\n%s", photo)
+	}
+	if !strings.Contains(q, "cand AS NOT MATERIALIZED") {
+		t.Errorf("candidates CTE must be inlined to use indexes:\n%s", q)
+	}
+}
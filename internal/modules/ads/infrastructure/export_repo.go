@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// sitemapLastMod — последнее видимое изменение объявления.
+const sitemapLastMod = `GREATEST(a.updated_at, COALESCE(a.published_at, a.updated_at))`
+
+func (r *PostgresRepo) PublishedAfter(ctx context.Context, afterID int64, limit int) ([]domain.Ad, error) {
+	rows, err := r.db.QueryContext(ctx, adSelect+`
+		WHERE a.status = 'published' AND a.id > $1
+		ORDER BY a.id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.Ad
+	for rows.Next() {
+		ad, err := scanAd(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *ad)
+	}
+	return items, rows.Err()
+}
+
+func (r *PostgresRepo) SitemapSegments(ctx context.Context, size int64) ([]domain.SitemapSegment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id / $1 AS seg, count(*), max(`+sitemapLastMod+`)
+		FROM ads a
+		WHERE a.status = 'published'
+		GROUP BY seg
+		ORDER BY seg
+	`, size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.SitemapSegment
+	for rows.Next() {
+		var s domain.SitemapSegment
+		if err := rows.Scan(&s.Index, &s.Count, &s.LastMod); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) SitemapSegment(ctx context.Context, segment, size int64) (domain.SitemapSegment, error) {
+	out := domain.SitemapSegment{Index: segment}
+	var lastMod sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT count(*), max(`+sitemapLastMod+`)
+		FROM ads a
+		WHERE a.status = 'published' AND a.id >= $1 AND a.id < $2
+	`, segment*size, (segment+1)*size).Scan(&out.Count, &lastMod)
+	out.LastMod = lastMod.Time
+	return out, err
+}
+
+func (r *PostgresRepo) SitemapEntries(ctx context.Context, segment, size int64) ([]domain.SitemapEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id, `+sitemapLastMod+`
+		FROM ads a
+		WHERE a.status = 'published' AND a.id >= $1 AND a.id < $2
+		ORDER BY a.id
+	`, segment*size, (segment+1)*size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.SitemapEntry
+	for rows.Next() {
+		var e domain.SitemapEntry
+		if err := rows.Scan(&e.AdID, &e.LastMod); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
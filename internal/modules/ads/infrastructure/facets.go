@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+const facetValuesLimit = 30
+
+// Facets считает все фасеты одним запросом: UNION ALL подзапросов, каждый со
+// своим WHERE (фильтр фасета исключён), аргументы общие.
+func (r *PostgresRepo) Facets(ctx context.Context, q domain.SearchQuery, f domain.ListFilter) (*domain.Facets, error) {
+	query, args := facetsQuery(q, f)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := &domain.Facets{
+		Brands:     []domain.FacetCount{},
+		Cities:     []domain.FacetCount{},
+		Inspection: []domain.FacetCount{},
+		Price:      domain.NewRangeBuckets(domain.PriceBucketEdges),
+		Year:       domain.NewRangeBuckets(domain.YearBucketEdges),
+		Mileage:    domain.NewRangeBuckets(domain.MileageBucketEdges),
+	}
+	for rows.Next() {
+		var facet, value string
+		var count int64
+		if err := rows.Scan(&facet, &value, &count); err != nil {
+			return nil, err
+		}
+		switch facet {
+		case "total":
+			out.Total = count
+		case "brand":
+			out.Brands = append(out.Brands, domain.FacetCount{Value: value, Count: count})
+		case "city":
+			out.Cities = append(out.Cities, domain.FacetCount{Value: value, Count: count})
+		case "inspection":
+			out.Inspection = append(out.Inspection, domain.FacetCount{Value: value, Count: count})
+		case "price":
+			setBucket(out.Price, value, count)
+		case "year":
+			setBucket(out.Year, value, count)
+		case "mileage":
+			setBucket(out.Mileage, value, count)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// facetsQuery собирает запрос Facets; плейсхолдеры всех подзапросов нумеруются
+// по общему списку аргументов.
+func facetsQuery(q domain.SearchQuery, f domain.ListFilter) (string, []any) {
+	all := &whereBuilder{}
+	var parts []string
+
+	where := func(mod func(*domain.ListFilter)) string {
+		ff := f
+		if mod != nil {
+			mod(&ff)
+		}
+		b := all.sub()
+		applyPublicFilter(b, ff)
+		applySearch(b, q)
+		all.adopt(b)
+		return b.sql()
+	}
+	values := func(name, col string, mod func(*domain.ListFilter)) {
+		parts = append(parts, fmt.Sprintf(`(SELECT '%s', %s, COUNT(1) FROM ads a %s GROUP BY 2 ORDER BY 3 DESC, 2 LIMIT %d)`,
+			name, col, where(mod), facetValuesLimit))
+	}
+	buckets := func(name, col string, edges []int, mod func(*domain.ListFilter)) {
+		w := where(mod)
+		edgesN := all.next(pq.Array(edges))
+		parts = append(parts, fmt.Sprintf(`(SELECT '%s', width_bucket(%s, $%d::int[])::text, COUNT(1) FROM ads a %s GROUP BY 2)`,
+			name, col, edgesN, w))
+	}
+
+	parts = append(parts, fmt.Sprintf(`(SELECT 'total', '', COUNT(1) FROM ads a %s)`, where(nil)))
+	values("brand", "a.brand", func(ff *domain.ListFilter) { ff.Brand, ff.BrandID = "", nil })
+	values("city", "a.city", func(ff *domain.ListFilter) { ff.City = "" })
+	values("inspection", "a.inspection_status", func(ff *domain.ListFilter) { ff.Inspection = ""; ff.VerifiedOnly = nil })
+	buckets("price", "a.price", domain.PriceBucketEdges, func(ff *domain.ListFilter) { ff.PriceFrom, ff.PriceTo = nil, nil })
+	buckets("year", "a.year", domain.YearBucketEdges, func(ff *domain.ListFilter) { ff.YearFrom, ff.YearTo = nil, nil })
+	buckets("mileage", "a.mileage", domain.MileageBucketEdges, func(ff *domain.ListFilter) { ff.MileageFrom, ff.MileageTo = nil, nil })
+
+	return strings.Join(parts, "\nUNION ALL\n"), all.args
+}
+
+func setBucket(buckets []domain.RangeBucket, idx string, count int64) {
+	i, err := strconv.Atoi(idx)
+	if err != nil || i < 0 || i >= len(buckets) {
+		return
+	}
+	buckets[i].Count = count
+}
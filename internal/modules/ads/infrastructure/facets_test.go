@@ -0,0 +1,131 @@
+package infrastructure
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+var placeholderRe = regexp.MustCompile(`\$(\d+)`)
+
+// renderArgs подставляет аргументы вместо $N — так видно, что каждое условие
+// получает своё значение, а не соседнее.
+func renderArgs(t *testing.T, part string, args []any, used []bool) string {
+	t.Helper()
+	return placeholderRe.ReplaceAllStringFunc(part, func(ph string) string {
+		n, _ := strconv.Atoi(ph[1:])
+		if n < 1 || n > len(args) {
+			t.Fatalf("placeholder %s out of range (%d args):\n%s", ph, len(args), part)
+		}
+		used[n-1] = true
+		v := reflect.Indirect(reflect.ValueOf(args[n-1])).Interface() // *pq.StringArray
+		if a, ok := v.(pq.GenericArray); ok {
+			v = a.A
+		}
+		return fmt.Sprint(v)
+	})
+}
+
+func TestFacetsQuery(t *testing.T) {
+	brandID, priceFrom, priceTo, yearFrom, mileageTo, verified := int64(5), 100000, 2000000, 2015, 150000, true
+	f := domain.ListFilter{
+		Brand:         "BMW",
+		BrandID:       &brandID,
+		City:          "Казань",
+		Inspection:    "done",
+		VerifiedOnly:  &verified,
+		PriceFrom:     &priceFrom,
+		PriceTo:       &priceTo,
+		YearFrom:      &yearFrom,
+		MileageTo:     &mileageTo,
+		Fuels:         []string{"diesel"},
+		Transmissions: []string{"automatic"},
+	}
+	query, args := facetsQuery(domain.ParseSearchQuery("икс5"), f)
+
+	conds := map[string][]string{
+		"brand":      {"(a.brand_id = 5 OR (a.brand_id IS NULL AND lower(a.brand) = lower(BMW)))"},
+		"city":       {"lower(a.city) = lower(Казань)"},
+		"inspection": {"a.inspection_status = done", "a.inspection_status IN ('done','certified')"},
+		"price":      {"a.price >= 100000", "a.price <= 2000000"},
+		"year":       {"a.year >= 2015"},
+		"mileage":    {"a.mileage <= 150000"},
+	}
+	// общие условия — в каждом подзапросе
+	common := []string{
+		"a.status = published",
+		"a.fuel = ANY([diesel])",
+		"a.transmission = ANY([automatic])",
+		"plainto_tsquery('russian', икс5) OR икс5 <%",
+	}
+	edges := map[string][]int{
+		"price":   domain.PriceBucketEdges,
+		"year":    domain.YearBucketEdges,
+		"mileage": domain.MileageBucketEdges,
+	}
+
+	parts := strings.Split(query, "\nUNION ALL\n")
+	names := []string{"total", "brand", "city", "inspection", "price", "year", "mileage"}
+	if len(parts) != len(names) {
+		t.Fatalf("got %d parts, want %d:\n%s", len(parts), len(names), query)
+	}
+	used := make([]bool, len(args))
+	for i, name := range names {
+		part := parts[i]
+		if !strings.HasPrefix(part, "(SELECT '"+name+"'") {
+			t.Fatalf("part %d is not %s facet:\n%s", i, name, part)
+		}
+		got := renderArgs(t, part, args, used)
+
+		for _, c := range common {
+			if !strings.Contains(got, c) {
+				t.Errorf("%s: no common condition %q:\n%s", name, c, got)
+			}
+		}
+		for facet, cs := range conds {
+			for _, c := range cs {
+				// фасет не фильтрует сам себя, но учитывает все остальные фильтры
+				if has := strings.Contains(got, c); has == (facet == name) {
+					t.Errorf("%s: condition %q present = %v:\n%s", name, c, has, got)
+				}
+			}
+		}
+		if e, ok := edges[name]; ok {
+			if !strings.Contains(got, fmt.Sprintf("width_bucket(a.%s, %v::int[])", name, e)) {
+				t.Errorf("%s: bucket edges not aligned:\n%s", name, got)
+			}
+		}
+	}
+	for i, u := range used {
+		if !u {
+			t.Errorf("arg $%d (%v) is never referenced", i+1, args[i])
+		}
+	}
+}
+
+func TestFacetsQueryNoFilters(t *testing.T) {
+	query, args := facetsQuery(domain.SearchQuery{}, domain.ListFilter{})
+	// статус на каждый из 7 подзапросов + границы трёх гистограмм
+	if len(args) != 10 {
+		t.Fatalf("args = %d, want 10", len(args))
+	}
+	want := []any{
+		"published", "published", "published", "published",
+		"published", pq.Array(domain.PriceBucketEdges),
+		"published", pq.Array(domain.YearBucketEdges),
+		"published", pq.Array(domain.MileageBucketEdges),
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %v", args)
+	}
+	if n := len(placeholderRe.FindAllString(query, -1)); n != len(args) {
+		t.Fatalf("placeholders = %d, want %d", n, len(args))
+	}
+}
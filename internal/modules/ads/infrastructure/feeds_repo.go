@@ -0,0 +1,234 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func (r *PostgresRepo) FeedAds(ctx context.Context, sellerID int64) (map[string]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT external_id, ad_id FROM dealer_feed_ads WHERE seller_id=$1`, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]int64)
+	for rows.Next() {
+		var ext string
+		var adID int64
+		if err := rows.Scan(&ext, &adID); err != nil {
+			return nil, err
+		}
+		out[ext] = adID
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) CreateFeedAd(ctx context.Context, ad *domain.Ad, externalID string) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := insertAd(ctx, tx, ad)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO dealer_feed_ads (seller_id, external_id, ad_id)
+		VALUES ($1,$2,$3)
+		ON CONFLICT (seller_id, external_id) DO UPDATE SET ad_id = EXCLUDED.ad_id
+	`, ad.SellerID, externalID, id); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+const feedColumns = `id, seller_id, url, format, active, last_sync_at, last_result, created_at`
+
+func scanFeed(row rowScanner) (*domain.Feed, error) {
+	var f domain.Feed
+	var lastSync sql.NullTime
+	var result []byte
+	if err := row.Scan(&f.ID, &f.SellerID, &f.URL, &f.Format, &f.Active, &lastSync, &result, &f.CreatedAt); err != nil {
+		return nil, err
+	}
+	if lastSync.Valid {
+		f.LastSyncAt = &lastSync.Time
+	}
+	if len(result) > 0 {
+		f.LastResult = &domain.FeedResult{}
+		if err := json.Unmarshal(result, f.LastResult); err != nil {
+			return nil, err
+		}
+	}
+	return &f, nil
+}
+
+func (r *PostgresRepo) CreateFeed(ctx context.Context, f *domain.Feed) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO dealer_feeds (seller_id, url, format, active)
+		VALUES ($1,$2,$3,$4)
+		RETURNING id, created_at
+	`, f.SellerID, f.URL, string(f.Format), f.Active).Scan(&f.ID, &f.CreatedAt)
+	return f.ID, err
+}
+
+func (r *PostgresRepo) GetFeed(ctx context.Context, id int64) (*domain.Feed, error) {
+	f, err := scanFeed(r.db.QueryRowContext(ctx, `SELECT `+feedColumns+` FROM dealer_feeds WHERE id=$1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("feed not found")
+	}
+	return f, err
+}
+
+func (r *PostgresRepo) ListFeeds(ctx context.Context, sellerID int64) ([]domain.Feed, error) {
+	return r.queryFeeds(ctx, `SELECT `+feedColumns+` FROM dealer_feeds WHERE seller_id=$1 ORDER BY id`, sellerID)
+}
+
+func (r *PostgresRepo) ActiveFeeds(ctx context.Context) ([]domain.Feed, error) {
+	return r.queryFeeds(ctx, `SELECT `+feedColumns+` FROM dealer_feeds WHERE active ORDER BY last_sync_at NULLS FIRST, id`)
+}
+
+func (r *PostgresRepo) queryFeeds(ctx context.Context, query string, args ...any) ([]domain.Feed, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Feed{}
+	for rows.Next() {
+		f, err := scanFeed(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *f)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) DeleteFeed(ctx context.Context, id, sellerID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM dealer_feeds WHERE id=$1 AND seller_id=$2`, id, sellerID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("feed not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepo) SaveFeedResult(ctx context.Context, id int64, res *domain.FeedResult) error {
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `UPDATE dealer_feeds SET last_sync_at=now(), last_result=$2 WHERE id=$1`, id, raw)
+	return err
+}
+
+// CreateFeedImport: одна незавершённая синхронизация на фид гарантируется
+// уникальным индексом ux_dealer_feed_imports_active_feed.
+func (r *PostgresRepo) CreateFeedImport(ctx context.Context, imp *domain.FeedImport, payload []byte) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO dealer_feed_imports (seller_id, feed_id, format, archive_missing, payload, status)
+		VALUES ($1,$2,$3,$4,$5,$6)
+		ON CONFLICT (feed_id) WHERE feed_id IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+		RETURNING id, created_at
+	`, imp.SellerID, imp.FeedID, string(imp.Format), imp.ArchiveMissing, payload, string(domain.FeedImportPending)).
+		Scan(&imp.ID, &imp.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrFeedSyncQueued
+	}
+	return err
+}
+
+const feedImportColumns = `id, seller_id, feed_id, format, archive_missing, status, result, error, created_at, finished_at`
+
+func scanFeedImport(row rowScanner, extra ...any) (*domain.FeedImport, error) {
+	var imp domain.FeedImport
+	var feedID sql.NullInt64
+	var result []byte
+	var finished sql.NullTime
+	dest := []any{&imp.ID, &imp.SellerID, &feedID, &imp.Format, &imp.ArchiveMissing, &imp.Status, &result, &imp.Error, &imp.CreatedAt, &finished}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if feedID.Valid {
+		imp.FeedID = &feedID.Int64
+	}
+	if finished.Valid {
+		imp.FinishedAt = &finished.Time
+	}
+	if len(result) > 0 {
+		imp.Result = &domain.FeedResult{}
+		if err := json.Unmarshal(result, imp.Result); err != nil {
+			return nil, err
+		}
+	}
+	return &imp, nil
+}
+
+func (r *PostgresRepo) ClaimFeedImport(ctx context.Context, staleBefore time.Time) (*domain.FeedImport, []byte, error) {
+	var payload []byte
+	imp, err := scanFeedImport(r.db.QueryRowContext(ctx, `
+		UPDATE dealer_feed_imports
+		SET status = 'running', started_at = now()
+		WHERE id = (
+			SELECT id FROM dealer_feed_imports
+			WHERE status = 'pending' OR (status = 'running' AND started_at < $1)
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+feedImportColumns+`, payload
+	`, staleBefore), &payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return imp, payload, nil
+}
+
+func (r *PostgresRepo) TouchFeedImport(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE dealer_feed_imports SET started_at = now() WHERE id=$1 AND status='running'`, id)
+	return err
+}
+
+// FinishFeedImport сохраняет итог; файл продавца больше не нужен и удаляется.
+func (r *PostgresRepo) FinishFeedImport(ctx context.Context, id int64, res *domain.FeedResult, errText string) error {
+	status := domain.FeedImportDone
+	if errText != "" {
+		status = domain.FeedImportFailed
+	}
+	var raw []byte
+	if res != nil {
+		var err error
+		if raw, err = json.Marshal(res); err != nil {
+			return err
+		}
+	}
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE dealer_feed_imports
+		SET status=$2, result=$3, error=$4, payload=NULL, finished_at=now()
+		WHERE id=$1
+	`, id, string(status), raw, errText)
+	return err
+}
+
+func (r *PostgresRepo) GetFeedImport(ctx context.Context, id int64) (*domain.FeedImport, error) {
+	imp, err := scanFeedImport(r.db.QueryRowContext(ctx, `SELECT `+feedImportColumns+` FROM dealer_feed_imports WHERE id=$1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("import not found")
+	}
+	return imp, err
+}
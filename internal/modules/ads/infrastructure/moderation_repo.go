@@ -0,0 +1,198 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func (r *PostgresRepo) ModerationQueue(ctx context.Context, f domain.QueueFilter) ([]domain.QueueItem, int64, error) {
+	w := &whereBuilder{}
+	w.add("a.status = $%d", string(domain.AdModeration))
+	if f.UnclaimedOnly {
+		w.add("(mc.ad_id IS NULL OR mc.expires_at < now() OR mc.moderator_id = $%d)", f.ModeratorID)
+	}
+
+	from := adFrom + ` LEFT JOIN moderation_claims mc ON mc.ad_id = a.id `
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(1) `+from+w.sql(), w.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	limitN := w.next(f.Limit)
+	offsetN := w.next(f.Offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s, a.status_changed_at,
+		       CASE WHEN mc.expires_at >= now() THEN mc.moderator_id END,
+		       CASE WHEN mc.expires_at >= now() THEN mc.expires_at END
+		%s %s
+		ORDER BY a.status_changed_at ASC, a.id ASC
+		LIMIT $%d OFFSET $%d
+	`, adColumns, from, w.sql(), limitN, offsetN), w.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.QueueItem, 0)
+	for rows.Next() {
+		var it domain.QueueItem
+		var claimedBy sql.NullInt64
+		var claimExp sql.NullTime
+		ad, err := scanAd(rows, &it.WaitingSince, &claimedBy, &claimExp)
+		if err != nil {
+			return nil, 0, err
+		}
+		it.Ad = *ad
+		if claimedBy.Valid {
+			it.ClaimedBy = &claimedBy.Int64
+			it.ClaimExpiresAt = &claimExp.Time
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// ClaimForModeration: занять свободный/просроченный захват или продлить свой.
+func (r *PostgresRepo) ClaimForModeration(ctx context.Context, adID, moderatorID int64, ttl time.Duration) (bool, error) {
+	var status string
+	if err := r.db.QueryRowContext(ctx, `SELECT status FROM ads WHERE id=$1`, adID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, errors.New("ad not found")
+		}
+		return false, err
+	}
+	if domain.AdStatus(status) != domain.AdModeration {
+		return false, errors.New("ad is not in moderation")
+	}
+
+	var holder int64
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO moderation_claims (ad_id, moderator_id, claimed_at, expires_at)
+		VALUES ($1, $2, now(), now() + $3 * interval '1 second')
+		ON CONFLICT (ad_id) DO UPDATE
+		SET moderator_id = EXCLUDED.moderator_id,
+		    claimed_at = CASE WHEN moderation_claims.moderator_id = EXCLUDED.moderator_id
+		                      THEN moderation_claims.claimed_at ELSE EXCLUDED.claimed_at END,
+		    expires_at = EXCLUDED.expires_at
+		WHERE moderation_claims.moderator_id = EXCLUDED.moderator_id
+		   OR moderation_claims.expires_at < now()
+		RETURNING moderator_id
+	`, adID, moderatorID, int64(ttl/time.Second)).Scan(&holder)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return holder == moderatorID, nil
+}
+
+func (r *PostgresRepo) ReleaseClaim(ctx context.Context, adID, moderatorID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM moderation_claims WHERE ad_id=$1 AND moderator_id=$2`, adID, moderatorID)
+	return err
+}
+
+func (r *PostgresRepo) Decide(ctx context.Context, d *domain.ModerationDecision, to domain.AdStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// чужой действующий захват блокирует решение
+	var holder sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT moderator_id FROM moderation_claims
+		WHERE ad_id=$1 AND expires_at >= now()
+		FOR UPDATE
+	`, d.AdID).Scan(&holder)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if holder.Valid && holder.Int64 != d.ModeratorID {
+		return errors.New("ad is claimed by another moderator")
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE ads
+		SET status = $2,
+		    status_changed_at = now(),
+		    published_at = CASE WHEN $2 = 'published' THEN now() ELSE published_at END
+		WHERE id=$1 AND status='moderation'
+	`, d.AdID, string(to))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("ad is not in moderation")
+	}
+
+	if err := saveDecision(ctx, tx, d); err != nil {
+		return err
+	}
+	if to == domain.AdPublished {
+		// одобрение снимает накопленные жалобы, иначе следующая же жалоба
+		// снова превысит порог
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE ad_complaints
+			SET outcome='dismissed', resolved_by=NULLIF($2, 0), resolved_at=now()
+			WHERE ad_id=$1 AND resolved_at IS NULL
+		`, d.AdID, d.ModeratorID); err != nil {
+			return err
+		}
+		if err := enqueueMatching(ctx, tx, d.AdID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// saveDecision записывает решение и снимает захват модератора.
+func saveDecision(ctx context.Context, tx *sql.Tx, d *domain.ModerationDecision) error {
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO moderation_decisions (ad_id, moderator_id, decision, reason, comment)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+		RETURNING id, created_at
+	`, d.AdID, d.ModeratorID, string(d.Decision), string(d.Reason), d.Comment).Scan(&d.ID, &d.CreatedAt); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM moderation_claims WHERE ad_id=$1`, d.AdID)
+	return err
+}
+
+func (r *PostgresRepo) ListDecisions(ctx context.Context, adID int64) ([]domain.ModerationDecision, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, ad_id, COALESCE(moderator_id, 0), decision, reason, comment, created_at
+		FROM moderation_decisions
+		WHERE ad_id=$1
+		ORDER BY id DESC
+	`, adID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.ModerationDecision, 0)
+	for rows.Next() {
+		var d domain.ModerationDecision
+		var decision, reason string
+		if err := rows.Scan(&d.ID, &d.AdID, &d.ModeratorID, &decision, &reason, &d.Comment, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		d.Decision = domain.ModerationDecisionType(decision)
+		d.Reason = domain.RejectionReason(reason)
+		d.ReasonText = domain.RejectionReasons[d.Reason]
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,179 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+const photoColumns = `id, ad_id, position, is_cover, original_key, medium_key, thumb_key`
+
+func scanPhoto(row rowScanner) (*domain.Photo, error) {
+	var p domain.Photo
+	var orig, medium, thumb string
+	if err := row.Scan(&p.ID, &p.AdID, &p.Position, &p.IsCover, &orig, &medium, &thumb); err != nil {
+		return nil, err
+	}
+	p.Keys = map[domain.PhotoVariant]string{
+		domain.PhotoOriginal: orig,
+		domain.PhotoMedium:   medium,
+		domain.PhotoThumb:    thumb,
+	}
+	return &p, nil
+}
+
+// AddPhoto добавляет фото в конец списка; первое фото объявления становится обложкой.
+func (r *PostgresRepo) AddPhoto(ctx context.Context, p *domain.Photo) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// сериализуем загрузки в одно объявление, чтобы позиции не совпадали
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM ads WHERE id=$1 FOR UPDATE`, p.AdID); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO ad_photos (ad_id, position, is_cover, original_key, medium_key, thumb_key, phash)
+		VALUES (
+			$1,
+			(SELECT COALESCE(MAX(position) + 1, 0) FROM ad_photos WHERE ad_id=$1),
+			NOT EXISTS (SELECT 1 FROM ad_photos WHERE ad_id=$1 AND is_cover),
+			$2, $3, $4, $5
+		)
+		RETURNING id, position, is_cover
+	`, p.AdID, p.Keys[domain.PhotoOriginal], p.Keys[domain.PhotoMedium], p.Keys[domain.PhotoThumb], int64(p.Hash)).
+		Scan(&id, &p.Position, &p.IsCover)
+	if err != nil {
+		return 0, err
+	}
+	p.ID = id
+
+	return id, tx.Commit()
+}
+
+func (r *PostgresRepo) ListPhotos(ctx context.Context, adID int64) ([]domain.Photo, error) {
+	m, err := r.PhotosByAds(ctx, []int64{adID})
+	if err != nil {
+		return nil, err
+	}
+	return m[adID], nil
+}
+
+func (r *PostgresRepo) PhotosByAds(ctx context.Context, adIDs []int64) (map[int64][]domain.Photo, error) {
+	out := make(map[int64][]domain.Photo, len(adIDs))
+	if len(adIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+photoColumns+`
+		FROM ad_photos
+		WHERE ad_id = ANY($1)
+		ORDER BY ad_id, position
+	`, pq.Array(adIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPhoto(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[p.AdID] = append(out[p.AdID], *p)
+	}
+	return out, rows.Err()
+}
+
+// DeletePhoto удаляет запись и возвращает её (ключи нужны для очистки хранилища).
+// Если удалили обложку — обложкой становится первое оставшееся фото.
+func (r *PostgresRepo) DeletePhoto(ctx context.Context, adID, photoID int64) (*domain.Photo, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	p, err := scanPhoto(tx.QueryRowContext(ctx, `
+		DELETE FROM ad_photos WHERE id=$1 AND ad_id=$2
+		RETURNING `+photoColumns, photoID, adID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("photo not found")
+		}
+		return nil, err
+	}
+
+	if p.IsCover {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE ad_photos SET is_cover = TRUE
+			WHERE id = (SELECT id FROM ad_photos WHERE ad_id=$1 ORDER BY position LIMIT 1)
+		`, adID); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, tx.Commit()
+}
+
+// ReorderPhotos выставляет позиции по порядку photoIDs; список должен содержать
+// ровно все фото объявления.
+func (r *PostgresRepo) ReorderPhotos(ctx context.Context, adID int64, photoIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(1) FROM ad_photos WHERE ad_id=$1`, adID).Scan(&count); err != nil {
+		return err
+	}
+	if count != len(photoIDs) {
+		return errors.New("photo_ids must list every photo of the ad")
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE ad_photos p SET position = o.ord - 1
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS o(id, ord)
+		WHERE p.id = o.id AND p.ad_id = $1
+	`, adID, pq.Array(photoIDs))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); int(n) != len(photoIDs) {
+		return errors.New("photo_ids must list every photo of the ad")
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) SetCoverPhoto(ctx context.Context, adID, photoID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE ad_photos SET is_cover = FALSE WHERE ad_id=$1 AND is_cover`, adID); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `UPDATE ad_photos SET is_cover = TRUE WHERE id=$1 AND ad_id=$2`, photoID, adID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("photo not found")
+	}
+
+	return tx.Commit()
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
 
 	"autera/internal/modules/ads/domain"
 )
@@ -13,87 +17,304 @@ type PostgresRepo struct{ db *sql.DB }
 func NewPostgresRepo(db *sql.DB) *PostgresRepo { return &PostgresRepo{db: db} }
 
 func (r *PostgresRepo) Create(ctx context.Context, ad *domain.Ad) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := insertAd(ctx, tx, ad)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// insertAd вставляет объявление вместе с привязкой к автомобилю по VIN.
+func insertAd(ctx context.Context, tx *sql.Tx, ad *domain.Ad) (int64, error) {
+	vehicleID, err := upsertVehicle(ctx, tx, ad.VIN)
+	if err != nil {
+		return 0, err
+	}
+
 	var id int64
-	err := r.db.QueryRowContext(ctx, `
-		INSERT INTO ads (seller_id, brand, model, year, mileage, price, vin, city, status, inspection_status)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO ads (seller_id, brand, model, year, mileage, price, vin, city, description, status, inspection_status,
+		                 brand_id, model_id, generation_id,
+		                 transmission, fuel, drive, body_type, engine_volume, color, steering, customs_cleared,
+		                 vehicle_id)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)
 		RETURNING id
 	`,
-		ad.SellerID, ad.Brand, ad.Model, ad.Year, ad.Mileage, ad.Price, ad.VIN, ad.City, string(ad.Status), string(ad.InspectionState),
+		ad.SellerID, ad.Brand, ad.Model, ad.Year, ad.Mileage, ad.Price, ad.VIN, ad.City, ad.Description, string(ad.Status), string(ad.InspectionState),
+		ad.BrandID, ad.ModelID, ad.GenerationID,
+		string(ad.Spec.Transmission), string(ad.Spec.Fuel), string(ad.Spec.Drive), ad.Spec.BodyType, ad.Spec.EngineVolume, ad.Spec.Color, string(ad.Spec.Steering), ad.Spec.CustomsCleared,
+		vehicleID,
 	).Scan(&id)
 	return id, err
 }
 
-func (r *PostgresRepo) Get(ctx context.Context, id int64) (*domain.Ad, error) {
-	row := r.db.QueryRowContext(ctx, `
-		SELECT id, seller_id, brand, model, year, mileage, price, vin, city, status, inspection_status
-		FROM ads WHERE id=$1
-	`, id)
+// adColumns/adFrom — общая проекция объявления с баллом последнего отчёта проверки.
+const adColumns = `
+	a.id, a.seller_id, a.brand, a.model, a.year, a.mileage, a.price, a.vin, a.city, a.description,
+	a.status, a.inspection_status, sc.total_score, a.published_at, a.sold_price,
+	a.brand_id, a.model_id, a.generation_id,
+	a.transmission, a.fuel, a.drive, a.body_type, a.engine_volume, a.color, a.steering, a.customs_cleared,
+	a.price_before_drop, a.price_dropped_at,
+	EXISTS (SELECT 1 FROM ad_promotions hp
+	        WHERE hp.ad_id = a.id AND hp.kind = 'highlight' AND hp.status = 'active'
+	          AND now() >= hp.starts_at AND now() < hp.ends_at)`
+
+const adFrom = `
+	FROM ads a
+	LEFT JOIN LATERAL (
+		SELECT rp.total_score
+		FROM reports rp
+		JOIN inspections i ON i.id = rp.inspection_id
+		WHERE i.ad_id = a.id
+		ORDER BY rp.id DESC
+		LIMIT 1
+	) sc ON TRUE
+`
 
+const adSelect = `SELECT ` + adColumns + adFrom
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAd читает колонки adColumns; extra — дополнительные колонки после них.
+func scanAd(row rowScanner, extra ...any) (*domain.Ad, error) {
 	var ad domain.Ad
 	var st, ins string
-	if err := row.Scan(&ad.ID, &ad.SellerID, &ad.Brand, &ad.Model, &ad.Year, &ad.Mileage, &ad.Price, &ad.VIN, &ad.City, &st, &ins); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("ad not found")
-		}
+	var score, soldPrice sql.NullInt64
+	var publishedAt, droppedAt sql.NullTime
+	var beforeDrop sql.NullInt64
+	dest := []any{&ad.ID, &ad.SellerID, &ad.Brand, &ad.Model, &ad.Year, &ad.Mileage, &ad.Price, &ad.VIN, &ad.City, &ad.Description, &st, &ins, &score, &publishedAt, &soldPrice,
+		&ad.BrandID, &ad.ModelID, &ad.GenerationID,
+		&ad.Spec.Transmission, &ad.Spec.Fuel, &ad.Spec.Drive, &ad.Spec.BodyType, &ad.Spec.EngineVolume, &ad.Spec.Color, &ad.Spec.Steering, &ad.Spec.CustomsCleared,
+		&beforeDrop, &droppedAt, &ad.Highlighted}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
 		return nil, err
 	}
 	ad.Status = domain.AdStatus(st)
 	ad.InspectionState = domain.InspectionStatus(ins)
+	if score.Valid {
+		v := int(score.Int64)
+		ad.InspectionScore = &v
+	}
+	if publishedAt.Valid {
+		ad.PublishedAt = &publishedAt.Time
+	}
+	if soldPrice.Valid {
+		v := int(soldPrice.Int64)
+		ad.SoldPrice = &v
+	}
+	if beforeDrop.Valid && droppedAt.Valid && time.Since(droppedAt.Time) <= domain.PriceDropBadgePeriod {
+		ad.PriceDrop = &domain.PriceDrop{PreviousPrice: int(beforeDrop.Int64), DroppedAt: droppedAt.Time}
+	}
 	return &ad, nil
 }
 
+func (r *PostgresRepo) Get(ctx context.Context, id int64) (*domain.Ad, error) {
+	ad, err := scanAd(r.db.QueryRowContext(ctx, adSelect+` WHERE a.id=$1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("ad not found")
+		}
+		return nil, err
+	}
+	return ad, nil
+}
+
+// List — публичная выдача. На первой странице перед ней идёт блок продвигаемых
+// (Promoted); он сверх limit и не входит в total, те же объявления остаются
+// и на своих местах в выдаче.
 func (r *PostgresRepo) List(ctx context.Context, f domain.ListFilter) ([]domain.Ad, int64, error) {
-	// MVP: без динамического SQL билдера — позже заменишь на sql builder
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, seller_id, brand, model, year, mileage, price, vin, city, status, inspection_status
-		FROM ads
-		ORDER BY id DESC
-		LIMIT $1 OFFSET $2
-	`, f.Limit, f.Offset)
+	w := publicListWhere(f)
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM ads a `+w.sql(), w.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	var items []domain.Ad
+	if f.Offset == 0 && f.Cursor == nil {
+		promoted, err := r.promotedSlots(ctx, f)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = promoted
+	}
+
+	spec := sortSpecFor(f.Sort)
+	offset := f.Offset
+	if f.Cursor != nil {
+		spec.applyCursor(w, f.Cursor)
+		offset = 0
+	}
+	limitN := w.next(f.Limit)
+	offsetN := w.next(offset)
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`%s %s %s LIMIT $%d OFFSET $%d`,
+		adSelect, w.sql(), spec.orderBy(), limitN, offsetN), w.args...)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer rows.Close()
 
-	var items []domain.Ad
 	for rows.Next() {
-		var ad domain.Ad
-		var st, ins string
-		if err := rows.Scan(&ad.ID, &ad.SellerID, &ad.Brand, &ad.Model, &ad.Year, &ad.Mileage, &ad.Price, &ad.VIN, &ad.City, &st, &ins); err != nil {
+		ad, err := scanAd(rows)
+		if err != nil {
 			return nil, 0, err
 		}
-		ad.Status = domain.AdStatus(st)
-		ad.InspectionState = domain.InspectionStatus(ins)
-		items = append(items, ad)
+		items = append(items, *ad)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
 	}
 
+	return items, total, nil
+}
+
+// Search — полнотекстовый поиск (русская морфология) с нечётким совпадением
+// марки/модели/города через pg_trgm. Каждое слово запроса должно найтись хотя бы
+// одним из способов; годы из запроса фильтруют по году выпуска.
+func (r *PostgresRepo) Search(ctx context.Context, q domain.SearchQuery, f domain.ListFilter) ([]domain.SearchHit, int64, error) {
+	w := publicListWhere(f)
+	applySearch(w, q)
+
 	var total int64
-	_ = r.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM ads`).Scan(&total)
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM ads a `+w.sql(), w.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
 
-	return items, total, nil
+	textN := w.next(q.Text())
+	rank := fmt.Sprintf(`(ts_rank_cd(a.search_tsv, %s) + word_similarity($%d, %s))`,
+		orTSQuery(textN), textN, searchFuzzyExpr)
+	// совпадения размечаются управляющими символами, а HTML собирается
+	// в highlightHTML после экранирования текста
+	headline := fmt.Sprintf(`ts_headline('russian',
+		translate(a.brand || ' ' || a.model || ' ' || a.city || ' ' || a.description, chr(2) || chr(3), ''), %s,
+		'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxFragments=2, MaxWords=20, MinWords=5')`, orTSQuery(textN))
+
+	// по релевантности — только offset; при явной сортировке работает и курсор
+	order := fmt.Sprintf("ORDER BY %s DESC, a.id DESC", rank)
+	offset := f.Offset
+	if f.Sort != "" && f.Sort != domain.SortRelevance {
+		spec := sortSpecFor(f.Sort)
+		order = spec.orderBy()
+		if f.Cursor != nil {
+			spec.applyCursor(w, f.Cursor)
+			offset = 0
+		}
+	}
+	limitN := w.next(f.Limit)
+	offsetN := w.next(offset)
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`SELECT %s, %s, %s %s %s %s LIMIT $%d OFFSET $%d`,
+		adColumns, rank, headline, adFrom, w.sql(), order, limitN, offsetN), w.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var hits []domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		ad, err := scanAd(rows, &hit.Rank, &hit.Highlight)
+		if err != nil {
+			return nil, 0, err
+		}
+		hit.Ad = *ad
+		hit.Highlight = highlightHTML(hit.Highlight)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return hits, total, nil
 }
 
-func (r *PostgresRepo) SubmitToModeration(ctx context.Context, adID, sellerID int64) error {
+// Маркеры совпадений в ts_headline (chr(2)/chr(3)); из самого текста они вырезаются.
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+// highlightHTML экранирует фрагмент объявления и только потом размечает совпадения <b>.
+func highlightHTML(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, headlineStart, "<b>")
+	return strings.ReplaceAll(s, headlineStop, "</b>")
+}
+
+// Transition — compare-and-set статуса: меняет только если текущий статус from.
+// Проверка допустимости перехода — в application (машина состояний).
+func (r *PostgresRepo) Transition(ctx context.Context, adID int64, from, to domain.AdStatus) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE ads
+		SET status = $3,
+		    status_changed_at = now(),
+		    published_at = CASE WHEN $3 = 'published' THEN now() ELSE published_at END
+		WHERE id=$1 AND status=$2
+	`, adID, string(from), string(to))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("ad status changed concurrently")
+	}
+	if to == domain.AdPublished {
+		if err := enqueueMatching(ctx, tx, adID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) MarkSold(ctx context.Context, adID int64, from domain.AdStatus, finalPrice int) error {
 	res, err := r.db.ExecContext(ctx, `
-		UPDATE ads SET status='moderation'
-		WHERE id=$1 AND seller_id=$2 AND status IN ('draft','rejected')
-	`, adID, sellerID)
+		UPDATE ads
+		SET status = 'sold', sold_price = $3, sold_at = now(), status_changed_at = now()
+		WHERE id=$1 AND status=$2
+	`, adID, string(from), finalPrice)
 	if err != nil {
 		return err
 	}
-	n, _ := res.RowsAffected()
-	if n == 0 {
-		return errors.New("cannot submit to moderation")
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("ad status changed concurrently")
 	}
 	return nil
 }
 
-func (r *PostgresRepo) Moderate(ctx context.Context, adID int64, decision string) error {
-	status := "rejected"
-	if decision == "approve" {
-		status = "published"
+// ExpirePublished переводит в expired опубликованные раньше publishedBefore.
+func (r *PostgresRepo) ExpirePublished(ctx context.Context, publishedBefore time.Time) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE ads
+		SET status = 'expired', status_changed_at = now()
+		WHERE status = 'published' AND published_at < $1
+		RETURNING id
+	`, publishedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
 	}
-	_, err := r.db.ExecContext(ctx, `UPDATE ads SET status=$2 WHERE id=$1`, adID, status)
-	return err
+	return ids, rows.Err()
 }
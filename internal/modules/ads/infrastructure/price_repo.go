@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"context"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func (r *PostgresRepo) PriceHistory(ctx context.Context, adID int64) ([]domain.PriceChange, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT old_price, new_price, changed_at
+		FROM ad_price_history
+		WHERE ad_id=$1
+		ORDER BY id
+	`, adID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.PriceChange{}
+	for rows.Next() {
+		var pc domain.PriceChange
+		if err := rows.Scan(&pc.OldPrice, &pc.NewPrice, &pc.ChangedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, pc)
+	}
+	return out, rows.Err()
+}
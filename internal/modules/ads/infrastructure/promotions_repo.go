@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// promotedSlots — объявления под фильтром с активным top или закреплением
+// в городе из фильтра. Закреплённые выше, дальше — недавно купленные.
+func (r *PostgresRepo) promotedSlots(ctx context.Context, f domain.ListFilter) ([]domain.Ad, error) {
+	w := publicListWhere(f)
+	cityN := w.next(f.City)
+	limitN := w.next(domain.PromotedSlots)
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s %s
+		JOIN LATERAL (
+			SELECT bool_or(p.kind = 'pin_city') AS pinned, max(p.starts_at) AS since
+			FROM ad_promotions p
+			WHERE p.ad_id = a.id AND p.status = 'active' AND now() >= p.starts_at AND now() < p.ends_at
+			  AND (p.kind = 'top' OR (p.kind = 'pin_city' AND $%[3]d::text <> '' AND lower(p.city) = lower($%[3]d::text)))
+		) pr ON pr.since IS NOT NULL
+		%[4]s
+		ORDER BY pr.pinned DESC, pr.since DESC, a.id DESC
+		LIMIT $%[5]d
+	`, adColumns, adFrom, cityN, w.sql(), limitN), w.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.Ad
+	for rows.Next() {
+		ad, err := scanAd(rows)
+		if err != nil {
+			return nil, err
+		}
+		ad.Promoted = true
+		items = append(items, *ad)
+	}
+	return items, rows.Err()
+}
+
+const promotionColumns = `id, ad_id, seller_id, kind, product, city, price, days, status, starts_at, ends_at, paid_at, created_at`
+
+func scanPromotion(row rowScanner) (*domain.Promotion, error) {
+	var p domain.Promotion
+	if err := row.Scan(&p.ID, &p.AdID, &p.SellerID, &p.Kind, &p.Product, &p.City, &p.Price, &p.Days, &p.Status,
+		&p.StartsAt, &p.EndsAt, &p.PaidAt, &p.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// AddPromotion сохраняет заказ; срока у него нет до оплаты.
+func (r *PostgresRepo) AddPromotion(ctx context.Context, p *domain.Promotion) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO ad_promotions (ad_id, seller_id, kind, product, city, price, days, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'pending')
+		RETURNING status, created_at
+	`, p.AdID, p.SellerID, string(p.Kind), p.Product, p.City, p.Price, p.Days).Scan(&p.Status, &p.CreatedAt)
+}
+
+// ActivatePromotion: в одной транзакции с блокировкой строки объявления, чтобы
+// параллельные оплаты не пересеклись по времени.
+func (r *PostgresRepo) ActivatePromotion(ctx context.Context, id int64) (*domain.Promotion, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var adID int64
+	var kind string
+	err = tx.QueryRowContext(ctx, `
+		SELECT ad_id, kind FROM ad_promotions WHERE id=$1 AND status='pending' FOR UPDATE
+	`, id).Scan(&adID, &kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("promotion is not awaiting payment")
+	}
+	if err != nil {
+		return nil, err
+	}
+	// пока ждали оплату, объявление могли продать, снять или отправить на
+	// модерацию: срок не начинаем, заказ остаётся pending
+	var status string
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM ads WHERE id=$1 FOR UPDATE`, adID).Scan(&status); err != nil {
+		return nil, err
+	}
+	if status != string(domain.AdPublished) {
+		return nil, domain.ErrPromotionAdNotPublished
+	}
+
+	p, err := scanPromotion(tx.QueryRowContext(ctx, `
+		UPDATE ad_promotions p
+		SET status = 'active', paid_at = now(), starts_at = s.at, ends_at = s.at + p.days * interval '1 day'
+		FROM (
+			SELECT GREATEST(now(), COALESCE(max(ends_at), now())) AS at
+			FROM ad_promotions
+			WHERE ad_id=$2 AND kind=$3 AND status='active' AND ends_at > now()
+		) s
+		WHERE p.id=$1
+		RETURNING `+promotionColumns, id, adID, kind))
+	if err != nil {
+		return nil, err
+	}
+	return p, tx.Commit()
+}
+
+func (r *PostgresRepo) ListPromotions(ctx context.Context, adID int64) ([]domain.Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+promotionColumns+`
+		FROM ad_promotions
+		WHERE ad_id=$1
+		ORDER BY created_at DESC, id DESC
+	`, adID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Promotion{}
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *p)
+	}
+	return out, rows.Err()
+}
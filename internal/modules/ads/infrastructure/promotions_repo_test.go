@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func TestActivatePromotionChecksAdStatus(t *testing.T) {
+	tests := []struct {
+		status     string
+		wantErr    error
+		wantUpdate bool
+	}{
+		{status: "published", wantUpdate: true},
+		{status: "sold", wantErr: domain.ErrPromotionAdNotPublished},
+		{status: "expired", wantErr: domain.ErrPromotionAdNotPublished},
+		{status: "moderation", wantErr: domain.ErrPromotionAdNotPublished},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			repo, rec := newRecRepo(t)
+			rec.answer("FROM ad_promotions WHERE id=$1 AND status='pending'", []driver.Value{int64(5), "top"})
+			rec.answer("SELECT status FROM ads", []driver.Value{tt.status})
+
+			_, err := repo.ActivatePromotion(context.Background(), 1)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var updated bool
+			for _, q := range rec.queries {
+				if strings.Contains(q, "UPDATE ad_promotions") {
+					updated = true
+				}
+			}
+			if updated != tt.wantUpdate {
+				t.Fatalf("promotion updated = %v, want %v", updated, tt.wantUpdate)
+			}
+		})
+	}
+}
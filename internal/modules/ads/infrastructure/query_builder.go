@@ -0,0 +1,211 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+// whereBuilder собирает WHERE из условий с плейсхолдерами $N.
+// Значения никогда не подставляются в текст запроса — только через args.
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+// add добавляет условие; format содержит по одному %d на каждый аргумент.
+func (b *whereBuilder) add(format string, vs ...any) {
+	nums := make([]any, 0, len(vs))
+	for _, v := range vs {
+		b.args = append(b.args, v)
+		nums = append(nums, len(b.args))
+	}
+	b.conds = append(b.conds, fmt.Sprintf(format, nums...))
+}
+
+// next возвращает номер следующего плейсхолдера (для LIMIT/OFFSET после WHERE).
+func (b *whereBuilder) next(v any) int {
+	b.args = append(b.args, v)
+	return len(b.args)
+}
+
+// raw добавляет условие без аргументов (только константный SQL).
+func (b *whereBuilder) raw(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+func (b *whereBuilder) sql() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// publicListWhere применяет все поля ListFilter к публичной витрине:
+// покупателю видны только опубликованные объявления.
+func publicListWhere(f domain.ListFilter) *whereBuilder {
+	b := &whereBuilder{}
+	applyPublicFilter(b, f)
+	return b
+}
+
+// sub возвращает builder для отдельного подзапроса, продолжающий нумерацию
+// аргументов; после сборки подзапроса аргументы забираются через adopt.
+func (b *whereBuilder) sub() *whereBuilder {
+	return &whereBuilder{args: b.args}
+}
+
+func (b *whereBuilder) adopt(s *whereBuilder) {
+	b.args = s.args
+}
+
+func applyPublicFilter(b *whereBuilder, f domain.ListFilter) {
+	b.add("a.status = $%d", string(domain.AdPublished))
+
+	switch {
+	case f.BrandID != nil && f.Brand != "":
+		b.add("(a.brand_id = $%d OR (a.brand_id IS NULL AND lower(a.brand) = lower($%d)))", *f.BrandID, f.Brand)
+	case f.BrandID != nil:
+		b.add("a.brand_id = $%d", *f.BrandID)
+	case f.Brand != "":
+		b.add("lower(a.brand) = lower($%d)", f.Brand)
+	}
+	if f.ModelID != nil {
+		b.add("a.model_id = $%d", *f.ModelID)
+	}
+	if f.GenerationID != nil {
+		b.add("a.generation_id = $%d", *f.GenerationID)
+	}
+	if f.City != "" {
+		b.add("lower(a.city) = lower($%d)", f.City)
+	}
+	if f.YearFrom != nil {
+		b.add("a.year >= $%d", *f.YearFrom)
+	}
+	if f.YearTo != nil {
+		b.add("a.year <= $%d", *f.YearTo)
+	}
+	if f.PriceFrom != nil {
+		b.add("a.price >= $%d", *f.PriceFrom)
+	}
+	if f.PriceTo != nil {
+		b.add("a.price <= $%d", *f.PriceTo)
+	}
+	if f.MileageFrom != nil {
+		b.add("a.mileage >= $%d", *f.MileageFrom)
+	}
+	if f.MileageTo != nil {
+		b.add("a.mileage <= $%d", *f.MileageTo)
+	}
+	if f.Inspection != "" {
+		b.add("a.inspection_status = $%d", f.Inspection)
+	}
+
+	lists := []struct {
+		col    string
+		values []string
+	}{
+		{"a.transmission", f.Transmissions},
+		{"a.fuel", f.Fuels},
+		{"a.drive", f.Drives},
+		{"a.body_type", f.BodyTypes},
+		{"a.color", f.Colors},
+	}
+	for _, l := range lists {
+		if len(l.values) > 0 {
+			b.add(l.col+" = ANY($%d)", pq.Array(l.values))
+		}
+	}
+	if f.Steering != "" {
+		b.add("a.steering = $%d", f.Steering)
+	}
+	if f.EngineVolumeFrom != nil {
+		b.add("a.engine_volume >= $%d", *f.EngineVolumeFrom)
+	}
+	if f.EngineVolumeTo != nil {
+		b.add("a.engine_volume <= $%d", *f.EngineVolumeTo)
+	}
+	if f.CustomsCleared != nil {
+		b.add("a.customs_cleared = $%d", *f.CustomsCleared)
+	}
+
+	if f.PriceDroppedSince != nil {
+		b.add("a.price_dropped_at >= $%d", *f.PriceDroppedSince)
+	}
+
+	if f.VerifiedOnly != nil && *f.VerifiedOnly {
+		b.raw("a.inspection_status IN ('done','certified')")
+	}
+}
+
+// searchFuzzyExpr — текст, с которым нечётко сравниваются слова запроса
+// (опечатки в марке/модели/городе). По нему построен trigram-индекс
+// ix_ads_search_trgm — выражения должны совпадать.
+const searchFuzzyExpr = `(a.brand || ' ' || a.model || ' ' || a.city)`
+
+// applySearch требует, чтобы каждое слово совпало по tsvector (с морфологией)
+// или нечётко по марке/модели/городу; годы из запроса фильтруют year.
+// Порог <% — pg_trgm.word_similarity_threshold, задан для базы в миграции.
+func applySearch(b *whereBuilder, q domain.SearchQuery) {
+	for _, term := range q.Terms {
+		b.add(fmt.Sprintf(`(a.search_tsv @@ plainto_tsquery('russian', $%%[1]d) OR $%%[1]d <%%%% %s)`,
+			searchFuzzyExpr), term)
+	}
+	for _, y := range q.Years {
+		b.add("a.year = $%d", y)
+	}
+}
+
+// orTSQuery строит tsquery «любое из слов» для ранжирования и подсветки:
+// plainto_tsquery экранирует ввод, после чего & безопасно заменить на |.
+func orTSQuery(n int) string {
+	return fmt.Sprintf(`replace(plainto_tsquery('russian', $%d)::text, '&', '|')::tsquery`, n)
+}
+
+type sortSpec struct {
+	expr string // SQL-выражение ключа, согласовано с domain.SortKey
+	desc bool
+}
+
+var sortSpecs = map[domain.SortOrder]sortSpec{
+	domain.SortNewest:      {expr: "a.id", desc: true},
+	domain.SortPriceAsc:    {expr: "a.price"},
+	domain.SortPriceDesc:   {expr: "a.price", desc: true},
+	domain.SortYearDesc:    {expr: "a.year", desc: true},
+	domain.SortYearAsc:     {expr: "a.year"},
+	domain.SortMileageAsc:  {expr: "a.mileage"},
+	domain.SortMileageDesc: {expr: "a.mileage", desc: true},
+	domain.SortScoreDesc:   {expr: "COALESCE(sc.total_score, -1)", desc: true},
+}
+
+func sortSpecFor(s domain.SortOrder) sortSpec {
+	if spec, ok := sortSpecs[s]; ok {
+		return spec
+	}
+	return sortSpecs[domain.SortNewest]
+}
+
+// orderBy — ORDER BY по ключу и id в одном направлении, чтобы keyset-условие
+// было простым сравнением кортежей.
+func (s sortSpec) orderBy() string {
+	dir := "ASC"
+	if s.desc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("ORDER BY %s %s, a.id %s", s.expr, dir, dir)
+}
+
+// applyCursor добавляет keyset-условие «после курсора».
+func (s sortSpec) applyCursor(b *whereBuilder, c *domain.Cursor) {
+	if c == nil {
+		return
+	}
+	op := ">"
+	if s.desc {
+		op = "<"
+	}
+	b.add("("+s.expr+", a.id) "+op+" ($%d, $%d)", c.Key, c.ID)
+}
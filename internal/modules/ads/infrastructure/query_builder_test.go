@@ -0,0 +1,48 @@
+package infrastructure
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func TestApplySearch(t *testing.T) {
+	b := &whereBuilder{}
+	b.raw("a.status = 'published'")
+	applySearch(b, domain.ParseSearchQuery("камри 2018"))
+
+	want := "WHERE a.status = 'published'" +
+		" AND (a.search_tsv @@ plainto_tsquery('russian', $1) OR $1 <% " + searchFuzzyExpr + ")" +
+		" AND a.year = $2"
+	if got := b.sql(); got != want {
+		t.Fatalf("sql:\n got %s\nwant %s", got, want)
+	}
+	if !reflect.DeepEqual(b.args, []any{"камри", 2018}) {
+		t.Fatalf("args = %v", b.args)
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain match", in: "Toyota \x02Camry\x03 Москва", want: "Toyota <b>Camry</b> Москва"},
+		{name: "markup in description is escaped", in: "\x02Camry\x03 <script>alert(1)</script>", want: "<b>Camry</b> &lt;script&gt;alert(1)&lt;/script&gt;"},
+		{name: "entities and quotes", in: `a & "b" 'c'`, want: "a &amp; &#34;b&#34; &#39;c&#39;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := highlightHTML(tt.in)
+			if got != tt.want {
+				t.Fatalf("highlightHTML = %q, want %q", got, tt.want)
+			}
+			if strings.ContainsAny(got, "\x02\x03") {
+				t.Fatalf("markers left in %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func (r *PostgresRepo) Update(ctx context.Context, ad *domain.Ad, from domain.AdStatus, revs []domain.Revision) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// история цены: старое значение берём из строки до UPDATE
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO ad_price_history (ad_id, old_price, new_price)
+		SELECT id, price, $3 FROM ads WHERE id=$1 AND seller_id=$2 AND price <> $3
+	`, ad.ID, ad.SellerID, ad.Price); err != nil {
+		return err
+	}
+
+	vehicleID, err := upsertVehicle(ctx, tx, ad.VIN)
+	if err != nil {
+		return err
+	}
+
+	// плашка «цена снижена»: при снижении помним цену до первого снижения,
+	// повышение её сбрасывает (в SET колонки — значения до UPDATE)
+	res, err := tx.ExecContext(ctx, `
+		UPDATE ads
+		SET brand=$3, model=$4, year=$5, mileage=$6, price=$7, vin=$8, city=$9, description=$10,
+		    status_changed_at = CASE WHEN status <> $11 THEN now() ELSE status_changed_at END,
+		    status=$11,
+		    brand_id=$12, model_id=$13, generation_id=$14,
+		    transmission=$15, fuel=$16, drive=$17, body_type=$18, engine_volume=$19, color=$20, steering=$21, customs_cleared=$22,
+		    price_before_drop = CASE WHEN $7 < price THEN COALESCE(price_before_drop, price)
+		                             WHEN $7 > price THEN NULL ELSE price_before_drop END,
+		    price_dropped_at = CASE WHEN $7 < price THEN now()
+		                            WHEN $7 > price THEN NULL ELSE price_dropped_at END,
+		    vehicle_id = $23,
+		    updated_at = now()
+		WHERE id=$1 AND seller_id=$2 AND status=$24
+	`, ad.ID, ad.SellerID, ad.Brand, ad.Model, ad.Year, ad.Mileage, ad.Price, ad.VIN, ad.City, ad.Description, string(ad.Status),
+		ad.BrandID, ad.ModelID, ad.GenerationID,
+		string(ad.Spec.Transmission), string(ad.Spec.Fuel), string(ad.Spec.Drive), ad.Spec.BodyType, ad.Spec.EngineVolume, ad.Spec.Color, string(ad.Spec.Steering), ad.Spec.CustomsCleared,
+		vehicleID, string(from))
+	if err != nil {
+		return err
+	}
+	// владелец проверен сервисом; 0 строк — статус успели сменить (модерация, снятие)
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("ad status changed concurrently")
+	}
+
+	for _, rv := range revs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO ad_revisions (ad_id, field, old_value, new_value, actor_id)
+			VALUES ($1,$2,$3,$4,$5)
+		`, ad.ID, rv.Field, rv.OldValue, rv.NewValue, rv.ActorID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) EditedSinceStatusChange(ctx context.Context, adID int64) (bool, error) {
+	var edited bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM ad_revisions rv
+			JOIN ads a ON a.id = rv.ad_id
+			WHERE rv.ad_id = $1 AND rv.changed_at >= a.status_changed_at
+		)
+	`, adID).Scan(&edited)
+	return edited, err
+}
+
+func (r *PostgresRepo) ListRevisions(ctx context.Context, adID int64) ([]domain.Revision, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, ad_id, field, old_value, new_value, COALESCE(actor_id, 0), changed_at
+		FROM ad_revisions
+		WHERE ad_id=$1
+		ORDER BY id DESC
+	`, adID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.Revision, 0)
+	for rows.Next() {
+		var rv domain.Revision
+		if err := rows.Scan(&rv.ID, &rv.AdID, &rv.Field, &rv.OldValue, &rv.NewValue, &rv.ActorID, &rv.ChangedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, rv)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+// SaveRuleHits заменяет результаты прошлого прогона правил для объявления.
+func (r *PostgresRepo) SaveRuleHits(ctx context.Context, adID int64, hits []domain.RuleHit) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ad_rule_hits WHERE ad_id=$1`, adID); err != nil {
+		return err
+	}
+	for _, h := range hits {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO ad_rule_hits (ad_id, rule, action, message)
+			VALUES ($1,$2,$3,$4)
+		`, adID, h.Rule, string(h.Action), h.Message); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) RuleHitsByAds(ctx context.Context, adIDs []int64) (map[int64][]domain.RuleHit, error) {
+	out := make(map[int64][]domain.RuleHit, len(adIDs))
+	if len(adIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ad_id, rule, action, message, created_at
+		FROM ad_rule_hits
+		WHERE ad_id = ANY($1)
+		ORDER BY ad_id, id
+	`, pq.Array(adIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h domain.RuleHit
+		var action string
+		if err := rows.Scan(&h.AdID, &h.Rule, &action, &h.Message, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		h.Action = domain.RuleAction(action)
+		out[h.AdID] = append(out[h.AdID], h)
+	}
+	return out, rows.Err()
+}
+
+// PriceStats — медиана цены опубликованных и проданных объявлений той же модели.
+func (r *PostgresRepo) PriceStats(ctx context.Context, brand, model string, yearFrom, yearTo int) (domain.PriceStats, error) {
+	var st domain.PriceStats
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY price), 0)::int, COUNT(1)
+		FROM ads
+		WHERE status IN ('published','sold')
+		  AND lower(brand) = lower($1) AND lower(model) = lower($2)
+		  AND year BETWEEN $3 AND $4
+	`, brand, model, yearFrom, yearTo).Scan(&st.Median, &st.Sample)
+	return st, err
+}
+
+func (r *PostgresRepo) CountSellerAdsSince(ctx context.Context, sellerID int64, since time.Time) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM ads WHERE seller_id=$1 AND created_at >= $2`, sellerID, since).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,316 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// enqueueMatching ставит опубликованное объявление в очередь матчера сохранённых поисков.
+func enqueueMatching(ctx context.Context, db execer, adID int64) error {
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO saved_search_queue (ad_id) VALUES ($1) ON CONFLICT (ad_id) DO NOTHING
+	`, adID)
+	return err
+}
+
+// ---- favourites
+
+func (r *PostgresRepo) AddFavorite(ctx context.Context, userID, adID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO favorites (user_id, ad_id) VALUES ($1,$2) ON CONFLICT DO NOTHING
+	`, userID, adID)
+	return err
+}
+
+func (r *PostgresRepo) RemoveFavorite(ctx context.Context, userID, adID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM favorites WHERE user_id=$1 AND ad_id=$2`, userID, adID)
+	return err
+}
+
+// ListFavorites — избранное в порядке добавления (новые сверху), в любых статусах:
+// покупатель видит, что машину уже продали.
+func (r *PostgresRepo) ListFavorites(ctx context.Context, userID int64, limit, offset int) ([]domain.Ad, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT count(*) FROM favorites WHERE user_id=$1`, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+adColumns+adFrom+`
+		JOIN favorites fv ON fv.ad_id = a.id AND fv.user_id = $1
+		WHERE a.status <> 'draft'
+		ORDER BY fv.created_at DESC, a.id DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []domain.Ad{}
+	for rows.Next() {
+		ad, err := scanAd(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *ad)
+	}
+	return items, total, rows.Err()
+}
+
+// ---- saved searches
+
+const savedSearchColumns = `id, user_id, name, query, filter, created_at`
+
+// savedFilter — сохраняемая форма фильтра: явные имена ключей, чтобы
+// переименование полей ListFilter не ломало уже сохранённые поиски.
+type savedFilter struct {
+	VerifiedOnly      *bool      `json:"verified_only,omitempty"`
+	Brand             string     `json:"brand,omitempty"`
+	BrandID           *int64     `json:"brand_id,omitempty"`
+	ModelID           *int64     `json:"model_id,omitempty"`
+	GenerationID      *int64     `json:"generation_id,omitempty"`
+	City              string     `json:"city,omitempty"`
+	YearFrom          *int       `json:"year_from,omitempty"`
+	YearTo            *int       `json:"year_to,omitempty"`
+	PriceFrom         *int       `json:"price_from,omitempty"`
+	PriceTo           *int       `json:"price_to,omitempty"`
+	MileageFrom       *int       `json:"mileage_from,omitempty"`
+	MileageTo         *int       `json:"mileage_to,omitempty"`
+	Inspection        string     `json:"inspection,omitempty"`
+	Transmissions     []string   `json:"transmissions,omitempty"`
+	Fuels             []string   `json:"fuels,omitempty"`
+	Drives            []string   `json:"drives,omitempty"`
+	BodyTypes         []string   `json:"body_types,omitempty"`
+	Colors            []string   `json:"colors,omitempty"`
+	Steering          string     `json:"steering,omitempty"`
+	EngineVolumeFrom  *int       `json:"engine_volume_from,omitempty"`
+	EngineVolumeTo    *int       `json:"engine_volume_to,omitempty"`
+	CustomsCleared    *bool      `json:"customs_cleared,omitempty"`
+	PriceDroppedSince *time.Time `json:"price_dropped_since,omitempty"`
+}
+
+func toSavedFilter(f domain.ListFilter) savedFilter {
+	return savedFilter{
+		VerifiedOnly: f.VerifiedOnly, Brand: f.Brand, BrandID: f.BrandID, ModelID: f.ModelID,
+		GenerationID: f.GenerationID, City: f.City, YearFrom: f.YearFrom, YearTo: f.YearTo,
+		PriceFrom: f.PriceFrom, PriceTo: f.PriceTo, MileageFrom: f.MileageFrom, MileageTo: f.MileageTo,
+		Inspection: f.Inspection, Transmissions: f.Transmissions, Fuels: f.Fuels, Drives: f.Drives,
+		BodyTypes: f.BodyTypes, Colors: f.Colors, Steering: f.Steering,
+		EngineVolumeFrom: f.EngineVolumeFrom, EngineVolumeTo: f.EngineVolumeTo,
+		CustomsCleared: f.CustomsCleared, PriceDroppedSince: f.PriceDroppedSince,
+	}
+}
+
+func (f savedFilter) listFilter() domain.ListFilter {
+	return domain.ListFilter{
+		VerifiedOnly: f.VerifiedOnly, Brand: f.Brand, BrandID: f.BrandID, ModelID: f.ModelID,
+		GenerationID: f.GenerationID, City: f.City, YearFrom: f.YearFrom, YearTo: f.YearTo,
+		PriceFrom: f.PriceFrom, PriceTo: f.PriceTo, MileageFrom: f.MileageFrom, MileageTo: f.MileageTo,
+		Inspection: f.Inspection, Transmissions: f.Transmissions, Fuels: f.Fuels, Drives: f.Drives,
+		BodyTypes: f.BodyTypes, Colors: f.Colors, Steering: f.Steering,
+		EngineVolumeFrom: f.EngineVolumeFrom, EngineVolumeTo: f.EngineVolumeTo,
+		CustomsCleared: f.CustomsCleared, PriceDroppedSince: f.PriceDroppedSince,
+	}
+}
+
+func scanSavedSearch(row rowScanner) (*domain.SavedSearch, error) {
+	var s domain.SavedSearch
+	var raw []byte
+	if err := row.Scan(&s.ID, &s.UserID, &s.Name, &s.Query, &raw, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+	var f savedFilter
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("saved search %d: %w", s.ID, err)
+	}
+	s.Filter = f.listFilter()
+	return &s, nil
+}
+
+// CreateSavedSearch проверяет лимит под блокировкой строки пользователя:
+// параллельные сохранения одного покупателя не проскочат его вдвоём.
+func (r *PostgresRepo) CreateSavedSearch(ctx context.Context, s *domain.SavedSearch, limit int) (int64, error) {
+	raw, err := json.Marshal(toSavedFilter(s.Filter.Criteria()))
+	if err != nil {
+		return 0, err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM users WHERE id=$1 FOR UPDATE`, s.UserID); err != nil {
+		return 0, err
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO saved_searches (user_id, name, query, filter)
+		SELECT $1,$2,$3,$4
+		WHERE (SELECT count(*) FROM saved_searches WHERE user_id=$1) < $5
+		RETURNING id, created_at
+	`, s.UserID, s.Name, s.Query, raw, limit).Scan(&s.ID, &s.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrTooManySavedSearches
+	}
+	if err != nil {
+		return 0, err
+	}
+	return s.ID, tx.Commit()
+}
+
+func (r *PostgresRepo) ListSavedSearches(ctx context.Context, userID int64) ([]domain.SavedSearch, error) {
+	return r.querySavedSearches(ctx, `
+		SELECT `+savedSearchColumns+` FROM saved_searches WHERE user_id=$1 ORDER BY id DESC
+	`, userID)
+}
+
+func (r *PostgresRepo) DeleteSavedSearch(ctx context.Context, id, userID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errors.New("saved search not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepo) SavedSearchesAfter(ctx context.Context, afterID int64, limit int) ([]domain.SavedSearch, error) {
+	return r.querySavedSearches(ctx, `
+		SELECT `+savedSearchColumns+` FROM saved_searches WHERE id > $1 ORDER BY id LIMIT $2
+	`, afterID, limit)
+}
+
+func (r *PostgresRepo) querySavedSearches(ctx context.Context, query string, args ...any) ([]domain.SavedSearch, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.SavedSearch{}
+	for rows.Next() {
+		s, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	return out, rows.Err()
+}
+
+// MatchSavedSearch проверяет пачку объявлений тем же WHERE, что и витрина, —
+// сохранённый поиск находит ровно то, что покупатель увидел бы в списке.
+func (r *PostgresRepo) MatchSavedSearch(ctx context.Context, s domain.SavedSearch, adIDs []int64) ([]int64, error) {
+	if len(adIDs) == 0 {
+		return nil, nil
+	}
+	w := publicListWhere(s.Filter)
+	applySearch(w, domain.ParseSearchQuery(s.Query))
+	w.add("a.id = ANY($%d)", pq.Array(adIDs))
+	w.add("a.seller_id <> $%d", s.UserID)
+
+	rows, err := r.db.QueryContext(ctx, `SELECT a.id FROM ads a `+w.sql(), w.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+// ---- matcher queue
+
+func (r *PostgresRepo) QueuedForMatching(ctx context.Context, limit int) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ad_id FROM saved_search_queue ORDER BY queued_at LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) DequeueMatching(ctx context.Context, adIDs []int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM saved_search_queue WHERE ad_id = ANY($1)`, pq.Array(adIDs))
+	return err
+}
+
+// ---- notifications
+
+func (r *PostgresRepo) AddNotifications(ctx context.Context, ns []domain.Notification) error {
+	if len(ns) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, n := range ns {
+		// одно уведомление на объявление, даже если совпало несколько поисков
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO notifications (user_id, kind, ad_id, saved_search_id)
+			VALUES ($1,$2,$3,$4)
+			ON CONFLICT (user_id, kind, ad_id) DO NOTHING
+		`, n.UserID, n.Kind, n.AdID, n.SavedSearchID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) ListNotifications(ctx context.Context, userID int64, limit int) ([]domain.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, kind, ad_id, saved_search_id, created_at, sent_at
+		FROM notifications
+		WHERE user_id=$1
+		ORDER BY id DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Notification{}
+	for rows.Next() {
+		var n domain.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.AdID, &n.SavedSearchID, &n.CreatedAt, &n.SentAt); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func TestSavedFilterRoundTrip(t *testing.T) {
+	brandID, yearFrom, cleared := int64(5), 2015, true
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := domain.ListFilter{
+		Brand:             "BMW",
+		BrandID:           &brandID,
+		City:              "Казань",
+		YearFrom:          &yearFrom,
+		Fuels:             []string{"petrol", "diesel"},
+		Steering:          "left",
+		CustomsCleared:    &cleared,
+		PriceDroppedSince: &since,
+	}
+
+	raw, err := json.Marshal(toSavedFilter(f.Criteria()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{`"brand_id":5`, `"year_from":2015`, `"fuels":["petrol","diesel"]`, `"price_dropped_since"`} {
+		if !strings.Contains(string(raw), key) {
+			t.Errorf("stored filter %s has no %s", raw, key)
+		}
+	}
+
+	var back savedFilter
+	if err := json.Unmarshal(raw, &back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.listFilter(); !reflect.DeepEqual(got, f) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", got, f)
+	}
+}
+
+func TestSavedFilterDropsPaging(t *testing.T) {
+	f := domain.ListFilter{Brand: "Audi", Sort: domain.SortOrder("price_asc"), Limit: 20, Offset: 40}
+	raw, err := json.Marshal(toSavedFilter(f.Criteria()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != `{"brand":"Audi"}` {
+		t.Fatalf("stored filter = %s", raw)
+	}
+}
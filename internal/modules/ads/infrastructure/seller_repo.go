@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// SellerAds — объявления продавца, последние отредактированные сверху. Для отклонённых
+// подтягивается последнее решение reject.
+func (r *PostgresRepo) SellerAds(ctx context.Context, f domain.SellerAdsFilter) ([]domain.SellerAd, int64, error) {
+	w := &whereBuilder{}
+	w.add("a.seller_id = $%d", f.SellerID)
+	if f.Status != "" {
+		w.add("a.status = $%d", string(f.Status))
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(1) FROM ads a `+w.sql(), w.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	from := adFrom + `
+	LEFT JOIN LATERAL (
+		SELECT md.id, COALESCE(md.moderator_id, 0) AS moderator_id, md.reason, md.comment, md.created_at
+		FROM moderation_decisions md
+		WHERE md.ad_id = a.id AND md.decision = 'reject' AND a.status = 'rejected'
+		ORDER BY md.id DESC
+		LIMIT 1
+	) rj ON TRUE
+`
+	limitN := w.next(f.Limit)
+	offsetN := w.next(f.Offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s, a.updated_at, a.status_changed_at, rj.id, rj.moderator_id, rj.reason, rj.comment, rj.created_at
+		%s %s
+		ORDER BY a.updated_at DESC, a.id DESC
+		LIMIT $%d OFFSET $%d
+	`, adColumns, from, w.sql(), limitN, offsetN), w.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.SellerAd, 0)
+	for rows.Next() {
+		var it domain.SellerAd
+		var (
+			rjID, rjModerator   sql.NullInt64
+			rjReason, rjComment sql.NullString
+			rjAt                sql.NullTime
+		)
+		ad, err := scanAd(rows, &it.UpdatedAt, &it.StatusChangedAt, &rjID, &rjModerator, &rjReason, &rjComment, &rjAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		it.Ad = *ad
+		if rjID.Valid {
+			d := &domain.ModerationDecision{
+				ID:          rjID.Int64,
+				AdID:        ad.ID,
+				ModeratorID: rjModerator.Int64,
+				Decision:    domain.DecisionReject,
+				Reason:      domain.RejectionReason(rjReason.String),
+				Comment:     rjComment.String,
+				CreatedAt:   rjAt.Time,
+			}
+			d.ReasonText = domain.RejectionReasons[d.Reason]
+			it.Rejection = d
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *PostgresRepo) SellerStatusCounts(ctx context.Context, sellerID int64) (map[domain.AdStatus]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT status, COUNT(1) FROM ads WHERE seller_id=$1 GROUP BY status`, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[domain.AdStatus]int64, len(domain.AdStatuses))
+	for _, st := range domain.AdStatuses {
+		out[domain.AdStatus(st)] = 0
+	}
+	for rows.Next() {
+		var status string
+		var n int64
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, err
+		}
+		out[domain.AdStatus(status)] = n
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// SimilarCandidates отбирает кандидатов без ранжирования. Итоговый порядок
+// считает domain.SimilarWeights.
+func (r *PostgresRepo) SimilarCandidates(ctx context.Context, base *domain.Ad, q domain.SimilarQuery) ([]domain.Ad, error) {
+	query, args := similarCandidatesQuery(base, q)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.Ad, 0)
+	for rows.Next() {
+		ad, err := scanAd(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *ad)
+	}
+	return items, rows.Err()
+}
+
+// similarCandidatesQuery — та же модель или кузов в окне цены/года. Сначала
+// берутся объявления той же модели, затем того же кузова, внутри — ближайшие
+// по цене: иначе на популярном кузове чужие модели вытесняют из лимита свою.
+func similarCandidatesQuery(base *domain.Ad, q domain.SimilarQuery) (string, []any) {
+	w := &whereBuilder{}
+	w.add("a.status = $%d", string(domain.AdPublished))
+	w.add("a.id <> $%d", base.ID)
+
+	sameModel := fmt.Sprintf("(lower(a.brand) = lower($%d) AND lower(a.model) = lower($%d))", w.next(base.Brand), w.next(base.Model))
+	if base.ModelID != nil {
+		sameModel = fmt.Sprintf("(a.model_id = $%d OR %s)", w.next(*base.ModelID), sameModel)
+	}
+	if base.Spec.BodyType != "" {
+		w.add("("+sameModel+" OR a.body_type = $%d)", base.Spec.BodyType)
+	} else {
+		w.raw(sameModel)
+	}
+
+	spread := int(float64(base.Price) * q.PriceSpread)
+	w.add("a.price BETWEEN $%d AND $%d", base.Price-spread, base.Price+spread)
+	w.add("a.year BETWEEN $%d AND $%d", base.Year-q.YearSpread, base.Year+q.YearSpread)
+
+	priceN := w.next(base.Price)
+	limitN := w.next(q.Candidates)
+	return fmt.Sprintf(`%s %s ORDER BY %s DESC, abs(a.price - $%d), a.id DESC LIMIT $%d`,
+		adSelect, w.sql(), sameModel, priceN, limitN), w.args
+}
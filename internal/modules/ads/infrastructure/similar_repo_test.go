@@ -0,0 +1,50 @@
+package infrastructure
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/ads/domain"
+)
+
+func TestSimilarCandidatesQuery(t *testing.T) {
+	modelID := int64(10)
+	base := &domain.Ad{ID: 5, Brand: "BMW", Model: "X5", ModelID: &modelID, Year: 2015, Price: 2_000_000,
+		Spec: domain.Spec{BodyType: "suv"}}
+	q := domain.SimilarQuery{PriceSpread: 0.2, YearSpread: 2, Candidates: 100}
+
+	query, args := similarCandidatesQuery(base, q)
+
+	sameModel := "(a.model_id = $5 OR (lower(a.brand) = lower($3) AND lower(a.model) = lower($4)))"
+	for _, part := range []string{
+		"(" + sameModel + " OR a.body_type = $6)",
+		"a.price BETWEEN $7 AND $8",
+		"a.year BETWEEN $9 AND $10",
+		// своя модель попадает в лимит раньше чужих моделей того же кузова
+		"ORDER BY " + sameModel + " DESC, abs(a.price - $11), a.id DESC LIMIT $12",
+	} {
+		if !strings.Contains(query, part) {
+			t.Errorf("query has no %q:\n%s", part, query)
+		}
+	}
+	want := []any{"published", int64(5), "BMW", "X5", int64(10), "suv", 1_600_000, 2_400_000, 2013, 2017, 2_000_000, 100}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %v, want %v", args, want)
+	}
+}
+
+func TestSimilarCandidatesQueryWithoutBodyType(t *testing.T) {
+	base := &domain.Ad{ID: 5, Brand: "Lada", Model: "Vesta", Year: 2020, Price: 1_000_000}
+	query, args := similarCandidatesQuery(base, domain.SimilarQuery{Candidates: 50})
+
+	if strings.Contains(query, "a.body_type = ") {
+		t.Fatalf("query must not filter by body type:\n%s", query)
+	}
+	if !strings.Contains(query, "ORDER BY (lower(a.brand) = lower($3) AND lower(a.model) = lower($4)) DESC") {
+		t.Fatalf("query:\n%s", query)
+	}
+	if len(args) != 10 {
+		t.Fatalf("args = %v", args)
+	}
+}
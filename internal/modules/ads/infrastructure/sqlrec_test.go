@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recDriver — драйвер database/sql для тестов: запоминает текст запросов и
+// отвечает пустым результатом либо строками из answers.
+type recDriver struct {
+	mu      sync.Mutex
+	queries []string
+	answers []recAnswer
+}
+
+// recAnswer — ответ на запросы, текст которых содержит match.
+type recAnswer struct {
+	match string
+	rows  [][]driver.Value
+}
+
+// answer задаёт строки для запросов, содержащих match.
+func (d *recDriver) answer(match string, rows ...[]driver.Value) {
+	d.answers = append(d.answers, recAnswer{match: match, rows: rows})
+}
+
+func (d *recDriver) Open(string) (driver.Conn, error) { return &recConn{d: d}, nil }
+
+func (d *recDriver) record(q string) driver.Rows {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queries = append(d.queries, q)
+	for _, a := range d.answers {
+		if strings.Contains(q, a.match) {
+			return &recRows{rows: a.rows}
+		}
+	}
+	return &recRows{}
+}
+
+type recConn struct{ d *recDriver }
+
+func (c *recConn) Prepare(q string) (driver.Stmt, error) { return &recStmt{d: c.d, q: q}, nil }
+func (c *recConn) Close() error                          { return nil }
+func (c *recConn) Begin() (driver.Tx, error)             { return recTx{}, nil }
+
+func (c *recConn) QueryContext(_ context.Context, q string, _ []driver.NamedValue) (driver.Rows, error) {
+	return c.d.record(q), nil
+}
+
+func (c *recConn) ExecContext(_ context.Context, q string, _ []driver.NamedValue) (driver.Result, error) {
+	c.d.record(q)
+	return driver.RowsAffected(0), nil
+}
+
+type recStmt struct {
+	d *recDriver
+	q string
+}
+
+func (s *recStmt) Close() error  { return nil }
+func (s *recStmt) NumInput() int { return -1 }
+
+func (s *recStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.d.record(s.q)
+	return driver.RowsAffected(0), nil
+}
+
+func (s *recStmt) Query([]driver.Value) (driver.Rows, error) {
+	return s.d.record(s.q), nil
+}
+
+type recTx struct{}
+
+func (recTx) Commit() error   { return nil }
+func (recTx) Rollback() error { return nil }
+
+type recRows struct {
+	rows [][]driver.Value
+}
+
+func (r *recRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *recRows) Close() error { return nil }
+
+func (r *recRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// newRecRepo — PostgresRepo поверх recDriver.
+func newRecRepo(t *testing.T) (*PostgresRepo, *recDriver) {
+	t.Helper()
+	d := &recDriver{}
+	db := sql.OpenDB(recConnector{d})
+	t.Cleanup(func() { _ = db.Close() })
+	return NewPostgresRepo(db), d
+}
+
+type recConnector struct{ d *recDriver }
+
+func (c recConnector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c recConnector) Driver() driver.Driver                        { return c.d }
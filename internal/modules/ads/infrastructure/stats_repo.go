@@ -0,0 +1,102 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+// AddStatHits: устройства, уже засчитанные за день, отсекает ON CONFLICT,
+// остальное складывается в дневные счётчики. События по удалённым
+// объявлениям молча отбрасываются.
+func (r *PostgresRepo) AddStatHits(ctx context.Context, hits []domain.StatHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(hits))
+	days := make([]string, len(hits))
+	evs := make([]string, len(hits))
+	devices := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i], days[i], evs[i], devices[i] = h.AdID, h.Day, string(h.Event), h.Device
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		WITH fresh AS (
+			INSERT INTO ad_stats_devices (ad_id, day, event, device)
+			SELECT u.ad_id, u.day, u.event, u.device
+			FROM unnest($1::bigint[], $2::date[], $3::text[], $4::text[]) AS u(ad_id, day, event, device)
+			JOIN ads a ON a.id = u.ad_id
+			ON CONFLICT DO NOTHING
+			RETURNING ad_id, day, event
+		)
+		INSERT INTO ad_stats_daily (ad_id, day, views, phones, favorites, reports)
+		SELECT ad_id, day,
+		       count(*) FILTER (WHERE event = 'view'),
+		       count(*) FILTER (WHERE event = 'phone'),
+		       count(*) FILTER (WHERE event = 'favorite'),
+		       count(*) FILTER (WHERE event = 'report')
+		FROM fresh
+		GROUP BY ad_id, day
+		ON CONFLICT (ad_id, day) DO UPDATE SET
+			views     = ad_stats_daily.views + EXCLUDED.views,
+			phones    = ad_stats_daily.phones + EXCLUDED.phones,
+			favorites = ad_stats_daily.favorites + EXCLUDED.favorites,
+			reports   = ad_stats_daily.reports + EXCLUDED.reports
+	`, pq.Array(ids), pq.Array(days), pq.Array(evs), pq.Array(devices))
+	return err
+}
+
+func (r *PostgresRepo) PurgeStatDevices(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM ad_stats_devices WHERE day < $1::date`, before.UTC().Format(time.DateOnly))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *PostgresRepo) SellerStats(ctx context.Context, sellerID int64, from, to time.Time) ([]domain.AdStats, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id, a.brand, a.model, a.status,
+		       to_char(s.day, 'YYYY-MM-DD'), s.views, s.phones, s.favorites, s.reports
+		FROM ads a
+		LEFT JOIN ad_stats_daily s ON s.ad_id = a.id AND s.day BETWEEN $2::date AND $3::date
+		WHERE a.seller_id = $1
+		ORDER BY a.id DESC, s.day
+	`, sellerID, from.UTC().Format(time.DateOnly), to.UTC().Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.AdStats, 0)
+	for rows.Next() {
+		var (
+			st  domain.AdStats
+			day sql.NullString
+			cnt [4]sql.NullInt64
+		)
+		if err := rows.Scan(&st.AdID, &st.Brand, &st.Model, &st.Status,
+			&day, &cnt[0], &cnt[1], &cnt[2], &cnt[3]); err != nil {
+			return nil, err
+		}
+		if n := len(out); n == 0 || out[n-1].AdID != st.AdID {
+			out = append(out, st)
+		}
+		if day.Valid {
+			last := &out[len(out)-1]
+			last.Days = append(last.Days, domain.DailyStats{
+				Day:       day.String,
+				Views:     cnt[0].Int64,
+				Phones:    cnt[1].Int64,
+				Favorites: cnt[2].Int64,
+				Reports:   cnt[3].Int64,
+			})
+		}
+	}
+	return out, rows.Err()
+}
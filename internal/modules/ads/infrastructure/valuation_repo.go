@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"autera/internal/modules/ads/domain"
+
+	"github.com/lib/pq"
+)
+
+// valuationAggregates — агрегаты по выборке s. Для проданных берётся фактическая
+// цена продажи.
+const valuationAggregates = `
+	count(*),
+	percentile_cont(ARRAY[0.1, 0.25, 0.5, 0.75, 0.9]) WITHIN GROUP (ORDER BY COALESCE(s.sold_price, s.price)),
+	percentile_cont(0.5) WITHIN GROUP (ORDER BY s.mileage),
+	regr_slope(COALESCE(s.sold_price, s.price), s.mileage),
+	regr_r2(COALESCE(s.sold_price, s.price), s.mileage)`
+
+// valuationSource — опубликованные и проданные за последний год.
+const valuationSource = `
+	(s.status = 'published' OR (s.status = 'sold' AND s.sold_at >= now() - interval '365 days'))`
+
+// valuationRow — приёмник колонок valuationAggregates; в строке их может быть несколько.
+type valuationRow struct {
+	sample             int
+	pct                pq.Float64Array
+	mileage, slope, r2 sql.NullFloat64
+}
+
+func (v *valuationRow) dest() []any {
+	return []any{&v.sample, &v.pct, &v.mileage, &v.slope, &v.r2}
+}
+
+func (v *valuationRow) stats() domain.ValuationStats {
+	st := domain.ValuationStats{Sample: v.sample, Percentiles: v.pct, MileageMedian: v.mileage.Float64}
+	if v.slope.Valid {
+		st.Slope = &v.slope.Float64
+	}
+	if v.r2.Valid {
+		st.R2 = &v.r2.Float64
+	}
+	return st
+}
+
+func (r *PostgresRepo) ValuationStats(ctx context.Context, seg domain.Segment) (domain.ValuationStats, error) {
+	w := &whereBuilder{}
+	w.raw(valuationSource)
+	if seg.ModelID != nil {
+		w.add("(s.model_id = $%d OR (lower(s.brand) = lower($%d) AND lower(s.model) = lower($%d)))", *seg.ModelID, seg.Brand, seg.Model)
+	} else {
+		w.add("lower(s.brand) = lower($%d) AND lower(s.model) = lower($%d)", seg.Brand, seg.Model)
+	}
+	w.add("s.year BETWEEN $%d AND $%d", seg.YearFrom, seg.YearTo)
+	if seg.City != "" {
+		w.add("lower(s.city) = lower($%d)", seg.City)
+	}
+
+	var v valuationRow
+	if err := r.db.QueryRowContext(ctx, `SELECT `+valuationAggregates+` FROM ads s `+w.sql(), w.args...).Scan(v.dest()...); err != nil {
+		return domain.ValuationStats{}, err
+	}
+	return v.stats(), nil
+}
+
+// segmentMatch — похожие на x: та же модель и близкий год.
+const segmentMatch = `
+	s.id <> x.id
+	AND ((x.model_id IS NOT NULL AND s.model_id = x.model_id)
+	     OR (lower(s.brand) = lower(x.brand) AND lower(s.model) = lower(x.model)))
+	AND s.year BETWEEN x.year - $2 AND x.year + $2`
+
+func (r *PostgresRepo) SegmentStatsByAds(ctx context.Context, adIDs []int64) (map[int64]domain.SegmentStats, error) {
+	out := make(map[int64]domain.SegmentStats, len(adIDs))
+	if len(adIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT x.id, sc.*, st.*
+		FROM ads x
+		CROSS JOIN LATERAL (
+			SELECT `+valuationAggregates+`
+			FROM ads s
+			WHERE `+valuationSource+` AND `+segmentMatch+`
+			  AND lower(s.city) = lower(x.city)
+		) sc
+		CROSS JOIN LATERAL (
+			SELECT `+valuationAggregates+`
+			FROM ads s
+			WHERE `+valuationSource+` AND `+segmentMatch+`
+		) st
+		WHERE x.id = ANY($1)
+	`, pq.Array(adIDs), domain.ValuationYearSpread)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var city, country valuationRow
+		dest := append(append([]any{&id}, city.dest()...), country.dest()...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		out[id] = domain.SegmentStats{City: city.stats(), Country: country.stats()}
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,145 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+
+	"autera/internal/modules/ads/domain"
+)
+
+// upsertVehicle возвращает id автомобиля по VIN, создавая запись при первом
+// появлении VIN. Пустой VIN — nil.
+func upsertVehicle(ctx context.Context, tx *sql.Tx, vin string) (*int64, error) {
+	if vin == "" {
+		return nil, nil
+	}
+	var id int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO vehicles (vin) VALUES ($1)
+		ON CONFLICT (vin) DO UPDATE SET vin = EXCLUDED.vin
+		RETURNING id
+	`, vin).Scan(&id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// vehicleListed — объявления, которые действительно были в витрине. На модерации,
+// отклонённые и черновики в историю не попадают: иначе чужой VIN с выдуманным
+// пробегом выглядел бы скруткой у настоящего объявления.
+const vehicleListed = `a.published_at IS NOT NULL AND a.status IN ('published', 'sold', 'expired', 'archived')`
+
+func (r *PostgresRepo) VehicleHistory(ctx context.Context, vin string) (*domain.VehicleHistory, error) {
+	h := &domain.VehicleHistory{
+		VIN:         vin,
+		Listings:    []domain.VehicleListing{},
+		Inspections: []domain.VehicleInspection{},
+		Reports:     []domain.VehicleReport{},
+		Mileage:     []domain.MileagePoint{},
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id, a.status, a.price, a.mileage, a.city, a.created_at
+		FROM ads a
+		JOIN vehicles v ON v.id = a.vehicle_id
+		WHERE v.vin = $1 AND `+vehicleListed+`
+		ORDER BY a.created_at
+	`, vin)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var l domain.VehicleListing
+		var st string
+		if err := rows.Scan(&l.AdID, &st, &l.Price, &l.Mileage, &l.City, &l.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		l.Status = domain.AdStatus(st)
+		h.Listings = append(h.Listings, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT i.id, i.ad_id, i.status, i.created_at
+		FROM inspections i
+		JOIN ads a ON a.id = i.ad_id
+		JOIN vehicles v ON v.id = a.vehicle_id
+		WHERE v.vin = $1 AND `+vehicleListed+`
+		ORDER BY i.created_at
+	`, vin)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var it domain.VehicleInspection
+		if err := rows.Scan(&it.ID, &it.AdID, &it.Status, &it.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		h.Inspections = append(h.Inspections, it)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.QueryContext(ctx, `
+		SELECT rp.id, rp.inspection_id, i.ad_id, rp.total_score, rp.label, rp.created_at
+		FROM reports rp
+		JOIN inspections i ON i.id = rp.inspection_id
+		JOIN ads a ON a.id = i.ad_id
+		JOIN vehicles v ON v.id = a.vehicle_id
+		WHERE v.vin = $1 AND `+vehicleListed+`
+		ORDER BY rp.created_at
+	`, vin)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var rp domain.VehicleReport
+		if err := rows.Scan(&rp.ID, &rp.InspectionID, &rp.AdID, &rp.TotalScore, &rp.Label, &rp.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		h.Reports = append(h.Reports, rp)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// пробег: исходное значение каждого объявления (до первой правки) + все правки
+	rows, err = r.db.QueryContext(ctx, `
+		WITH va AS (
+			SELECT a.id, a.mileage, a.created_at
+			FROM ads a JOIN vehicles v ON v.id = a.vehicle_id
+			WHERE v.vin = $1 AND `+vehicleListed+`
+		)
+		SELECT va.id, va.created_at,
+		       COALESCE((SELECT rv.old_value::int FROM ad_revisions rv
+		                 WHERE rv.ad_id = va.id AND rv.field = 'mileage'
+		                 ORDER BY rv.id LIMIT 1), va.mileage)
+		FROM va
+		UNION ALL
+		SELECT rv.ad_id, rv.changed_at, rv.new_value::int
+		FROM ad_revisions rv JOIN va ON va.id = rv.ad_id
+		WHERE rv.field = 'mileage'
+		ORDER BY 2
+	`, vin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p domain.MileagePoint
+		if err := rows.Scan(&p.AdID, &p.At, &p.Mileage); err != nil {
+			return nil, err
+		}
+		h.Mileage = append(h.Mileage, p)
+	}
+	return h, rows.Err()
+}
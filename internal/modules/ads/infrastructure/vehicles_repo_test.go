@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUpsertVehicleEmptyVIN(t *testing.T) {
+	// пустой VIN не должен обращаться к базе
+	id, err := upsertVehicle(context.Background(), nil, "")
+	if err != nil || id != nil {
+		t.Fatalf("upsertVehicle(\"\") = %v, %v; want nil, nil", id, err)
+	}
+}
+
+func TestVehicleHistoryOnlyPublishedListings(t *testing.T) {
+	repo, rec := newRecRepo(t)
+
+	h, err := repo.VehicleHistory(context.Background(), "WBAPH5C55BA123456")
+	if err != nil {
+		t.Fatalf("VehicleHistory: %v", err)
+	}
+	if h.Listings == nil || h.Mileage == nil {
+		t.Fatalf("history = %+v, want empty slices", h)
+	}
+	if len(rec.queries) != 4 {
+		t.Fatalf("got %d queries, want 4", len(rec.queries))
+	}
+	for i, q := range rec.queries {
+		if !strings.Contains(q, vehicleListed) {
+			t.Errorf("query %d does not restrict to published listings:\n%s", i, q)
+		}
+	}
+}
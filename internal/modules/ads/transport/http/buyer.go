@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) AddFavoriteBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.AddFavorite(r.Context(), user.ID, adID); err != nil {
+		response.BadRequest(w, "add favorite failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) RemoveFavoriteBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.RemoveFavorite(r.Context(), user.ID, adID); err != nil {
+		response.Internal(w, "remove favorite failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) FavoritesBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		response.BadRequest(w, "invalid page", err.Error())
+		return
+	}
+
+	items, total, err := h.svc.Favorites(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.Internal(w, "favorites failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+}
+
+// CreateSavedSearchBuyer принимает фильтры в том же виде, что и GET /ads:
+// {"name": "...", "query": "brand=BMW&price_to=20000"}.
+func (h *Handler) CreateSavedSearchBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	var body struct {
+		Name  string `json:"name"`
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	values, err := url.ParseQuery(body.Query)
+	if err != nil {
+		response.BadRequest(w, "invalid query", err.Error())
+		return
+	}
+	values.Del("cursor")
+	f, q, err := parseListFilter(values)
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+
+	saved, err := h.svc.SaveSearch(r.Context(), user.ID, body.Name, q, f)
+	if err != nil {
+		response.BadRequest(w, "save search failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusCreated, saved)
+}
+
+func (h *Handler) SavedSearchesBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	items, err := h.svc.SavedSearches(r.Context(), user.ID)
+	if err != nil {
+		response.Internal(w, "saved searches failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) DeleteSavedSearchBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.DeleteSavedSearch(r.Context(), id, user.ID); err != nil {
+		response.NotFound(w, "not found")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) NotificationsBuyer(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	items, err := h.svc.Notifications(r.Context(), user.ID)
+	if err != nil {
+		response.Internal(w, "notifications failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"autera/internal/modules/ads/application"
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) ComplaintReasonsPublic(w http.ResponseWriter, _ *http.Request) {
+	items := make([]map[string]string, 0, len(domain.ComplaintReasons))
+	for code, text := range domain.ComplaintReasons {
+		items = append(items, map[string]string{"code": string(code), "text": text})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i]["code"] < items[j]["code"] })
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// FileComplaintAuth — жалоба от любого авторизованного пользователя.
+func (h *Handler) FileComplaintAuth(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var in application.ComplaintInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	c, err := h.svc.FileComplaint(r.Context(), adID, user.ID, in)
+	if err != nil {
+		response.BadRequest(w, "complaint failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusCreated, c)
+}
+
+func (h *Handler) ComplaintQueueAdmin(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+	items, total, err := h.svc.ComplaintQueue(r.Context(), limit, offset)
+	if err != nil {
+		response.Internal(w, "complaints failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+}
+
+func (h *Handler) AdComplaintsAdmin(w http.ResponseWriter, r *http.Request) {
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	items, err := h.svc.AdComplaints(r.Context(), adID)
+	if err != nil {
+		response.Internal(w, "complaints failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ResolveComplaintsAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var in application.ResolveComplaintsInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	n, err := h.svc.ResolveComplaints(r.Context(), adID, user.ID, in)
+	if err != nil {
+		response.BadRequest(w, "resolve failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true, "resolved": n})
+}
@@ -0,0 +1,256 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"autera/internal/modules/ads/application"
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	exportMaxAge = 15 * time.Minute
+	// exportWriteTimeout заменяет общий WriteTimeout сервера для выгрузок
+	exportWriteTimeout = 10 * time.Minute
+	sitemapMaxAge      = time.Hour
+	sitemapXMLNS       = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// exportAd — объявление в партнёрской выгрузке.
+type exportAd struct {
+	XMLName          xml.Name `xml:"ad" json:"-"`
+	ID               int64    `xml:"id" json:"id"`
+	URL              string   `xml:"url" json:"url"`
+	Brand            string   `xml:"brand" json:"brand"`
+	Model            string   `xml:"model" json:"model"`
+	Year             int      `xml:"year" json:"year"`
+	Mileage          int      `xml:"mileage" json:"mileage"`
+	Price            int      `xml:"price" json:"price"`
+	VIN              string   `xml:"vin,omitempty" json:"vin,omitempty"`
+	City             string   `xml:"city" json:"city"`
+	Description      string   `xml:"description,omitempty" json:"description,omitempty"`
+	Transmission     string   `xml:"transmission,omitempty" json:"transmission,omitempty"`
+	Fuel             string   `xml:"fuel,omitempty" json:"fuel,omitempty"`
+	Drive            string   `xml:"drive,omitempty" json:"drive,omitempty"`
+	BodyType         string   `xml:"body_type,omitempty" json:"body_type,omitempty"`
+	EngineVolume     *int     `xml:"engine_volume,omitempty" json:"engine_volume,omitempty"`
+	Color            string   `xml:"color,omitempty" json:"color,omitempty"`
+	InspectionStatus string   `xml:"inspection_status" json:"inspection_status"`
+	InspectionScore  *int     `xml:"inspection_score,omitempty" json:"inspection_score,omitempty"`
+	ReportURL        string   `xml:"report_url,omitempty" json:"report_url,omitempty"`
+	Photos           []string `xml:"photos>photo" json:"photos"`
+	PublishedAt      string   `xml:"published_at,omitempty" json:"published_at,omitempty"`
+}
+
+func (h *Handler) adURL(id int64) string {
+	return h.siteURL + "/ads/" + strconv.FormatInt(id, 10)
+}
+
+func (h *Handler) exportAd(ad *domain.Ad) exportAd {
+	out := exportAd{
+		ID:               ad.ID,
+		URL:              h.adURL(ad.ID),
+		Brand:            ad.Brand,
+		Model:            ad.Model,
+		Year:             ad.Year,
+		Mileage:          ad.Mileage,
+		Price:            ad.Price,
+		VIN:              ad.VIN,
+		City:             ad.City,
+		Description:      ad.Description,
+		Transmission:     string(ad.Spec.Transmission),
+		Fuel:             string(ad.Spec.Fuel),
+		Drive:            string(ad.Spec.Drive),
+		BodyType:         ad.Spec.BodyType,
+		EngineVolume:     ad.Spec.EngineVolume,
+		Color:            ad.Spec.Color,
+		InspectionStatus: string(ad.InspectionState),
+		InspectionScore:  ad.InspectionScore,
+		Photos:           make([]string, 0, len(ad.Photos)),
+	}
+	// ссылка на отчёт — только если отчёт уже есть
+	if ad.InspectionScore != nil {
+		out.ReportURL = out.URL + "/report"
+	}
+	for _, p := range ad.Photos {
+		out.Photos = append(out.Photos, p.URLs[domain.PhotoMedium])
+	}
+	if ad.PublishedAt != nil {
+		out.PublishedAt = ad.PublishedAt.UTC().Format(time.RFC3339)
+	}
+	return out
+}
+
+func cacheFor(w http.ResponseWriter, d time.Duration) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(d.Seconds())))
+}
+
+// startExport продлевает дедлайн записи: выгрузка целиком не укладывается
+// в общий WriteTimeout сервера.
+func startExport(w http.ResponseWriter, contentType string) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	w.Header().Set("Content-Type", contentType)
+	cacheFor(w, exportMaxAge)
+}
+
+// abortExport рвёт соединение без завершающего чанка: клиент и CDN видят
+// незавершённый ответ и не кэшируют обрезанную выгрузку.
+func abortExport() {
+	panic(http.ErrAbortHandler)
+}
+
+// ExportJSONPublic — потоковая выгрузка опубликованных объявлений: {"items": [...]}.
+// Ошибка посреди потока обрывает соединение.
+func (h *Handler) ExportJSONPublic(w http.ResponseWriter, r *http.Request) {
+	startExport(w, "application/json; charset=utf-8")
+
+	enc := json.NewEncoder(w)
+	_, _ = io.WriteString(w, `{"items":[`)
+	first := true
+	err := h.svc.EachPublished(r.Context(), func(ad *domain.Ad) error {
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		return enc.Encode(h.exportAd(ad))
+	})
+	if err != nil {
+		abortExport()
+	}
+	_, _ = io.WriteString(w, "]}\n")
+}
+
+// ExportXMLPublic — та же выгрузка в XML: <ads><ad>…</ad></ads>.
+func (h *Handler) ExportXMLPublic(w http.ResponseWriter, r *http.Request) {
+	startExport(w, "application/xml; charset=utf-8")
+
+	_, _ = io.WriteString(w, xml.Header)
+	_, _ = fmt.Fprintf(w, "<ads generated_at=%q>\n", time.Now().UTC().Format(time.RFC3339))
+	enc := xml.NewEncoder(w)
+	err := h.svc.EachPublished(r.Context(), func(ad *domain.Ad) error {
+		if err := enc.Encode(h.exportAd(ad)); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	})
+	if err != nil {
+		abortExport()
+	}
+	_, _ = io.WriteString(w, "</ads>\n")
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapRef `xml:"sitemap"`
+}
+
+type sitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// writeSitemap отдаёт XML с валидаторами кэша; при совпадении — 304.
+func writeSitemap(w http.ResponseWriter, r *http.Request, etag string, lastMod time.Time, v any) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", lastMod.UTC().Format(http.TimeFormat))
+	cacheFor(w, sitemapMaxAge)
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if match == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !lastMod.Truncate(time.Second).After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func segmentETag(prefix string, s domain.SitemapSegment) string {
+	return fmt.Sprintf(`"%s-%d-%d-%d"`, prefix, s.Index, s.Count, s.LastMod.UnixNano())
+}
+
+// SitemapIndexPublic — /sitemap.xml: индекс сегментов /sitemaps/ads-{n}.xml.
+func (h *Handler) SitemapIndexPublic(w http.ResponseWriter, r *http.Request) {
+	segs, err := h.svc.SitemapIndex(r.Context())
+	if err != nil {
+		response.Internal(w, "sitemap failed")
+		return
+	}
+
+	idx := sitemapIndex{XMLNS: sitemapXMLNS, Sitemaps: make([]sitemapRef, 0, len(segs))}
+	var lastMod time.Time
+	tag := fnv.New64a()
+	for _, s := range segs {
+		idx.Sitemaps = append(idx.Sitemaps, sitemapRef{
+			Loc:     fmt.Sprintf("%s/sitemaps/ads-%d.xml", h.siteURL, s.Index),
+			LastMod: s.LastMod.UTC().Format(time.RFC3339),
+		})
+		if s.LastMod.After(lastMod) {
+			lastMod = s.LastMod
+		}
+		_, _ = io.WriteString(tag, segmentETag("", s))
+	}
+	etag := fmt.Sprintf(`"idx-%d-%x"`, len(segs), tag.Sum64())
+	writeSitemap(w, r, etag, lastMod, idx)
+}
+
+// SitemapSegmentPublic — /sitemaps/ads-{n}.xml.
+func (h *Handler) SitemapSegmentPublic(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	raw, ok := strings.CutSuffix(strings.TrimPrefix(name, "ads-"), ".xml")
+	index, err := strconv.ParseInt(raw, 10, 64)
+	if !ok || !strings.HasPrefix(name, "ads-") || err != nil || index < 0 {
+		response.NotFound(w, "not found")
+		return
+	}
+
+	seg, entries, err := h.svc.SitemapSegment(r.Context(), index)
+	if errors.Is(err, application.ErrSitemapSegmentNotFound) {
+		response.NotFound(w, "not found")
+		return
+	}
+	if err != nil {
+		response.Internal(w, "sitemap failed")
+		return
+	}
+
+	set := urlSet{XMLNS: sitemapXMLNS, URLs: make([]sitemapURL, 0, len(entries))}
+	for _, e := range entries {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:     h.adURL(e.AdID),
+			LastMod: e.LastMod.UTC().Format(time.RFC3339),
+		})
+	}
+	writeSitemap(w, r, segmentETag("ads", seg), seg.LastMod, set)
+}
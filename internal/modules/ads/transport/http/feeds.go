@@ -0,0 +1,135 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ImportFeedSeller — разовая загрузка фида телом запроса:
+// format=csv|xml, archive_missing=true — заархивировать пропавшие из фида.
+// Фид разбирается в фоне; состояние — GET /feeds/imports/{id}.
+func (h *Handler) ImportFeedSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	format := domain.FeedFormat(q.Get("format"))
+	archive, err := queryBoolPtr(q, "archive_missing")
+	if err != nil {
+		response.BadRequest(w, "invalid archive_missing", err.Error())
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, domain.MaxFeedSize)
+	imp, err := h.svc.ImportFeed(r.Context(), user.ID, format, body, archive != nil && *archive)
+	if errors.Is(err, domain.ErrNotCompany) {
+		response.Forbidden(w, err.Error())
+		return
+	}
+	if err != nil {
+		response.BadRequest(w, "import failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusAccepted, imp)
+}
+
+func (h *Handler) FeedImportSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	imp, err := h.svc.FeedImport(r.Context(), id, user.ID)
+	if err != nil {
+		response.NotFound(w, err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, imp)
+}
+
+func (h *Handler) FeedsSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	items, err := h.svc.Feeds(r.Context(), user.ID)
+	if err != nil {
+		response.Internal(w, "feeds failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) CreateFeedSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	var body struct {
+		URL    string            `json:"url"`
+		Format domain.FeedFormat `json:"format"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+
+	f, err := h.svc.RegisterFeed(r.Context(), user.ID, body.URL, body.Format)
+	if errors.Is(err, domain.ErrNotCompany) {
+		response.Forbidden(w, err.Error())
+		return
+	}
+	if err != nil {
+		response.BadRequest(w, "create feed failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusCreated, f)
+}
+
+func (h *Handler) DeleteFeedSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.DeleteFeed(r.Context(), id, user.ID); err != nil {
+		response.NotFound(w, err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) SyncFeedSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	imp, err := h.svc.SyncFeed(r.Context(), id, user.ID)
+	if err != nil {
+		response.BadRequest(w, "sync failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusAccepted, imp)
+}
@@ -2,8 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"autera/internal/modules/ads/application"
 	"autera/internal/modules/ads/domain"
@@ -14,23 +16,81 @@ import (
 )
 
 type Handler struct {
-	svc *application.Service
+	svc     *application.Service
+	siteURL string // публичный адрес сайта для ссылок в выгрузке и sitemap
+
+	deviceKey []byte // ключ HMAC идентификатора устройства в счётчиках
 }
 
-func NewHandler(svc *application.Service) *Handler {
+func NewHandler(svc *application.Service, siteURL string, deviceKey []byte) *Handler {
 	return &Handler{
-		svc: svc,
+		svc:       svc,
+		siteURL:   strings.TrimRight(siteURL, "/"),
+		deviceKey: deviceKey,
 	}
 }
 
 func (h *Handler) ListPublic(w http.ResponseWriter, r *http.Request) {
-	f := domain.ListFilter{Limit: 20, Offset: 0}
+	f, q, err := parseListFilter(r.URL.Query())
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+
+	if !q.Empty() {
+		hits, total, err := h.svc.Search(r.Context(), q, f)
+		if errors.Is(err, domain.ErrUnknownBodyType) {
+			response.BadRequest(w, "invalid filter", err.Error())
+			return
+		}
+		if err != nil {
+			response.Internal(w, "search failed")
+			return
+		}
+		ads := make([]domain.Ad, 0, len(hits))
+		for _, hit := range hits {
+			ads = append(ads, hit.Ad)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"items":       hits,
+			"total":       total,
+			"next_cursor": domain.NextCursor(f.Sort, ads, f.Limit),
+		})
+		return
+	}
+
 	items, total, err := h.svc.List(r.Context(), f)
+	if errors.Is(err, domain.ErrUnknownBodyType) {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
 	if err != nil {
 		response.Internal(w, "list failed")
 		return
 	}
-	response.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+	response.JSON(w, http.StatusOK, map[string]any{
+		"items":       items,
+		"total":       total,
+		"next_cursor": domain.NextCursor(f.Sort, items, f.Limit),
+	})
+}
+
+func (h *Handler) FacetsPublic(w http.ResponseWriter, r *http.Request) {
+	f, q, err := parseListFilter(r.URL.Query())
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+	facets, err := h.svc.Facets(r.Context(), q, f)
+	if errors.Is(err, domain.ErrUnknownBodyType) {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+	if err != nil {
+		response.Internal(w, "facets failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, facets)
 }
 
 func (h *Handler) GetPublic(w http.ResponseWriter, r *http.Request) {
@@ -40,7 +100,84 @@ func (h *Handler) GetPublic(w http.ResponseWriter, r *http.Request) {
 		response.NotFound(w, "not found")
 		return
 	}
-	response.JSON(w, http.StatusOK, ad)
+
+	resp := struct {
+		*domain.Ad
+		PriceHistory   []domain.PriceChange   `json:"price_history"`
+		VehicleHistory *domain.VehicleHistory `json:"vehicle_history,omitempty"`
+	}{Ad: ad}
+	if resp.PriceHistory, err = h.svc.PriceHistory(r.Context(), ad.ID); err != nil {
+		response.Internal(w, "price history failed")
+		return
+	}
+	_ = h.svc.TrackView(ad, h.deviceID(r))
+	if ad.VIN != "" {
+		if resp.VehicleHistory, err = h.svc.VehicleHistory(r.Context(), ad.VIN); err != nil {
+			response.Internal(w, "vehicle history failed")
+			return
+		}
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) SimilarPublic(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	limit, err := queryIntPtr(r.URL.Query(), "limit")
+	if err != nil {
+		response.BadRequest(w, "invalid limit", err.Error())
+		return
+	}
+	n := 0
+	if limit != nil {
+		n = *limit
+	}
+
+	items, err := h.svc.Similar(r.Context(), id, n)
+	if err != nil {
+		response.NotFound(w, "not found")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) DecodeVINPublic(w http.ResponseWriter, r *http.Request) {
+	info, err := h.svc.DecodeVIN(chi.URLParam(r, "vin"))
+	if err != nil {
+		response.BadRequest(w, "invalid vin", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, info)
+}
+
+// ValuationPublic — рыночная оценка: brand, model, year обязательны; mileage, city — по желанию.
+func (h *Handler) ValuationPublic(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	in := application.ValuationInput{
+		Brand: q.Get("brand"),
+		Model: q.Get("model"),
+		City:  q.Get("city"),
+	}
+	year, err := queryIntPtr(q, "year")
+	if err != nil || year == nil {
+		response.BadRequest(w, "invalid year", "year required")
+		return
+	}
+	in.Year = *year
+	if in.Mileage, err = queryIntPtr(q, "mileage"); err != nil {
+		response.BadRequest(w, "invalid mileage", err.Error())
+		return
+	}
+
+	v, err := h.svc.Valuation(r.Context(), in)
+	if errors.Is(err, domain.ErrNotEnoughData) {
+		response.NotFound(w, "not enough data")
+		return
+	}
+	if err != nil {
+		response.BadRequest(w, "valuation failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, v)
 }
 
 func (h *Handler) CreateSeller(w http.ResponseWriter, r *http.Request) {
@@ -65,6 +202,46 @@ func (h *Handler) CreateSeller(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusCreated, map[string]any{"ad_id": id})
 }
 
+func (h *Handler) UpdateSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var in application.UpdateAdInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+
+	ad, err := h.svc.Update(r.Context(), adID, user.ID, in)
+	if err != nil {
+		response.BadRequest(w, "update failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, ad)
+}
+
+func (h *Handler) RevisionsSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	items, err := h.svc.Revisions(r.Context(), adID, user.ID)
+	if err != nil {
+		response.BadRequest(w, "revisions failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
 func (h *Handler) SubmitSeller(w http.ResponseWriter, r *http.Request) {
 	user, ok := middleware.UserFromCtx(r)
 	if !ok || user == nil {
@@ -81,19 +258,69 @@ func (h *Handler) SubmitSeller(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, http.StatusOK, map[string]any{"status": "moderation"})
 }
 
-func (h *Handler) ModerateAdmin(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) MarkSoldSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
 	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 
 	var body struct {
-		Decision string `json:"decision"` // approve / reject
+		FinalPrice int `json:"final_price"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		response.BadRequest(w, "invalid json", err.Error())
 		return
 	}
-	if err := h.svc.Moderate(r.Context(), adID, body.Decision); err != nil {
-		response.BadRequest(w, "moderate failed", err.Error())
+	if err := h.svc.MarkSold(r.Context(), adID, user.ID, body.FinalPrice); err != nil {
+		response.BadRequest(w, "mark sold failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"status": domain.AdSold})
+}
+
+func (h *Handler) ArchiveSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.Archive(r.Context(), adID, user.ID); err != nil {
+		response.BadRequest(w, "archive failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"status": domain.AdArchived})
+}
+
+func (h *Handler) RelistSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	status, err := h.svc.Relist(r.Context(), adID, user.ID)
+	if err != nil {
+		response.BadRequest(w, "relist failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"status": status})
+}
+
+func (h *Handler) RevisionsAdmin(w http.ResponseWriter, r *http.Request) {
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	items, err := h.svc.Revisions(r.Context(), adID, 0)
+	if err != nil {
+		response.Internal(w, "revisions failed")
 		return
 	}
-	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
 }
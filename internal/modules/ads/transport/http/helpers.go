@@ -0,0 +1,224 @@
+package http
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"autera/internal/modules/ads/domain"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+	maxListOffset    = 10000
+)
+
+func queryIntPtr(q url.Values, key string) (*int, error) {
+	raw := q.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, errors.New("invalid " + key)
+	}
+	if v < 0 {
+		return nil, errors.New(key + " must be >= 0")
+	}
+	return &v, nil
+}
+
+func queryIDPtr(q url.Values, key string) (*int64, error) {
+	raw := q.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || v <= 0 {
+		return nil, errors.New("invalid " + key)
+	}
+	return &v, nil
+}
+
+// queryList читает список через запятую и проверяет каждое значение.
+func queryList(q url.Values, key string, allowed []string) ([]string, error) {
+	raw := q.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	var out []string
+	for _, v := range strings.Split(raw, ",") {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if allowed != nil && !domain.OneOf(v, allowed) {
+			return nil, errors.New("invalid " + key)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func queryBoolPtr(q url.Values, key string) (*bool, error) {
+	raw := q.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, errors.New("invalid " + key)
+	}
+	return &v, nil
+}
+
+// parseListFilter читает фильтры витрины из query string:
+// q, brand, brand_id, model_id, generation_id, city, year_from/to, price_from/to, mileage_from/to, inspection, verified,
+// transmission, fuel, drive, body_type, color (списки через запятую), steering,
+// engine_volume_from/to, customs_cleared, price_dropped_since, sort, cursor, limit, offset.
+func parseListFilter(q url.Values) (domain.ListFilter, domain.SearchQuery, error) {
+	search := domain.ParseSearchQuery(q.Get("q"))
+	f := domain.ListFilter{
+		Brand:  q.Get("brand"),
+		City:   q.Get("city"),
+		Limit:  defaultListLimit,
+		Offset: 0,
+	}
+
+	var err error
+	ints := []struct {
+		key string
+		dst **int
+	}{
+		{"year_from", &f.YearFrom},
+		{"year_to", &f.YearTo},
+		{"price_from", &f.PriceFrom},
+		{"price_to", &f.PriceTo},
+		{"mileage_from", &f.MileageFrom},
+		{"mileage_to", &f.MileageTo},
+		{"engine_volume_from", &f.EngineVolumeFrom},
+		{"engine_volume_to", &f.EngineVolumeTo},
+	}
+	for _, it := range ints {
+		if *it.dst, err = queryIntPtr(q, it.key); err != nil {
+			return f, search, err
+		}
+	}
+
+	ids := []struct {
+		key string
+		dst **int64
+	}{
+		{"brand_id", &f.BrandID},
+		{"model_id", &f.ModelID},
+		{"generation_id", &f.GenerationID},
+	}
+	for _, it := range ids {
+		if *it.dst, err = queryIDPtr(q, it.key); err != nil {
+			return f, search, err
+		}
+	}
+
+	if f.VerifiedOnly, err = queryBoolPtr(q, "verified"); err != nil {
+		return f, search, err
+	}
+	if f.CustomsCleared, err = queryBoolPtr(q, "customs_cleared"); err != nil {
+		return f, search, err
+	}
+
+	lists := []struct {
+		key     string
+		dst     *[]string
+		allowed []string
+	}{
+		{"transmission", &f.Transmissions, domain.Transmissions},
+		{"fuel", &f.Fuels, domain.Fuels},
+		{"drive", &f.Drives, domain.Drives},
+		{"body_type", &f.BodyTypes, nil}, // коды, названия или синонимы; сопоставляет сервис по справочнику
+		{"color", &f.Colors, domain.Colors},
+	}
+	for _, it := range lists {
+		if *it.dst, err = queryList(q, it.key, it.allowed); err != nil {
+			return f, search, err
+		}
+	}
+	if raw := q.Get("price_dropped_since"); raw != "" {
+		since, err := parseSince(raw)
+		if err != nil {
+			return f, search, errors.New("invalid price_dropped_since")
+		}
+		f.PriceDroppedSince = &since
+	}
+
+	if st := strings.ToLower(strings.TrimSpace(q.Get("steering"))); st != "" {
+		if !domain.OneOf(st, domain.SteeringSides) {
+			return f, search, errors.New("invalid steering")
+		}
+		f.Steering = st
+	}
+
+	if ins := q.Get("inspection"); ins != "" {
+		switch domain.InspectionStatus(ins) {
+		case domain.InspectionNone, domain.InspectionRequested, domain.InspectionInProgress,
+			domain.InspectionDone, domain.InspectionCertified:
+			f.Inspection = ins
+		default:
+			return f, search, errors.New("invalid inspection")
+		}
+	}
+
+	f.Sort = domain.SortNewest
+	if !search.Empty() {
+		f.Sort = domain.SortRelevance
+	}
+	if sort := q.Get("sort"); sort != "" {
+		f.Sort = domain.SortOrder(sort)
+		if !f.Sort.Valid() || (f.Sort == domain.SortRelevance && search.Empty()) {
+			return f, search, errors.New("invalid sort")
+		}
+	}
+	if token := q.Get("cursor"); token != "" {
+		if f.Cursor, err = domain.DecodeCursor(token, f.Sort); err != nil {
+			return f, search, err
+		}
+	}
+
+	if f.Limit, f.Offset, err = parsePage(q); err != nil {
+		return f, search, err
+	}
+
+	return f, search, nil
+}
+
+// parseSince принимает дату (2006-01-02) или RFC 3339.
+func parseSince(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// parsePage читает limit/offset с ограничениями сверху.
+func parsePage(q url.Values) (limit, offset int, err error) {
+	limit = defaultListLimit
+
+	l, err := queryIntPtr(q, "limit")
+	if err != nil {
+		return 0, 0, err
+	}
+	if l != nil && *l > 0 {
+		limit = min(*l, maxListLimit)
+	}
+
+	o, err := queryIntPtr(q, "offset")
+	if err != nil {
+		return 0, 0, err
+	}
+	if o != nil {
+		offset = min(*o, maxListOffset)
+	}
+	return limit, offset, nil
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseListFilterSpec(t *testing.T) {
+	tests := []struct {
+		query        string
+		wantSteering string
+		wantBodies   []string
+		wantErr      bool
+	}{
+		{query: "steering=LEFT", wantSteering: "left"},
+		{query: "steering=%20right%20", wantSteering: "right"},
+		{query: "steering=middle", wantErr: true},
+		// тип кузова сопоставляет сервис: здесь только нормализация
+		{query: "body_type=SUV,%20Внедорожник", wantBodies: []string{"suv", "внедорожник"}},
+		{query: "fuel=Diesel,steam", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, _, err := parseListFilter(q)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if f.Steering != tt.wantSteering || !reflect.DeepEqual(f.BodyTypes, tt.wantBodies) {
+				t.Fatalf("steering = %q, body types = %v", f.Steering, f.BodyTypes)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"autera/internal/modules/ads/application"
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) ModerationQueueAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	f := domain.QueueFilter{ModeratorID: user.ID, Limit: defaultListLimit}
+	unclaimed, err := queryBoolPtr(q, "unclaimed")
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+	f.UnclaimedOnly = unclaimed != nil && *unclaimed
+	if f.Limit, f.Offset, err = parsePage(q); err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+
+	items, total, err := h.svc.ModerationQueue(r.Context(), f)
+	if err != nil {
+		response.Internal(w, "queue failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+}
+
+func (h *Handler) RejectionReasonsAdmin(w http.ResponseWriter, _ *http.Request) {
+	items := make([]map[string]string, 0, len(domain.RejectionReasons))
+	for code, text := range domain.RejectionReasons {
+		items = append(items, map[string]string{"code": string(code), "text": text})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i]["code"] < items[j]["code"] })
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ClaimAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.ClaimForModeration(r.Context(), adID, user.ID); err != nil {
+		response.JSON(w, http.StatusConflict, response.Error{Error: "claim failed", Details: err.Error()})
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true, "ttl_seconds": int(domain.ClaimTTL.Seconds())})
+}
+
+func (h *Handler) ReleaseAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.ReleaseClaim(r.Context(), adID, user.ID); err != nil {
+		response.Internal(w, "release failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) ModerateAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var in application.ModerateInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	d, err := h.svc.Moderate(r.Context(), adID, user.ID, in)
+	if err != nil {
+		response.BadRequest(w, "moderate failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, d)
+}
+
+func (h *Handler) ModerationHistoryAdmin(w http.ResponseWriter, r *http.Request) {
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	items, err := h.svc.ModerationHistory(r.Context(), adID, 0)
+	if err != nil {
+		response.Internal(w, "history failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ModerationHistorySeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	items, err := h.svc.ModerationHistory(r.Context(), adID, user.ID)
+	if err != nil {
+		response.BadRequest(w, "history failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ResolveDuplicateAdmin(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var in application.ResolveDuplicateInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	if err := h.svc.ResolveDuplicate(r.Context(), adID, user.ID, in); err != nil {
+		response.BadRequest(w, "resolve duplicate failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"autera/internal/modules/ads/application"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxPhotosPerRequest = 10
+	maxPhotoRequestBody = maxPhotosPerRequest*application.MaxPhotoSize + 1<<20
+	multipartMemory     = 32 << 20
+)
+
+// UploadPhotosSeller принимает multipart/form-data с одним или несколькими полями "photos".
+func (h *Handler) UploadPhotosSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPhotoRequestBody)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		response.BadRequest(w, "invalid multipart form", err.Error())
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	headers := r.MultipartForm.File["photos"]
+	if len(headers) == 0 {
+		response.BadRequest(w, "no photos", nil)
+		return
+	}
+	if len(headers) > maxPhotosPerRequest {
+		response.BadRequest(w, "too many files in one request", nil)
+		return
+	}
+
+	uploads := make([]application.PhotoUpload, 0, len(headers))
+	for _, fh := range headers {
+		if fh.Size > application.MaxPhotoSize {
+			response.BadRequest(w, "file too large", fh.Filename)
+			return
+		}
+		f, err := fh.Open()
+		if err != nil {
+			response.BadRequest(w, "invalid file", err.Error())
+			return
+		}
+		defer f.Close()
+		uploads = append(uploads, application.PhotoUpload{Filename: fh.Filename, Data: f})
+	}
+
+	photos, err := h.svc.AddPhotos(r.Context(), adID, user.ID, uploads)
+	if err != nil {
+		response.BadRequest(w, "upload failed", map[string]any{"error": err.Error(), "uploaded": photos})
+		return
+	}
+	response.JSON(w, http.StatusCreated, map[string]any{"items": photos})
+}
+
+func (h *Handler) DeletePhotoSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	photoID, _ := strconv.ParseInt(chi.URLParam(r, "photo_id"), 10, 64)
+
+	if err := h.svc.DeletePhoto(r.Context(), adID, user.ID, photoID); err != nil {
+		response.BadRequest(w, "delete failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) ReorderPhotosSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var body struct {
+		PhotoIDs []int64 `json:"photo_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	if err := h.svc.ReorderPhotos(r.Context(), adID, user.ID, body.PhotoIDs); err != nil {
+		response.BadRequest(w, "reorder failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) SetCoverPhotoSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	photoID, _ := strconv.ParseInt(chi.URLParam(r, "photo_id"), 10, 64)
+
+	if err := h.svc.SetCoverPhoto(r.Context(), adID, user.ID, photoID); err != nil {
+		response.BadRequest(w, "set cover failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
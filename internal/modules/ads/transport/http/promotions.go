@@ -0,0 +1,73 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) PromotionProductsPublic(w http.ResponseWriter, _ *http.Request) {
+	response.JSON(w, http.StatusOK, map[string]any{"items": domain.PromotionProducts})
+}
+
+func (h *Handler) BuyPromotionSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var body struct {
+		Product string `json:"product"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+
+	p, err := h.svc.BuyPromotion(r.Context(), adID, user.ID, body.Product)
+	if err != nil {
+		response.BadRequest(w, "promotion failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusCreated, p)
+}
+
+func (h *Handler) PromotionsSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	items, err := h.svc.Promotions(r.Context(), adID, user.ID)
+	if err != nil {
+		response.BadRequest(w, "promotions failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// ActivatePromotionAdmin — подтверждение оплаты продвижения.
+func (h *Handler) ActivatePromotionAdmin(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		response.BadRequest(w, "invalid id", err.Error())
+		return
+	}
+
+	p, err := h.svc.ActivatePromotion(r.Context(), id)
+	if err != nil {
+		response.BadRequest(w, "activate promotion failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, p)
+}
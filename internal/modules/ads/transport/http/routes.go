@@ -4,14 +4,83 @@ import "github.com/go-chi/chi/v5"
 
 func RegisterPublicRoutes(r chi.Router, h *Handler) {
 	r.Get("/ads", h.ListPublic)
+	r.Get("/ads/facets", h.FacetsPublic)
 	r.Get("/ads/{id}", h.GetPublic)
+	r.Get("/ads/{id}/similar", h.SimilarPublic)
+	r.Post("/ads/{id}/track", h.TrackPublic)
+	r.Get("/vin/{vin}", h.DecodeVINPublic)
+	r.Get("/valuation", h.ValuationPublic)
+	r.Get("/promotions/products", h.PromotionProductsPublic)
+	r.Get("/complaints/reasons", h.ComplaintReasonsPublic)
+}
+
+// RegisterExportRoutes — потоковые выгрузки; подключаются без общего таймаута запроса.
+func RegisterExportRoutes(r chi.Router, h *Handler) {
+	r.Get("/ads.json", h.ExportJSONPublic)
+	r.Get("/ads.xml", h.ExportXMLPublic)
+}
+
+// RegisterSitemapRoutes — sitemap в корне сайта, вне /api/v1.
+func RegisterSitemapRoutes(r chi.Router, h *Handler) {
+	r.Get("/sitemap.xml", h.SitemapIndexPublic)
+	r.Get("/sitemaps/{name}", h.SitemapSegmentPublic)
+}
+
+// RegisterAuthRoutes — действия любого авторизованного пользователя, без проверки роли.
+func RegisterAuthRoutes(r chi.Router, h *Handler) {
+	r.Post("/ads/{id}/complaints", h.FileComplaintAuth)
 }
 
 func RegisterSellerRoutes(r chi.Router, h *Handler) {
+	r.Get("/ads", h.ListSeller)
 	r.Post("/ads", h.CreateSeller)
+	r.Get("/ads/stats", h.StatsSeller)
+	r.Patch("/ads/{id}", h.UpdateSeller)
+	r.Get("/ads/{id}/revisions", h.RevisionsSeller)
+	r.Get("/ads/{id}/moderation", h.ModerationHistorySeller)
 	r.Post("/ads/{id}/submit", h.SubmitSeller)
+	r.Post("/ads/{id}/sold", h.MarkSoldSeller)
+	r.Post("/ads/{id}/archive", h.ArchiveSeller)
+	r.Post("/ads/{id}/relist", h.RelistSeller)
+	r.Get("/ads/{id}/promotions", h.PromotionsSeller)
+	r.Post("/ads/{id}/promotions", h.BuyPromotionSeller)
+
+	r.Post("/ads/{id}/photos", h.UploadPhotosSeller)
+	r.Put("/ads/{id}/photos/order", h.ReorderPhotosSeller)
+	r.Post("/ads/{id}/photos/{photo_id}/cover", h.SetCoverPhotoSeller)
+	r.Delete("/ads/{id}/photos/{photo_id}", h.DeletePhotoSeller)
+
+	r.Post("/feeds/import", h.ImportFeedSeller)
+	r.Get("/feeds/imports/{id}", h.FeedImportSeller)
+	r.Get("/feeds", h.FeedsSeller)
+	r.Post("/feeds", h.CreateFeedSeller)
+	r.Delete("/feeds/{id}", h.DeleteFeedSeller)
+	r.Post("/feeds/{id}/sync", h.SyncFeedSeller)
 }
 
 func RegisterAdminRoutes(r chi.Router, h *Handler) {
+	r.Get("/moderation/queue", h.ModerationQueueAdmin)
+	r.Get("/moderation/reasons", h.RejectionReasonsAdmin)
+	r.Post("/ads/{id}/claim", h.ClaimAdmin)
+	r.Post("/ads/{id}/release", h.ReleaseAdmin)
 	r.Post("/ads/{id}/moderate", h.ModerateAdmin)
+	r.Post("/ads/{id}/duplicates/resolve", h.ResolveDuplicateAdmin)
+	r.Get("/complaints", h.ComplaintQueueAdmin)
+	r.Get("/ads/{id}/complaints", h.AdComplaintsAdmin)
+	r.Post("/ads/{id}/complaints/resolve", h.ResolveComplaintsAdmin)
+	r.Get("/ads/{id}/moderation", h.ModerationHistoryAdmin)
+	r.Get("/ads/{id}/revisions", h.RevisionsAdmin)
+	r.Post("/promotions/{id}/activate", h.ActivatePromotionAdmin)
+}
+
+func RegisterBuyerRoutes(r chi.Router, h *Handler) {
+	r.Get("/favorites", h.FavoritesBuyer)
+	r.Put("/favorites/{id}", h.AddFavoriteBuyer)
+	r.Delete("/favorites/{id}", h.RemoveFavoriteBuyer)
+
+	r.Get("/saved-searches", h.SavedSearchesBuyer)
+	r.Post("/saved-searches", h.CreateSavedSearchBuyer)
+	r.Delete("/saved-searches/{id}", h.DeleteSavedSearchBuyer)
+
+	r.Get("/notifications", h.NotificationsBuyer)
 }
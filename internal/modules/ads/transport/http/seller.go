@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+)
+
+// ListSeller — «мои объявления»: status (необязательно), limit, offset.
+func (h *Handler) ListSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	f := domain.SellerAdsFilter{SellerID: user.ID}
+	if st := q.Get("status"); st != "" {
+		if !domain.OneOf(st, domain.AdStatuses) {
+			response.BadRequest(w, "invalid filter", "invalid status")
+			return
+		}
+		f.Status = domain.AdStatus(st)
+	}
+	var err error
+	if f.Limit, f.Offset, err = parsePage(q); err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+
+	items, total, counts, err := h.svc.SellerAds(r.Context(), f)
+	if err != nil {
+		response.Internal(w, "list failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{
+		"items":  items,
+		"total":  total,
+		"counts": counts,
+	})
+}
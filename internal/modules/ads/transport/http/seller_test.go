@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"autera/internal/modules/ads/application"
+	"autera/internal/modules/ads/domain"
+	userdomain "autera/internal/modules/users/domain"
+	"autera/internal/transport/http/middleware"
+)
+
+// sellerRepo запоминает фильтр кабинета; объявлений и фото у продавца нет.
+type sellerRepo struct {
+	domain.Repository
+	filter *domain.SellerAdsFilter
+}
+
+func (r *sellerRepo) SellerAds(_ context.Context, f domain.SellerAdsFilter) ([]domain.SellerAd, int64, error) {
+	r.filter = &f
+	return []domain.SellerAd{}, 0, nil
+}
+
+func (r *sellerRepo) SellerStatusCounts(_ context.Context, _ int64) (map[domain.AdStatus]int64, error) {
+	return map[domain.AdStatus]int64{}, nil
+}
+
+func (r *sellerRepo) PhotosByAds(_ context.Context, _ []int64) (map[int64][]domain.Photo, error) {
+	return nil, nil
+}
+
+func TestListSellerStatusFilter(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantCode   int
+		wantStatus domain.AdStatus
+	}{
+		{query: "", wantCode: http.StatusOK},
+		{query: "?status=rejected", wantCode: http.StatusOK, wantStatus: domain.AdRejected},
+		{query: "?status=expired", wantCode: http.StatusOK, wantStatus: domain.AdExpired},
+		{query: "?status=deleted", wantCode: http.StatusBadRequest},
+		{query: "?status=Published", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			repo := &sellerRepo{}
+			h := NewHandler(application.NewService(repo, nil, nil, nil, nil, application.RulesConfig{}, application.SimilarConfig{}), "", nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/me/ads"+tt.query, nil)
+			req = middleware.WithUser(req, &userdomain.User{ID: 7})
+			rec := httptest.NewRecorder()
+			h.ListSeller(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				if repo.filter != nil {
+					t.Fatal("repository must not be queried")
+				}
+				return
+			}
+			if repo.filter.SellerID != 7 || repo.filter.Status != tt.wantStatus {
+				t.Fatalf("filter = %+v", *repo.filter)
+			}
+		})
+	}
+}
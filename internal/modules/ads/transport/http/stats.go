@@ -0,0 +1,104 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"autera/internal/modules/ads/application"
+	"autera/internal/modules/ads/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const defaultStatsDays = 30
+
+// deviceID — идентификатор устройства для дедупликации счётчиков: HMAC от IP и
+// User-Agent на серверном ключе. Заголовкам клиента не доверяем — иначе счётчик
+// накручивается сменой идентификатора в каждом запросе; IP берётся из RemoteAddr,
+// который middleware.RealIP переписывает только за доверенным прокси. Без ключа
+// хэш перебирается по пространству IPv4 и раскрывает адреса посетителей.
+func (h *Handler) deviceID(r *http.Request) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	mac := hmac.New(sha256.New, h.deviceKey)
+	mac.Write([]byte(ip + "|" + r.UserAgent()))
+	return "h:" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// TrackPublic — события, которые видит только клиент: показ телефона и открытие отчёта.
+func (h *Handler) TrackPublic(w http.ResponseWriter, r *http.Request) {
+	adID, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var body struct {
+		Event domain.StatEvent `json:"event"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	if body.Event != domain.StatPhone && body.Event != domain.StatReport {
+		response.BadRequest(w, "invalid event", "event must be phone or report")
+		return
+	}
+	err := h.svc.Track(r.Context(), adID, body.Event, h.deviceID(r))
+	if errors.Is(err, application.ErrAdNotPublished) {
+		response.NotFound(w, "not found")
+		return
+	}
+	if err != nil {
+		response.BadRequest(w, "track failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusAccepted, map[string]any{"ok": true})
+}
+
+// StatsSeller — статистика объявлений продавца: from/to (2006-01-02), по умолчанию 30 дней.
+func (h *Handler) StatsSeller(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if raw := q.Get("to"); raw != "" {
+		t, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			response.BadRequest(w, "invalid to", err.Error())
+			return
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -(defaultStatsDays - 1))
+	if raw := q.Get("from"); raw != "" {
+		t, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			response.BadRequest(w, "invalid from", err.Error())
+			return
+		}
+		from = t
+	}
+
+	items, err := h.svc.SellerStats(r.Context(), user.ID, from, to)
+	if err != nil {
+		response.BadRequest(w, "stats failed", err.Error())
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{
+		"from":  from.Format(time.DateOnly),
+		"to":    to.Format(time.DateOnly),
+		"items": items,
+	})
+}
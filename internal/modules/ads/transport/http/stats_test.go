@@ -0,0 +1,49 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeviceIDIgnoresClientHeader(t *testing.T) {
+	h := &Handler{deviceKey: []byte("secret")}
+	req := func(addr, ua, header string) string {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/ads/1", nil)
+		r.RemoteAddr = addr
+		r.Header.Set("User-Agent", ua)
+		if header != "" {
+			r.Header.Set("X-Device-ID", header)
+		}
+		return h.deviceID(r)
+	}
+
+	base := req("10.0.0.1:5000", "Mozilla/5.0", "")
+	if got := req("10.0.0.1:6000", "Mozilla/5.0", "spoofed-1"); got != base {
+		t.Fatalf("device id depends on client header or port: %s != %s", got, base)
+	}
+	if got := req("10.0.0.2:5000", "Mozilla/5.0", ""); got == base {
+		t.Fatal("different IPs must give different devices")
+	}
+	if got := req("10.0.0.1:5000", "curl/8.0", ""); got == base {
+		t.Fatal("different user agents must give different devices")
+	}
+}
+
+func TestDeviceIDKeyed(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/ads/1", nil)
+	r.RemoteAddr = "10.0.0.1:5000"
+	r.Header.Set("User-Agent", "Mozilla/5.0")
+
+	a := (&Handler{deviceKey: []byte("secret-a")}).deviceID(r)
+	b := (&Handler{deviceKey: []byte("secret-b")}).deviceID(r)
+	if a == b {
+		t.Fatal("device id must depend on the server key")
+	}
+	sum := sha256.Sum256([]byte("10.0.0.1|Mozilla/5.0"))
+	if a == "h:"+hex.EncodeToString(sum[:16]) {
+		t.Fatal("device id must not be a plain hash of ip and user agent")
+	}
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"autera/internal/modules/catalog/domain"
+)
+
+// ImportModels — вид импорта марок/моделей/поколений; остальные виды — RefKind.
+const ImportModels = "models"
+
+const (
+	maxImportRows   = 50000
+	aliasSeparator  = "|"
+	maxImportErrors = 100
+)
+
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	Rows     int           `json:"rows"`
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
+
+func (r *ImportResult) addError(line int, err error) {
+	if len(r.Errors) < maxImportErrors {
+		r.Errors = append(r.Errors, ImportError{Line: line, Error: err.Error()})
+	}
+}
+
+// importColumns — обязательные колонки заголовка по виду импорта.
+var importColumns = map[string][]string{
+	ImportModels:                 {"brand"},
+	string(domain.RefBodyType):   {"code", "name"},
+	string(domain.RefEngineType): {"code", "name"},
+}
+
+// Import загружает CSV с заголовком. Строки применяются независимо: ошибочные
+// попадают в Errors, остальные сохраняются (upsert по названию / коду).
+//
+// models: brand, brand_aliases, model, model_aliases, generation, year_from, year_to
+// body_types / engine_types: code, name, aliases
+// Синонимы в одной ячейке разделяются "|".
+func (s *Service) Import(ctx context.Context, kind string, r io.Reader) (*ImportResult, error) {
+	required, ok := importColumns[kind]
+	if !ok {
+		return nil, errors.New("unknown import kind: " + kind)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.New("cannot read header: " + err.Error())
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, c := range required {
+		if _, ok := cols[c]; !ok {
+			return nil, errors.New("missing column: " + c)
+		}
+	}
+
+	res := &ImportResult{Errors: []ImportError{}}
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		res.Rows++
+		if res.Rows > maxImportRows {
+			return res, errors.New("too many rows")
+		}
+		// битая строка (кавычки) — ошибка строки; FieldPos после ошибки Read вызывать нельзя
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			res.addError(perr.StartLine, perr.Err)
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		get := func(col string) string {
+			if i, ok := cols[col]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		if kind == ImportModels {
+			err = s.importModelRow(ctx, get)
+		} else {
+			err = s.importRefRow(ctx, domain.RefKind(kind), get)
+		}
+		if err != nil {
+			res.addError(line, err)
+			continue
+		}
+		res.Imported++
+	}
+	return res, nil
+}
+
+func splitAliases(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return cleanAliases(strings.Split(s, aliasSeparator))
+}
+
+func (s *Service) importModelRow(ctx context.Context, get func(string) string) error {
+	b, err := s.brandFromInput(BrandInput{Name: get("brand"), Aliases: splitAliases(get("brand_aliases"))})
+	if err != nil {
+		return err
+	}
+	if _, err := s.repo.UpsertBrand(ctx, b); err != nil {
+		return err
+	}
+	if get("model") == "" {
+		return nil
+	}
+
+	m, err := s.modelFromInput(ModelInput{BrandID: b.ID, Name: get("model"), Aliases: splitAliases(get("model_aliases"))})
+	if err != nil {
+		return err
+	}
+	if _, err := s.repo.UpsertModel(ctx, m); err != nil {
+		return err
+	}
+	if get("generation") == "" {
+		return nil
+	}
+
+	in := GenerationInput{ModelID: m.ID, Name: get("generation")}
+	if in.YearFrom, err = strconv.Atoi(get("year_from")); err != nil {
+		return errors.New("invalid year_from")
+	}
+	if raw := get("year_to"); raw != "" {
+		to, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("invalid year_to")
+		}
+		in.YearTo = &to
+	}
+	g, err := s.generationFromInput(in)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.UpsertGeneration(ctx, g)
+	return err
+}
+
+func (s *Service) importRefRow(ctx context.Context, kind domain.RefKind, get func(string) string) error {
+	ref, err := s.refFromInput(RefInput{Code: get("code"), Name: get("name"), Aliases: splitAliases(get("aliases"))})
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.UpsertRef(ctx, kind, ref)
+	return err
+}
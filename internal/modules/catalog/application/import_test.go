@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"autera/internal/modules/catalog/domain"
+)
+
+func TestImportModels(t *testing.T) {
+	csv := "\uFEFFbrand,brand_aliases,model,model_aliases,generation,year_from,year_to\n" +
+		"BMW,БМВ|бэха,X5,икс5,E70,2006,2013\n" +
+		"BMW,,X5,,F15,2013,\n" +
+		"Lada,ВАЗ,,,,,\n" +
+		",,X6,,,,\n" +
+		"BMW,,X3,,F25,abc,\n"
+
+	repo := newMemRepo()
+	s := &Service{repo: repo}
+	res, err := s.Import(context.Background(), ImportModels, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if res.Rows != 5 || res.Imported != 3 {
+		t.Fatalf("rows/imported = %d/%d, want 5/3", res.Rows, res.Imported)
+	}
+	if len(res.Errors) != 2 || res.Errors[0].Line != 5 || res.Errors[1].Line != 6 || res.Errors[1].Error != "invalid year_from" {
+		t.Fatalf("errors = %+v", res.Errors)
+	}
+	if len(repo.brands) != 2 || len(repo.generations) != 2 {
+		t.Fatalf("brands = %+v, generations = %+v", repo.brands, repo.generations)
+	}
+	// X3 из ошибочной строки сохранён: строка применяется по уровням
+	if len(repo.models) != 2 || repo.generations[1].YearTo != nil {
+		t.Fatalf("models = %+v, generations = %+v", repo.models, repo.generations)
+	}
+
+	m, err := s.Resolve(context.Background(), "бэха", "икс 5", 2008)
+	if err != nil || m.Generation == nil || m.Generation.Name != "E70" {
+		t.Fatalf("Resolve after import = %+v, %v", m, err)
+	}
+}
+
+func TestImportRefs(t *testing.T) {
+	csv := "code,name,aliases\n" +
+		"SUV,Внедорожник,джип|кроссовер\n" +
+		",Седан,\n" +
+		"suv,Внедорожник,\n"
+
+	repo := newMemRepo()
+	s := &Service{repo: repo}
+	res, err := s.Import(context.Background(), string(domain.RefBodyType), strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if res.Rows != 3 || res.Imported != 2 || len(res.Errors) != 1 || res.Errors[0].Error != "code required" {
+		t.Fatalf("result = %+v", res)
+	}
+	if refs := repo.refs[domain.RefBodyType]; len(refs) != 1 || refs[0].Code != "suv" {
+		t.Fatalf("refs = %+v", refs)
+	}
+}
+
+func TestImportMalformedCSV(t *testing.T) {
+	tests := []struct {
+		name         string
+		csv          string
+		wantImported int
+		wantLine     int
+	}{
+		{
+			name:         "bare quote",
+			csv:          "code,name\nsedan,Седан\nsuv,Внедо\"рожник\nhatch,Хэтчбек\n",
+			wantImported: 2,
+			wantLine:     3,
+		},
+		{
+			name:         "unterminated quote",
+			csv:          "code,name\nsedan,Седан\nsuv,\"Внедорожник\n",
+			wantImported: 1,
+			wantLine:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{repo: newMemRepo()}
+			res, err := s.Import(context.Background(), string(domain.RefBodyType), strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if res.Imported != tt.wantImported || len(res.Errors) != 1 || res.Errors[0].Line != tt.wantLine {
+				t.Fatalf("result = %+v", res)
+			}
+		})
+	}
+}
+
+func TestImportRejectsFile(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		csv  string
+	}{
+		{name: "unknown kind", kind: "colors", csv: "code,name\n"},
+		{name: "missing column", kind: string(domain.RefEngineType), csv: "code\npetrol\n"},
+		{name: "empty file", kind: ImportModels, csv: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{repo: newMemRepo()}
+			if _, err := s.Import(context.Background(), tt.kind, strings.NewReader(tt.csv)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"autera/internal/modules/catalog/domain"
+)
+
+type Service struct {
+	repo domain.Repository
+}
+
+func NewService(repo domain.Repository) *Service {
+	return &Service{repo: repo}
+}
+
+type BrandInput struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+type ModelInput struct {
+	BrandID int64    `json:"brand_id"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+type GenerationInput struct {
+	ModelID  int64  `json:"model_id"`
+	Name     string `json:"name"`
+	YearFrom int    `json:"year_from"`
+	YearTo   *int   `json:"year_to"`
+}
+
+type RefInput struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+const minCatalogYear = 1900
+
+// cleanAliases убирает пустые и повторяющиеся (с точностью до Key) синонимы.
+func cleanAliases(in []string) []string {
+	seen := make(map[string]bool, len(in))
+	out := make([]string, 0, len(in))
+	for _, a := range in {
+		a = strings.TrimSpace(a)
+		k := domain.Key(a)
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, a)
+	}
+	return out
+}
+
+func cleanName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if domain.Key(name) == "" {
+		return "", errors.New("name required")
+	}
+	return name, nil
+}
+
+// ---- brands
+
+func (s *Service) ListBrands(ctx context.Context) ([]domain.Brand, error) {
+	return s.repo.ListBrands(ctx)
+}
+
+func (s *Service) brandFromInput(in BrandInput) (*domain.Brand, error) {
+	name, err := cleanName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Brand{Name: name, Aliases: cleanAliases(in.Aliases)}, nil
+}
+
+func (s *Service) CreateBrand(ctx context.Context, in BrandInput) (*domain.Brand, error) {
+	b, err := s.brandFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.CreateBrand(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *Service) UpdateBrand(ctx context.Context, id int64, in BrandInput) (*domain.Brand, error) {
+	b, err := s.brandFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	b.ID = id
+	if err := s.repo.UpdateBrand(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *Service) DeleteBrand(ctx context.Context, id int64) error {
+	return s.repo.DeleteBrand(ctx, id)
+}
+
+// ---- models
+
+func (s *Service) ListModels(ctx context.Context, brandID int64) ([]domain.Model, error) {
+	return s.repo.ListModels(ctx, brandID)
+}
+
+func (s *Service) modelFromInput(in ModelInput) (*domain.Model, error) {
+	name, err := cleanName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Model{BrandID: in.BrandID, Name: name, Aliases: cleanAliases(in.Aliases)}, nil
+}
+
+func (s *Service) CreateModel(ctx context.Context, in ModelInput) (*domain.Model, error) {
+	if in.BrandID <= 0 {
+		return nil, errors.New("brand_id required")
+	}
+	m, err := s.modelFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.CreateModel(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UpdateModel меняет название и синонимы; перенос в другую марку не поддерживается.
+func (s *Service) UpdateModel(ctx context.Context, id int64, in ModelInput) (*domain.Model, error) {
+	m, err := s.modelFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	m.ID = id
+	if err := s.repo.UpdateModel(ctx, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *Service) DeleteModel(ctx context.Context, id int64) error {
+	return s.repo.DeleteModel(ctx, id)
+}
+
+// ---- generations
+
+func (s *Service) ListGenerations(ctx context.Context, modelID int64) ([]domain.Generation, error) {
+	return s.repo.ListGenerations(ctx, modelID)
+}
+
+func (s *Service) generationFromInput(in GenerationInput) (*domain.Generation, error) {
+	name, err := cleanName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if in.YearFrom < minCatalogYear || (in.YearTo != nil && *in.YearTo < in.YearFrom) {
+		return nil, errors.New("invalid year range")
+	}
+	return &domain.Generation{ModelID: in.ModelID, Name: name, YearFrom: in.YearFrom, YearTo: in.YearTo}, nil
+}
+
+func (s *Service) CreateGeneration(ctx context.Context, in GenerationInput) (*domain.Generation, error) {
+	if in.ModelID <= 0 {
+		return nil, errors.New("model_id required")
+	}
+	g, err := s.generationFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.CreateGeneration(ctx, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *Service) UpdateGeneration(ctx context.Context, id int64, in GenerationInput) (*domain.Generation, error) {
+	g, err := s.generationFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	g.ID = id
+	if err := s.repo.UpdateGeneration(ctx, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *Service) DeleteGeneration(ctx context.Context, id int64) error {
+	return s.repo.DeleteGeneration(ctx, id)
+}
+
+// ---- body / engine types
+
+func parseRefKind(kind string) (domain.RefKind, error) {
+	k := domain.RefKind(kind)
+	if !k.Valid() {
+		return "", errors.New("unknown reference: " + kind)
+	}
+	return k, nil
+}
+
+func (s *Service) refFromInput(in RefInput) (*domain.Ref, error) {
+	name, err := cleanName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	code := strings.ToLower(strings.TrimSpace(in.Code))
+	if code == "" {
+		return nil, errors.New("code required")
+	}
+	return &domain.Ref{Code: code, Name: name, Aliases: cleanAliases(in.Aliases)}, nil
+}
+
+func (s *Service) ListRefs(ctx context.Context, kind string) ([]domain.Ref, error) {
+	k, err := parseRefKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListRefs(ctx, k)
+}
+
+func (s *Service) CreateRef(ctx context.Context, kind string, in RefInput) (*domain.Ref, error) {
+	k, err := parseRefKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := s.refFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.CreateRef(ctx, k, ref); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (s *Service) UpdateRef(ctx context.Context, kind string, id int64, in RefInput) (*domain.Ref, error) {
+	k, err := parseRefKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := s.refFromInput(in)
+	if err != nil {
+		return nil, err
+	}
+	ref.ID = id
+	if err := s.repo.UpdateRef(ctx, k, ref); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (s *Service) DeleteRef(ctx context.Context, kind string, id int64) error {
+	k, err := parseRefKind(kind)
+	if err != nil {
+		return err
+	}
+	return s.repo.DeleteRef(ctx, k, id)
+}
+
+// ---- resolve
+
+// Resolve сопоставляет свободный текст марки/модели со справочником.
+// Ненайденные уровни остаются nil; поколение ищется по году, если он задан.
+func (s *Service) Resolve(ctx context.Context, brand, model string, year int) (*domain.Match, error) {
+	m := &domain.Match{}
+	key := domain.Key(brand)
+	if key == "" {
+		return m, nil
+	}
+
+	b, err := s.repo.FindBrand(ctx, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Brand = b
+
+	if key = domain.Key(model); key == "" {
+		return m, nil
+	}
+	md, err := s.repo.FindModel(ctx, b.ID, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Model = md
+
+	if year <= 0 {
+		return m, nil
+	}
+	g, err := s.repo.FindGeneration(ctx, md.ID, year)
+	if errors.Is(err, domain.ErrNotFound) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.Generation = g
+	return m, nil
+}
+
+// ResolveRef находит тип кузова/двигателя по коду, названию или синониму; nil — нет совпадения.
+func (s *Service) ResolveRef(ctx context.Context, kind domain.RefKind, text string) (*domain.Ref, error) {
+	key := domain.Key(text)
+	if key == "" {
+		return nil, nil
+	}
+	ref, err := s.repo.FindRef(ctx, kind, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return ref, err
+}
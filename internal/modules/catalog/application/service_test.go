@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"autera/internal/modules/catalog/domain"
+)
+
+// memRepo — справочник в памяти с поиском по ключам названия и синонимов.
+type memRepo struct {
+	domain.Repository
+	brands      []domain.Brand
+	models      []domain.Model
+	generations []domain.Generation
+	refs        map[domain.RefKind][]domain.Ref
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{refs: make(map[domain.RefKind][]domain.Ref)}
+}
+
+func hasKey(key, name string, aliases []string) bool {
+	for _, k := range domain.Keys(name, aliases) {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memRepo) FindBrand(_ context.Context, key string) (*domain.Brand, error) {
+	for _, b := range r.brands {
+		if hasKey(key, b.Name, b.Aliases) {
+			return &b, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *memRepo) FindModel(_ context.Context, brandID int64, key string) (*domain.Model, error) {
+	for _, m := range r.models {
+		if m.BrandID == brandID && hasKey(key, m.Name, m.Aliases) {
+			return &m, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *memRepo) FindGeneration(_ context.Context, modelID int64, year int) (*domain.Generation, error) {
+	for _, g := range r.generations {
+		if g.ModelID == modelID && year >= g.YearFrom && (g.YearTo == nil || year <= *g.YearTo) {
+			return &g, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *memRepo) FindRef(_ context.Context, kind domain.RefKind, key string) (*domain.Ref, error) {
+	for _, ref := range r.refs[kind] {
+		if key == domain.Key(ref.Code) || hasKey(key, ref.Name, ref.Aliases) {
+			return &ref, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// mergeAliases — объединение синонимов без повторов, как mergeSQL в PostgresRepo.
+func mergeAliases(old, add []string) []string {
+	out := append([]string(nil), old...)
+	for _, a := range add {
+		if !hasKey(domain.Key(a), "", out) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Upsert* — по естественному ключу, как в PostgresRepo.
+func (r *memRepo) UpsertBrand(_ context.Context, b *domain.Brand) (int64, error) {
+	for i := range r.brands {
+		if domain.Key(r.brands[i].Name) == domain.Key(b.Name) {
+			b.ID = r.brands[i].ID
+			b.Aliases = mergeAliases(r.brands[i].Aliases, b.Aliases)
+			r.brands[i] = *b
+			return b.ID, nil
+		}
+	}
+	b.ID = int64(len(r.brands) + 1)
+	r.brands = append(r.brands, *b)
+	return b.ID, nil
+}
+
+func (r *memRepo) UpsertModel(_ context.Context, m *domain.Model) (int64, error) {
+	for i := range r.models {
+		if r.models[i].BrandID == m.BrandID && domain.Key(r.models[i].Name) == domain.Key(m.Name) {
+			m.ID = r.models[i].ID
+			m.Aliases = mergeAliases(r.models[i].Aliases, m.Aliases)
+			r.models[i] = *m
+			return m.ID, nil
+		}
+	}
+	m.ID = int64(len(r.models) + 1)
+	r.models = append(r.models, *m)
+	return m.ID, nil
+}
+
+func (r *memRepo) UpsertGeneration(_ context.Context, g *domain.Generation) (int64, error) {
+	for i := range r.generations {
+		if r.generations[i].ModelID == g.ModelID && domain.Key(r.generations[i].Name) == domain.Key(g.Name) {
+			g.ID = r.generations[i].ID
+			r.generations[i] = *g
+			return g.ID, nil
+		}
+	}
+	g.ID = int64(len(r.generations) + 1)
+	r.generations = append(r.generations, *g)
+	return g.ID, nil
+}
+
+func (r *memRepo) UpsertRef(_ context.Context, kind domain.RefKind, ref *domain.Ref) (int64, error) {
+	refs := r.refs[kind]
+	for i := range refs {
+		if refs[i].Code == ref.Code {
+			ref.ID = refs[i].ID
+			ref.Aliases = mergeAliases(refs[i].Aliases, ref.Aliases)
+			refs[i] = *ref
+			return ref.ID, nil
+		}
+	}
+	ref.ID = int64(len(refs) + 1)
+	r.refs[kind] = append(refs, *ref)
+	return ref.ID, nil
+}
+
+func TestResolve(t *testing.T) {
+	to := 2013
+	repo := newMemRepo()
+	repo.brands = []domain.Brand{{ID: 1, Name: "BMW", Aliases: []string{"БМВ"}}, {ID: 2, Name: "Mercedes-Benz"}}
+	repo.models = []domain.Model{{ID: 10, BrandID: 1, Name: "X5", Aliases: []string{"икс5"}}, {ID: 20, BrandID: 2, Name: "E-Class"}}
+	repo.generations = []domain.Generation{
+		{ID: 100, ModelID: 10, Name: "E70", YearFrom: 2006, YearTo: &to},
+		{ID: 101, ModelID: 10, Name: "F15", YearFrom: 2013},
+	}
+	s := &Service{repo: repo}
+
+	tests := []struct {
+		name                 string
+		brand, model         string
+		year                 int
+		wantBrand, wantModel int64
+		wantGeneration       int64
+	}{
+		{name: "alias brand and model", brand: "бмв", model: "Икс 5", year: 2010, wantBrand: 1, wantModel: 10, wantGeneration: 100},
+		{name: "open generation", brand: "BMW", model: "x5", year: 2020, wantBrand: 1, wantModel: 10, wantGeneration: 101},
+		{name: "no year", brand: "mercedes benz", model: "e class", wantBrand: 2, wantModel: 20},
+		{name: "year outside generations", brand: "BMW", model: "X5", year: 1990, wantBrand: 1, wantModel: 10},
+		{name: "model of another brand", brand: "BMW", model: "E-Class", wantBrand: 1},
+		{name: "unknown brand", brand: "Tesla", model: "Model 3"},
+		{name: "empty brand", brand: " ", model: "X5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := s.Resolve(context.Background(), tt.brand, tt.model, tt.year)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			var brand, model, generation int64
+			if m.Brand != nil {
+				brand = m.Brand.ID
+			}
+			if m.Model != nil {
+				model = m.Model.ID
+			}
+			if m.Generation != nil {
+				generation = m.Generation.ID
+			}
+			if brand != tt.wantBrand || model != tt.wantModel || generation != tt.wantGeneration {
+				t.Fatalf("match = %d/%d/%d, want %d/%d/%d", brand, model, generation, tt.wantBrand, tt.wantModel, tt.wantGeneration)
+			}
+		})
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	repo := newMemRepo()
+	repo.refs[domain.RefBodyType] = []domain.Ref{{ID: 1, Code: "suv", Name: "Внедорожник", Aliases: []string{"джип"}}}
+	s := &Service{repo: repo}
+
+	for text, want := range map[string]int64{"suv": 1, "Внедорожник": 1, "ДЖИП": 1, "седан": 0, "": 0} {
+		ref, err := s.ResolveRef(context.Background(), domain.RefBodyType, text)
+		if err != nil {
+			t.Fatalf("ResolveRef(%q): %v", text, err)
+		}
+		var got int64
+		if ref != nil {
+			got = ref.ID
+		}
+		if got != want {
+			t.Errorf("ResolveRef(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
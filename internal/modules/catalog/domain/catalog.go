@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Brand — марка. Aliases — синонимы в латинице и кириллице ("bmw", "бмв").
+type Brand struct {
+	ID      int64
+	Name    string
+	Aliases []string
+}
+
+type Model struct {
+	ID      int64
+	BrandID int64
+	Name    string
+	Aliases []string
+}
+
+// Generation — поколение модели; YearTo == nil — выпускается до сих пор.
+type Generation struct {
+	ID       int64
+	ModelID  int64
+	Name     string
+	YearFrom int
+	YearTo   *int
+}
+
+// RefKind — простые справочники с кодом: типы кузова и двигателя.
+type RefKind string
+
+const (
+	RefBodyType   RefKind = "body_types"
+	RefEngineType RefKind = "engine_types"
+)
+
+func (k RefKind) Valid() bool {
+	return k == RefBodyType || k == RefEngineType
+}
+
+type Ref struct {
+	ID      int64
+	Code    string
+	Name    string
+	Aliases []string
+}
+
+// Match — результат сопоставления свободного текста со справочником;
+// nil — соответствующий уровень не найден.
+type Match struct {
+	Brand      *Brand
+	Model      *Model
+	Generation *Generation
+}
+
+// Key — нормализованная форма названия для поиска: нижний регистр, ё→е,
+// только буквы и цифры ("Mercedes-Benz" и "mercedes benz" совпадают).
+func Key(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == 'ё':
+			b.WriteRune('е')
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Keys — ключи поиска по названию и синонимам без повторов.
+func Keys(name string, aliases []string) []string {
+	seen := make(map[string]bool, len(aliases)+1)
+	out := make([]string, 0, len(aliases)+1)
+	for _, s := range append([]string{name}, aliases...) {
+		k := Key(s)
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Mercedes-Benz", want: "mercedesbenz"},
+		{in: " mercedes benz ", want: "mercedesbenz"},
+		{in: "Ёлка", want: "елка"},
+		{in: "BMW X5 (E70)", want: "bmwx5e70"},
+		{in: "Лада", want: "лада"},
+		{in: " - / ", want: ""},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Key(tt.in); got != tt.want {
+				t.Fatalf("Key(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		aliases []string
+		want    []string
+	}{
+		{name: "name only", in: "BMW", want: []string{"bmw"}},
+		{name: "aliases", in: "BMW", aliases: []string{"БМВ", "бэха"}, want: []string{"bmw", "бмв", "бэха"}},
+		{name: "duplicates up to key", in: "Mercedes-Benz", aliases: []string{"mercedes benz", "MERCEDES-BENZ", "Мерседес"}, want: []string{"mercedesbenz", "мерседес"}},
+		{name: "empty aliases skipped", in: "Kia", aliases: []string{"", " - "}, want: []string{"kia"}},
+		{name: "empty name", in: "", aliases: []string{"vaz"}, want: []string{"vaz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Keys(tt.in, tt.aliases); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "context"
+
+type Repository interface {
+	// brands
+	ListBrands(ctx context.Context) ([]Brand, error)
+	CreateBrand(ctx context.Context, b *Brand) (int64, error)
+	UpdateBrand(ctx context.Context, b *Brand) error
+	DeleteBrand(ctx context.Context, id int64) error
+
+	// models
+	ListModels(ctx context.Context, brandID int64) ([]Model, error)
+	CreateModel(ctx context.Context, m *Model) (int64, error)
+	UpdateModel(ctx context.Context, m *Model) error
+	DeleteModel(ctx context.Context, id int64) error
+
+	// generations
+	ListGenerations(ctx context.Context, modelID int64) ([]Generation, error)
+	CreateGeneration(ctx context.Context, g *Generation) (int64, error)
+	UpdateGeneration(ctx context.Context, g *Generation) error
+	DeleteGeneration(ctx context.Context, id int64) error
+
+	// body / engine types
+	ListRefs(ctx context.Context, kind RefKind) ([]Ref, error)
+	CreateRef(ctx context.Context, kind RefKind, ref *Ref) (int64, error)
+	UpdateRef(ctx context.Context, kind RefKind, ref *Ref) error
+	DeleteRef(ctx context.Context, kind RefKind, id int64) error
+
+	// lookup по ключу (см. Key); ErrNotFound, если нет совпадения
+	FindBrand(ctx context.Context, key string) (*Brand, error)
+	FindModel(ctx context.Context, brandID int64, key string) (*Model, error)
+	FindGeneration(ctx context.Context, modelID int64, year int) (*Generation, error)
+	FindRef(ctx context.Context, kind RefKind, key string) (*Ref, error)
+
+	// import: вставка или обновление по естественному ключу (название / код)
+	UpsertBrand(ctx context.Context, b *Brand) (int64, error)
+	UpsertModel(ctx context.Context, m *Model) (int64, error)
+	UpsertGeneration(ctx context.Context, g *Generation) (int64, error)
+	UpsertRef(ctx context.Context, kind RefKind, ref *Ref) (int64, error)
+}
@@ -0,0 +1,309 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"autera/internal/modules/catalog/domain"
+
+	"github.com/lib/pq"
+)
+
+type PostgresRepo struct {
+	db *sql.DB
+}
+
+func NewPostgresRepo(db *sql.DB) *PostgresRepo {
+	return &PostgresRepo{
+		db: db,
+	}
+}
+
+// mergeArray — объединение массивов без повторов для upsert при импорте.
+const mergeArray = `(SELECT COALESCE(array_agg(DISTINCT x), '{}') FROM unnest(%[1]s.%[2]s || EXCLUDED.%[2]s) x)`
+
+func mergeSQL(table, col string) string { return fmt.Sprintf(mergeArray, table, col) }
+
+func affected(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// ---- brands
+
+func (r *PostgresRepo) ListBrands(ctx context.Context) ([]domain.Brand, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, aliases FROM car_brands ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Brand{}
+	for rows.Next() {
+		var b domain.Brand
+		if err := rows.Scan(&b.ID, &b.Name, pq.Array(&b.Aliases)); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) CreateBrand(ctx context.Context, b *domain.Brand) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO car_brands (name, aliases, keys) VALUES ($1,$2,$3) RETURNING id
+	`, b.Name, pq.Array(b.Aliases), pq.Array(domain.Keys(b.Name, b.Aliases))).Scan(&b.ID)
+	return b.ID, err
+}
+
+func (r *PostgresRepo) UpdateBrand(ctx context.Context, b *domain.Brand) error {
+	return affected(r.db.ExecContext(ctx, `
+		UPDATE car_brands SET name=$2, aliases=$3, keys=$4 WHERE id=$1
+	`, b.ID, b.Name, pq.Array(b.Aliases), pq.Array(domain.Keys(b.Name, b.Aliases))))
+}
+
+func (r *PostgresRepo) DeleteBrand(ctx context.Context, id int64) error {
+	return affected(r.db.ExecContext(ctx, `DELETE FROM car_brands WHERE id=$1`, id))
+}
+
+func (r *PostgresRepo) FindBrand(ctx context.Context, key string) (*domain.Brand, error) {
+	var b domain.Brand
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, name, aliases FROM car_brands WHERE keys @> ARRAY[$1]::text[] ORDER BY id LIMIT 1
+	`, key).Scan(&b.ID, &b.Name, pq.Array(&b.Aliases))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &b, nil
+}
+
+func (r *PostgresRepo) UpsertBrand(ctx context.Context, b *domain.Brand) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO car_brands (name, aliases, keys) VALUES ($1,$2,$3)
+		ON CONFLICT (lower(name)) DO UPDATE
+		SET aliases = `+mergeSQL("car_brands", "aliases")+`,
+		    keys = `+mergeSQL("car_brands", "keys")+`
+		RETURNING id
+	`, b.Name, pq.Array(b.Aliases), pq.Array(domain.Keys(b.Name, b.Aliases))).Scan(&b.ID)
+	return b.ID, err
+}
+
+// ---- models
+
+func (r *PostgresRepo) ListModels(ctx context.Context, brandID int64) ([]domain.Model, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, brand_id, name, aliases FROM car_models WHERE brand_id=$1 ORDER BY name
+	`, brandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Model{}
+	for rows.Next() {
+		var m domain.Model
+		if err := rows.Scan(&m.ID, &m.BrandID, &m.Name, pq.Array(&m.Aliases)); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) CreateModel(ctx context.Context, m *domain.Model) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO car_models (brand_id, name, aliases, keys) VALUES ($1,$2,$3,$4) RETURNING id
+	`, m.BrandID, m.Name, pq.Array(m.Aliases), pq.Array(domain.Keys(m.Name, m.Aliases))).Scan(&m.ID)
+	return m.ID, err
+}
+
+func (r *PostgresRepo) UpdateModel(ctx context.Context, m *domain.Model) error {
+	return affected(r.db.ExecContext(ctx, `
+		UPDATE car_models SET name=$2, aliases=$3, keys=$4 WHERE id=$1
+	`, m.ID, m.Name, pq.Array(m.Aliases), pq.Array(domain.Keys(m.Name, m.Aliases))))
+}
+
+func (r *PostgresRepo) DeleteModel(ctx context.Context, id int64) error {
+	return affected(r.db.ExecContext(ctx, `DELETE FROM car_models WHERE id=$1`, id))
+}
+
+func (r *PostgresRepo) FindModel(ctx context.Context, brandID int64, key string) (*domain.Model, error) {
+	var m domain.Model
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, brand_id, name, aliases FROM car_models
+		WHERE brand_id=$1 AND keys @> ARRAY[$2]::text[]
+		ORDER BY id LIMIT 1
+	`, brandID, key).Scan(&m.ID, &m.BrandID, &m.Name, pq.Array(&m.Aliases))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &m, nil
+}
+
+func (r *PostgresRepo) UpsertModel(ctx context.Context, m *domain.Model) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO car_models (brand_id, name, aliases, keys) VALUES ($1,$2,$3,$4)
+		ON CONFLICT (brand_id, lower(name)) DO UPDATE
+		SET aliases = `+mergeSQL("car_models", "aliases")+`,
+		    keys = `+mergeSQL("car_models", "keys")+`
+		RETURNING id
+	`, m.BrandID, m.Name, pq.Array(m.Aliases), pq.Array(domain.Keys(m.Name, m.Aliases))).Scan(&m.ID)
+	return m.ID, err
+}
+
+// ---- generations
+
+func scanGeneration(row interface{ Scan(...any) error }) (*domain.Generation, error) {
+	var g domain.Generation
+	var to sql.NullInt64
+	if err := row.Scan(&g.ID, &g.ModelID, &g.Name, &g.YearFrom, &to); err != nil {
+		return nil, err
+	}
+	if to.Valid {
+		v := int(to.Int64)
+		g.YearTo = &v
+	}
+	return &g, nil
+}
+
+func (r *PostgresRepo) ListGenerations(ctx context.Context, modelID int64) ([]domain.Generation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, model_id, name, year_from, year_to FROM car_generations
+		WHERE model_id=$1 ORDER BY year_from
+	`, modelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Generation{}
+	for rows.Next() {
+		g, err := scanGeneration(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *g)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) CreateGeneration(ctx context.Context, g *domain.Generation) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO car_generations (model_id, name, year_from, year_to) VALUES ($1,$2,$3,$4) RETURNING id
+	`, g.ModelID, g.Name, g.YearFrom, g.YearTo).Scan(&g.ID)
+	return g.ID, err
+}
+
+func (r *PostgresRepo) UpdateGeneration(ctx context.Context, g *domain.Generation) error {
+	return affected(r.db.ExecContext(ctx, `
+		UPDATE car_generations SET name=$2, year_from=$3, year_to=$4 WHERE id=$1
+	`, g.ID, g.Name, g.YearFrom, g.YearTo))
+}
+
+func (r *PostgresRepo) DeleteGeneration(ctx context.Context, id int64) error {
+	return affected(r.db.ExecContext(ctx, `DELETE FROM car_generations WHERE id=$1`, id))
+}
+
+// FindGeneration — поколение, выпускавшееся в год year; при пересечении берётся более новое.
+func (r *PostgresRepo) FindGeneration(ctx context.Context, modelID int64, year int) (*domain.Generation, error) {
+	g, err := scanGeneration(r.db.QueryRowContext(ctx, `
+		SELECT id, model_id, name, year_from, year_to FROM car_generations
+		WHERE model_id=$1 AND year_from <= $2 AND (year_to IS NULL OR year_to >= $2)
+		ORDER BY year_from DESC LIMIT 1
+	`, modelID, year))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return g, nil
+}
+
+func (r *PostgresRepo) UpsertGeneration(ctx context.Context, g *domain.Generation) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO car_generations (model_id, name, year_from, year_to) VALUES ($1,$2,$3,$4)
+		ON CONFLICT (model_id, lower(name)) DO UPDATE
+		SET year_from = EXCLUDED.year_from, year_to = EXCLUDED.year_to
+		RETURNING id
+	`, g.ModelID, g.Name, g.YearFrom, g.YearTo).Scan(&g.ID)
+	return g.ID, err
+}
+
+// ---- body / engine types
+// kind проверяется сервисом (RefKind.Valid) и совпадает с именем таблицы.
+
+func (r *PostgresRepo) ListRefs(ctx context.Context, kind domain.RefKind) ([]domain.Ref, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, code, name, aliases FROM `+string(kind)+` ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Ref{}
+	for rows.Next() {
+		var ref domain.Ref
+		if err := rows.Scan(&ref.ID, &ref.Code, &ref.Name, pq.Array(&ref.Aliases)); err != nil {
+			return nil, err
+		}
+		out = append(out, ref)
+	}
+	return out, rows.Err()
+}
+
+func refKeys(ref *domain.Ref) []string {
+	return domain.Keys(ref.Name, append([]string{ref.Code}, ref.Aliases...))
+}
+
+func (r *PostgresRepo) CreateRef(ctx context.Context, kind domain.RefKind, ref *domain.Ref) (int64, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO `+string(kind)+` (code, name, aliases, keys) VALUES ($1,$2,$3,$4) RETURNING id
+	`, ref.Code, ref.Name, pq.Array(ref.Aliases), pq.Array(refKeys(ref))).Scan(&ref.ID)
+	return ref.ID, err
+}
+
+func (r *PostgresRepo) UpdateRef(ctx context.Context, kind domain.RefKind, ref *domain.Ref) error {
+	return affected(r.db.ExecContext(ctx, `
+		UPDATE `+string(kind)+` SET code=$2, name=$3, aliases=$4, keys=$5 WHERE id=$1
+	`, ref.ID, ref.Code, ref.Name, pq.Array(ref.Aliases), pq.Array(refKeys(ref))))
+}
+
+func (r *PostgresRepo) DeleteRef(ctx context.Context, kind domain.RefKind, id int64) error {
+	return affected(r.db.ExecContext(ctx, `DELETE FROM `+string(kind)+` WHERE id=$1`, id))
+}
+
+func (r *PostgresRepo) FindRef(ctx context.Context, kind domain.RefKind, key string) (*domain.Ref, error) {
+	var ref domain.Ref
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, code, name, aliases FROM `+string(kind)+`
+		WHERE keys @> ARRAY[$1]::text[] ORDER BY id LIMIT 1
+	`, key).Scan(&ref.ID, &ref.Code, &ref.Name, pq.Array(&ref.Aliases))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &ref, nil
+}
+
+func (r *PostgresRepo) UpsertRef(ctx context.Context, kind domain.RefKind, ref *domain.Ref) (int64, error) {
+	t := string(kind)
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO `+t+` (code, name, aliases, keys) VALUES ($1,$2,$3,$4)
+		ON CONFLICT (code) DO UPDATE
+		SET name = EXCLUDED.name,
+		    aliases = `+mergeSQL(t, "aliases")+`,
+		    keys = `+mergeSQL(t, "keys")+`
+		RETURNING id
+	`, ref.Code, ref.Name, pq.Array(ref.Aliases), pq.Array(refKeys(ref))).Scan(&ref.ID)
+	return ref.ID, err
+}
@@ -0,0 +1,237 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"autera/internal/modules/catalog/application"
+	"autera/internal/modules/catalog/domain"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const maxImportSize = 10 << 20
+
+type Handler struct {
+	svc *application.Service
+}
+
+func NewHandler(svc *application.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+func idParam(r *http.Request) int64 {
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	return id
+}
+
+// writeErr: ErrNotFound → 404, остальное — ошибка ввода.
+func writeErr(w http.ResponseWriter, msg string, err error) {
+	if errors.Is(err, domain.ErrNotFound) {
+		response.NotFound(w, "not found")
+		return
+	}
+	response.BadRequest(w, msg, err.Error())
+}
+
+func decode(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return false
+	}
+	return true
+}
+
+// ---- public
+
+func (h *Handler) ListBrands(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListBrands(r.Context())
+	if err != nil {
+		response.Internal(w, "list brands failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ListModels(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListModels(r.Context(), idParam(r))
+	if err != nil {
+		response.Internal(w, "list models failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ListGenerations(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListGenerations(r.Context(), idParam(r))
+	if err != nil {
+		response.Internal(w, "list generations failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *Handler) ListRefs(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListRefs(r.Context(), chi.URLParam(r, "kind"))
+	if err != nil {
+		writeErr(w, "list failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// ---- admin
+
+func (h *Handler) CreateBrandAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.BrandInput
+	if !decode(w, r, &in) {
+		return
+	}
+	b, err := h.svc.CreateBrand(r.Context(), in)
+	if err != nil {
+		writeErr(w, "create brand failed", err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, b)
+}
+
+func (h *Handler) UpdateBrandAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.BrandInput
+	if !decode(w, r, &in) {
+		return
+	}
+	b, err := h.svc.UpdateBrand(r.Context(), idParam(r), in)
+	if err != nil {
+		writeErr(w, "update brand failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, b)
+}
+
+func (h *Handler) DeleteBrandAdmin(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteBrand(r.Context(), idParam(r)); err != nil {
+		writeErr(w, "delete brand failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) CreateModelAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.ModelInput
+	if !decode(w, r, &in) {
+		return
+	}
+	m, err := h.svc.CreateModel(r.Context(), in)
+	if err != nil {
+		writeErr(w, "create model failed", err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, m)
+}
+
+func (h *Handler) UpdateModelAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.ModelInput
+	if !decode(w, r, &in) {
+		return
+	}
+	m, err := h.svc.UpdateModel(r.Context(), idParam(r), in)
+	if err != nil {
+		writeErr(w, "update model failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, m)
+}
+
+func (h *Handler) DeleteModelAdmin(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteModel(r.Context(), idParam(r)); err != nil {
+		writeErr(w, "delete model failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) CreateGenerationAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.GenerationInput
+	if !decode(w, r, &in) {
+		return
+	}
+	g, err := h.svc.CreateGeneration(r.Context(), in)
+	if err != nil {
+		writeErr(w, "create generation failed", err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, g)
+}
+
+func (h *Handler) UpdateGenerationAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.GenerationInput
+	if !decode(w, r, &in) {
+		return
+	}
+	g, err := h.svc.UpdateGeneration(r.Context(), idParam(r), in)
+	if err != nil {
+		writeErr(w, "update generation failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, g)
+}
+
+func (h *Handler) DeleteGenerationAdmin(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteGeneration(r.Context(), idParam(r)); err != nil {
+		writeErr(w, "delete generation failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+func (h *Handler) CreateRefAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.RefInput
+	if !decode(w, r, &in) {
+		return
+	}
+	ref, err := h.svc.CreateRef(r.Context(), chi.URLParam(r, "kind"), in)
+	if err != nil {
+		writeErr(w, "create failed", err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, ref)
+}
+
+func (h *Handler) UpdateRefAdmin(w http.ResponseWriter, r *http.Request) {
+	var in application.RefInput
+	if !decode(w, r, &in) {
+		return
+	}
+	ref, err := h.svc.UpdateRef(r.Context(), chi.URLParam(r, "kind"), idParam(r), in)
+	if err != nil {
+		writeErr(w, "update failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, ref)
+}
+
+func (h *Handler) DeleteRefAdmin(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteRef(r.Context(), chi.URLParam(r, "kind"), idParam(r)); err != nil {
+		writeErr(w, "delete failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
+
+// ImportAdmin принимает CSV в теле запроса; вид — query-параметр kind
+// (models / body_types / engine_types).
+func (h *Handler) ImportAdmin(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	res, err := h.svc.Import(r.Context(), r.URL.Query().Get("kind"), r.Body)
+	if err != nil {
+		// строки до ошибки уже сохранены — отдаём частичный результат
+		response.BadRequest(w, "import failed", map[string]any{"reason": err.Error(), "result": res})
+		return
+	}
+	response.JSON(w, http.StatusOK, res)
+}
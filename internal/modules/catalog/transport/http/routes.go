@@ -0,0 +1,30 @@
+package http
+
+import "github.com/go-chi/chi/v5"
+
+func RegisterPublicRoutes(r chi.Router, h *Handler) {
+	r.Get("/catalog/brands", h.ListBrands)
+	r.Get("/catalog/brands/{id}/models", h.ListModels)
+	r.Get("/catalog/models/{id}/generations", h.ListGenerations)
+	r.Get("/catalog/refs/{kind}", h.ListRefs)
+}
+
+func RegisterAdminRoutes(r chi.Router, h *Handler) {
+	r.Post("/catalog/brands", h.CreateBrandAdmin)
+	r.Put("/catalog/brands/{id}", h.UpdateBrandAdmin)
+	r.Delete("/catalog/brands/{id}", h.DeleteBrandAdmin)
+
+	r.Post("/catalog/models", h.CreateModelAdmin)
+	r.Put("/catalog/models/{id}", h.UpdateModelAdmin)
+	r.Delete("/catalog/models/{id}", h.DeleteModelAdmin)
+
+	r.Post("/catalog/generations", h.CreateGenerationAdmin)
+	r.Put("/catalog/generations/{id}", h.UpdateGenerationAdmin)
+	r.Delete("/catalog/generations/{id}", h.DeleteGenerationAdmin)
+
+	r.Post("/catalog/refs/{kind}", h.CreateRefAdmin)
+	r.Put("/catalog/refs/{kind}/{id}", h.UpdateRefAdmin)
+	r.Delete("/catalog/refs/{kind}/{id}", h.DeleteRefAdmin)
+
+	r.Post("/catalog/import", h.ImportAdmin)
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"autera/internal/modules/conversations/domain"
+)
+
+// Ads — доступ к объявлениям модуля ads без прямой связи модулей.
+type Ads interface {
+	AdRef(ctx context.Context, adID int64) (domain.AdRef, error)
+}
+
+type Service struct {
+	repo domain.Repository
+	ads  Ads
+}
+
+func NewService(repo domain.Repository, ads Ads) *Service {
+	return &Service{
+		repo: repo,
+		ads:  ads,
+	}
+}
+
+// Started — результат Start; Created — переписка создана, а не продолжена.
+type Started struct {
+	Conversation *domain.Conversation
+	Message      *domain.Message
+	Created      bool
+}
+
+// Start открывает (или продолжает) переписку покупателя с продавцом по
+// опубликованному объявлению и отправляет первое сообщение.
+func (s *Service) Start(ctx context.Context, adID, buyerID int64, body string) (*Started, error) {
+	ad, err := s.ads.AdRef(ctx, adID)
+	if err != nil {
+		return nil, err
+	}
+	if !ad.Published {
+		return nil, errors.New("ad is not published")
+	}
+	if ad.SellerID == buyerID {
+		return nil, errors.New("cannot message your own ad")
+	}
+	// проверяем до создания, чтобы не оставлять пустых переписок; окончательно
+	// блокировку проверяет AddMessage
+	if _, err := normalizeBody(body); err != nil {
+		return nil, err
+	}
+	blocked, err := s.repo.IsBlocked(ctx, ad.SellerID, buyerID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, domain.ErrBlocked
+	}
+
+	c, created, err := s.repo.GetOrCreate(ctx, ad.ID, ad.SellerID, buyerID)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.send(ctx, c, buyerID, body)
+	if err != nil {
+		return nil, err
+	}
+	return &Started{Conversation: c, Message: m, Created: created}, nil
+}
+
+// Send — сообщение участника в существующую переписку. После блокировки
+// переписка закрыта для обеих сторон (проверяет AddMessage).
+func (s *Service) Send(ctx context.Context, conversationID, userID int64, body string) (*domain.Message, error) {
+	c, err := s.participant(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.send(ctx, c, userID, body)
+}
+
+func (s *Service) send(ctx context.Context, c *domain.Conversation, senderID int64, body string) (*domain.Message, error) {
+	text, err := normalizeBody(body)
+	if err != nil {
+		return nil, err
+	}
+	m := &domain.Message{
+		ConversationID: c.ID,
+		SenderID:       senderID,
+		Body:           domain.MaskContacts(text),
+	}
+	if err := s.repo.AddMessage(ctx, m); err != nil {
+		return nil, err
+	}
+	c.LastMessageAt = m.CreatedAt
+	return m, nil
+}
+
+func normalizeBody(body string) (string, error) {
+	text := strings.TrimSpace(body)
+	if text == "" {
+		return "", errors.New("message is empty")
+	}
+	if utf8.RuneCountInString(text) > domain.MaxMessageLength {
+		return "", errors.New("message is too long")
+	}
+	return text, nil
+}
+
+// participant — переписка, если пользователь в ней участвует.
+func (s *Service) participant(ctx context.Context, conversationID, userID int64) (*domain.Conversation, error) {
+	c, err := s.repo.Get(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsParticipant(userID) {
+		return nil, domain.ErrNotParticipant
+	}
+	return c, nil
+}
+
+func (s *Service) List(ctx context.Context, userID int64, limit, offset int) ([]domain.Conversation, int64, error) {
+	return s.repo.ListForUser(ctx, userID, limit, offset)
+}
+
+// Messages — страница сообщений от новых к старым; beforeID — курсор предыдущей страницы.
+func (s *Service) Messages(ctx context.Context, conversationID, userID, beforeID int64, limit int) ([]domain.Message, error) {
+	if _, err := s.participant(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.Messages(ctx, conversationID, beforeID, limit)
+}
+
+// MarkRead отмечает прочитанными входящие сообщения; собеседник видит read_at.
+func (s *Service) MarkRead(ctx context.Context, conversationID, userID int64) (int64, error) {
+	if _, err := s.participant(ctx, conversationID, userID); err != nil {
+		return 0, err
+	}
+	return s.repo.MarkRead(ctx, conversationID, userID)
+}
+
+func (s *Service) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	return s.repo.UnreadCount(ctx, userID)
+}
+
+// SetBlocked — продавец блокирует или разблокирует покупателя переписки
+// во всех своих объявлениях.
+func (s *Service) SetBlocked(ctx context.Context, conversationID, sellerID int64, blocked bool) error {
+	c, err := s.participant(ctx, conversationID, sellerID)
+	if err != nil {
+		return err
+	}
+	if c.SellerID != sellerID {
+		return errors.New("only the seller can block")
+	}
+	if blocked {
+		return s.repo.Block(ctx, c.SellerID, c.BuyerID)
+	}
+	return s.repo.Unblock(ctx, c.SellerID, c.BuyerID)
+}
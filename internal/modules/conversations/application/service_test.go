@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"autera/internal/modules/conversations/domain"
+)
+
+type fakeAds map[int64]domain.AdRef
+
+func (a fakeAds) AdRef(_ context.Context, adID int64) (domain.AdRef, error) {
+	ad, ok := a[adID]
+	if !ok {
+		return domain.AdRef{}, errors.New("ad not found")
+	}
+	return ad, nil
+}
+
+// memRepo — переписки в памяти; blocked проверяется и в AddMessage, как в базе.
+type memRepo struct {
+	domain.Repository
+	convs    map[int64]*domain.Conversation
+	blocked  map[[2]int64]bool
+	messages []domain.Message
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{convs: map[int64]*domain.Conversation{}, blocked: map[[2]int64]bool{}}
+}
+
+func (r *memRepo) GetOrCreate(_ context.Context, adID, sellerID, buyerID int64) (*domain.Conversation, bool, error) {
+	for _, c := range r.convs {
+		if c.AdID == adID && c.BuyerID == buyerID {
+			return c, false, nil
+		}
+	}
+	c := &domain.Conversation{ID: int64(len(r.convs) + 1), AdID: adID, SellerID: sellerID, BuyerID: buyerID}
+	r.convs[c.ID] = c
+	return c, true, nil
+}
+
+func (r *memRepo) Get(_ context.Context, id int64) (*domain.Conversation, error) {
+	c, ok := r.convs[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func (r *memRepo) AddMessage(_ context.Context, m *domain.Message) error {
+	c := r.convs[m.ConversationID]
+	if r.blocked[[2]int64{c.SellerID, c.BuyerID}] {
+		return domain.ErrBlocked
+	}
+	m.ID = int64(len(r.messages) + 1)
+	r.messages = append(r.messages, *m)
+	return nil
+}
+
+func (r *memRepo) IsBlocked(_ context.Context, sellerID, userID int64) (bool, error) {
+	return r.blocked[[2]int64{sellerID, userID}], nil
+}
+
+func (r *memRepo) Block(_ context.Context, sellerID, userID int64) error {
+	r.blocked[[2]int64{sellerID, userID}] = true
+	return nil
+}
+
+func (r *memRepo) Unblock(_ context.Context, sellerID, userID int64) error {
+	delete(r.blocked, [2]int64{sellerID, userID})
+	return nil
+}
+
+const (
+	sellerID = int64(7)
+	buyerID  = int64(8)
+)
+
+func newTestService() (*Service, *memRepo) {
+	repo := newMemRepo()
+	ads := fakeAds{
+		1: {ID: 1, SellerID: sellerID, Published: true},
+		2: {ID: 2, SellerID: sellerID, Published: false},
+	}
+	return NewService(repo, ads), repo
+}
+
+func TestStartCreatedOnce(t *testing.T) {
+	s, repo := newTestService()
+	ctx := context.Background()
+
+	first, err := s.Start(ctx, 1, buyerID, "Здравствуйте, машина в продаже?")
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if !first.Created {
+		t.Fatal("first Start must create the conversation")
+	}
+	again, err := s.Start(ctx, 1, buyerID, "Звоните 89991234567")
+	if err != nil {
+		t.Fatalf("Start again: %v", err)
+	}
+	if again.Created || again.Conversation.ID != first.Conversation.ID {
+		t.Fatalf("second Start must continue conversation %d: %+v", first.Conversation.ID, again)
+	}
+	if again.Message.Body != "Звоните "+domain.MaskedContact {
+		t.Fatalf("body = %q, contacts must be masked", again.Message.Body)
+	}
+	if len(repo.messages) != 2 {
+		t.Fatalf("messages = %d, want 2", len(repo.messages))
+	}
+}
+
+func TestStartRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		adID    int64
+		buyer   int64
+		body    string
+		blocked bool
+		wantErr error
+	}{
+		{name: "unpublished ad", adID: 2, buyer: buyerID, body: "привет"},
+		{name: "own ad", adID: 1, buyer: sellerID, body: "привет"},
+		{name: "empty body", adID: 1, buyer: buyerID, body: "   "},
+		{name: "blocked buyer", adID: 1, buyer: buyerID, body: "привет", blocked: true, wantErr: domain.ErrBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newTestService()
+			if tt.blocked {
+				_ = repo.Block(context.Background(), sellerID, tt.buyer)
+			}
+			_, err := s.Start(context.Background(), tt.adID, tt.buyer, tt.body)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.convs) != 0 {
+				t.Fatal("conversation must not be created")
+			}
+		})
+	}
+}
+
+func TestSendAfterBlock(t *testing.T) {
+	s, _ := newTestService()
+	ctx := context.Background()
+
+	res, err := s.Start(ctx, 1, buyerID, "привет")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetBlocked(ctx, res.Conversation.ID, buyerID, true); err == nil {
+		t.Fatal("buyer must not be able to block")
+	}
+	if err := s.SetBlocked(ctx, res.Conversation.ID, sellerID, true); err != nil {
+		t.Fatal(err)
+	}
+	// переписка закрыта для обеих сторон
+	for _, user := range []int64{buyerID, sellerID} {
+		if _, err := s.Send(ctx, res.Conversation.ID, user, "ещё"); !errors.Is(err, domain.ErrBlocked) {
+			t.Fatalf("Send by %d: err = %v, want ErrBlocked", user, err)
+		}
+	}
+	if _, err := s.Send(ctx, res.Conversation.ID, 99, "чужой"); !errors.Is(err, domain.ErrNotParticipant) {
+		t.Fatalf("outsider: err = %v, want ErrNotParticipant", err)
+	}
+
+	if err := s.SetBlocked(ctx, res.Conversation.ID, sellerID, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Send(ctx, res.Conversation.ID, buyerID, "снова"); err != nil {
+		t.Fatalf("Send after unblock: %v", err)
+	}
+}
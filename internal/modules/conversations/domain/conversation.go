@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound       = errors.New("conversation not found")
+	ErrNotParticipant = errors.New("not a participant of the conversation")
+	ErrBlocked        = errors.New("seller has blocked this user")
+)
+
+// MaxMessageLength — предел длины сообщения в символах.
+const MaxMessageLength = 2000
+
+// Conversation — переписка покупателя с продавцом по одному объявлению.
+type Conversation struct {
+	ID            int64     `json:"id"`
+	AdID          int64     `json:"ad_id"`
+	SellerID      int64     `json:"seller_id"`
+	BuyerID       int64     `json:"buyer_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastMessageAt time.Time `json:"last_message_at"`
+
+	// заполняются для конкретного участника
+	LastMessage *Message `json:"last_message,omitempty"`
+	Unread      int64    `json:"unread"`
+	Blocked     bool     `json:"blocked"`
+}
+
+// IsParticipant — пользователь продавец или покупатель в переписке.
+func (c *Conversation) IsParticipant(userID int64) bool {
+	return userID == c.SellerID || userID == c.BuyerID
+}
+
+type Message struct {
+	ID             int64      `json:"id"`
+	ConversationID int64      `json:"conversation_id"`
+	SenderID       int64      `json:"sender_id"`
+	Body           string     `json:"body"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReadAt         *time.Time `json:"read_at,omitempty"` // прочитано получателем
+}
+
+// AdRef — сведения об объявлении из модуля ads.
+type AdRef struct {
+	ID        int64
+	SellerID  int64
+	Published bool
+}
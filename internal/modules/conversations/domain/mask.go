@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaskedContact заменяет телефоны, ссылки и почту в сообщениях, чтобы сделка
+// не уходила с площадки.
+const MaskedContact = "[скрыто]"
+
+var (
+	emailRe = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-zа-я]{2,}`)
+	// ссылки со схемой (без завершающей пунктуации) и ники мессенджеров
+	urlRe = regexp.MustCompile(`(?i)(?:https?://|www\.)\S*[^\s,;.:!?()"'«»]|@[a-z0-9_]{5,}`)
+	// домен без схемы; tail — символ после домена, чтобы не резать слова вроде "site.rubles"
+	domainRe = regexp.MustCompile(`(?i)(?:[a-zа-я0-9\-]+\.)+(?:ru|рф|su|com|net|org|info|biz|me|io|app|link|ly)(?:/\S*)?(?P<tail>[^\p{L}\p{N}_]|$)`)
+	// телефон только в телефонной раскладке: +7/8 и 10 цифр группами 3-3-2-2,
+	// те же группы без префикса с мобильной девятки, либо международный номер
+	// с плюсом. Цены, годы и пробеги под эти шаблоны не подходят.
+	phoneRe = regexp.MustCompile(`(?:\+7|8)[\s\-]?\(?\d{3}\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}` +
+		`|\+[1-9]\d{0,2}[\s\-]?\(?\d{2,4}\)?(?:[\s\-]?\d{2,4}){2,4}` +
+		`|\(?9\d{2}\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}`)
+)
+
+// телефон — от 10 до 15 цифр (E.164)
+const minPhoneDigits, maxPhoneDigits = 10, 15
+
+// MaskContacts скрывает контакты в тексте сообщения.
+func MaskContacts(text string) string {
+	text = emailRe.ReplaceAllString(text, MaskedContact)
+	text = urlRe.ReplaceAllString(text, MaskedContact)
+	text = domainRe.ReplaceAllString(text, MaskedContact+"${tail}")
+	return maskPhones(text)
+}
+
+// maskPhones заменяет совпадения phoneRe, которые не являются куском более
+// длинного числа (цифра вплотную слева или справа).
+func maskPhones(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range phoneRe.FindAllStringIndex(text, -1) {
+		start, end := m[0], m[1]
+		if start > 0 && isDigit(text[start-1]) || end < len(text) && isDigit(text[end]) {
+			continue
+		}
+		n := 0
+		for i := start; i < end; i++ {
+			if isDigit(text[i]) {
+				n++
+			}
+		}
+		if n < minPhoneDigits || n > maxPhoneDigits {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(MaskedContact)
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
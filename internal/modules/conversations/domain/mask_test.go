@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestMaskContacts(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		// не телефоны: цены, годы, пробеги
+		{name: "price with spaces", in: "Отдам за 1 250 000 (2019)", want: "Отдам за 1 250 000 (2019)"},
+		{name: "year and mileage", in: "Год 2018 150000 км", want: "Год 2018 150000 км"},
+		{name: "mileage with spaces", in: "пробег 250 000 км, цена 980 000", want: "пробег 250 000 км, цена 980 000"},
+		{name: "price and year", in: "1 450 000 руб, 2015 г.", want: "1 450 000 руб, 2015 г."},
+		{name: "engine and power", in: "2.0 л, 150 л.с., 2019-2020", want: "2.0 л, 150 л.с., 2019-2020"},
+		{name: "long number", in: "номер кузова 123456789012345678", want: "номер кузова 123456789012345678"},
+
+		// телефоны
+		{name: "plain 8", in: "звоните 89991234567", want: "звоните [скрыто]"},
+		{name: "plus seven formatted", in: "тел. +7 (999) 123-45-67 вечером", want: "тел. [скрыто] вечером"},
+		{name: "eight with dashes", in: "8-999-123-45-67", want: "[скрыто]"},
+		{name: "eight with brackets", in: "8(999)1234567", want: "[скрыто]"},
+		{name: "mobile without prefix", in: "мой 999 123 45 67", want: "мой [скрыто]"},
+		{name: "international", in: "+375 29 123-45-67", want: "[скрыто]"},
+		{name: "two phones", in: "89991234567 или 89997654321", want: "[скрыто] или [скрыто]"},
+		{name: "phone glued to text", in: "тел89991234567", want: "тел[скрыто]"},
+		{name: "phone and price", in: "Цена 1 250 000, звоните +79991234567", want: "Цена 1 250 000, звоните [скрыто]"},
+
+		// прочие контакты
+		{name: "email", in: "пишите ivan.petrov@mail.ru", want: "пишите [скрыто]"},
+		{name: "url", in: "смотри https://example.com/car?id=1 тут", want: "смотри [скрыто] тут"},
+		{name: "url before punctuation", in: "см. www.example.com/car.", want: "см. [скрыто]."},
+		{name: "messenger nick", in: "tg @ivan_cars", want: "tg [скрыто]"},
+		{name: "bare domain", in: "на avito.ru дешевле", want: "на [скрыто] дешевле"},
+		{name: "word with dot is kept", in: "site.rubles", want: "site.rubles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskContacts(tt.in); got != tt.want {
+				t.Fatalf("MaskContacts(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
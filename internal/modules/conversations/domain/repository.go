@@ -0,0 +1,27 @@
+package domain
+
+import "context"
+
+type Repository interface {
+	// GetOrCreate возвращает переписку по объявлению и покупателю, создавая её
+	// при первом обращении; created — переписка создана этим вызовом.
+	GetOrCreate(ctx context.Context, adID, sellerID, buyerID int64) (c *Conversation, created bool, err error)
+	Get(ctx context.Context, id int64) (*Conversation, error)
+	// ListForUser — переписки пользователя (продавца или покупателя), свежие сверху,
+	// с последним сообщением и числом непрочитанных.
+	ListForUser(ctx context.Context, userID int64, limit, offset int) ([]Conversation, int64, error)
+
+	// AddMessage сохраняет сообщение и сдвигает last_message_at переписки;
+	// ErrBlocked — если продавец заблокировал покупателя (проверка под блокировкой переписки).
+	AddMessage(ctx context.Context, m *Message) error
+	// Messages — сообщения новее к старым; beforeID > 0 — только старше него.
+	Messages(ctx context.Context, conversationID, beforeID int64, limit int) ([]Message, error)
+	// MarkRead отмечает прочитанными сообщения собеседника.
+	MarkRead(ctx context.Context, conversationID, readerID int64) (int64, error)
+	UnreadCount(ctx context.Context, userID int64) (int64, error)
+
+	// blocks
+	Block(ctx context.Context, sellerID, userID int64) error
+	Unblock(ctx context.Context, sellerID, userID int64) error
+	IsBlocked(ctx context.Context, sellerID, userID int64) (bool, error)
+}
@@ -0,0 +1,248 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"autera/internal/modules/conversations/domain"
+)
+
+type PostgresRepo struct{ db *sql.DB }
+
+func NewPostgresRepo(db *sql.DB) *PostgresRepo { return &PostgresRepo{db: db} }
+
+const conversationColumns = `c.id, c.ad_id, c.seller_id, c.buyer_id, c.created_at, c.last_message_at`
+
+func (r *PostgresRepo) GetOrCreate(ctx context.Context, adID, sellerID, buyerID int64) (*domain.Conversation, bool, error) {
+	// DO UPDATE без изменений, чтобы RETURNING вернул и существующую строку;
+	// xmax = 0 только у строки, вставленной этим запросом
+	var c domain.Conversation
+	var created bool
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO conversations AS c (ad_id, seller_id, buyer_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (ad_id, buyer_id) DO UPDATE SET ad_id = EXCLUDED.ad_id
+		RETURNING `+conversationColumns+`, (c.xmax = 0)`,
+		adID, sellerID, buyerID,
+	).Scan(&c.ID, &c.AdID, &c.SellerID, &c.BuyerID, &c.CreatedAt, &c.LastMessageAt, &created)
+	if err != nil {
+		return nil, false, err
+	}
+	return &c, created, nil
+}
+
+func (r *PostgresRepo) Get(ctx context.Context, id int64) (*domain.Conversation, error) {
+	var c domain.Conversation
+	err := r.db.QueryRowContext(ctx, `SELECT `+conversationColumns+` FROM conversations c WHERE c.id=$1`, id).
+		Scan(&c.ID, &c.AdID, &c.SellerID, &c.BuyerID, &c.CreatedAt, &c.LastMessageAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *PostgresRepo) ListForUser(ctx context.Context, userID int64, limit, offset int) ([]domain.Conversation, int64, error) {
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(1) FROM conversations WHERE seller_id=$1 OR buyer_id=$1
+	`, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+conversationColumns+`,
+		       (SELECT COUNT(1) FROM conversation_messages u
+		        WHERE u.conversation_id = c.id AND u.sender_id <> $1 AND u.read_at IS NULL),
+		       EXISTS (SELECT 1 FROM conversation_blocks b WHERE b.seller_id = c.seller_id AND b.user_id = c.buyer_id),
+		       lm.id, lm.sender_id, lm.body, lm.created_at, lm.read_at
+		FROM conversations c
+		LEFT JOIN LATERAL (
+			SELECT m.id, m.sender_id, m.body, m.created_at, m.read_at
+			FROM conversation_messages m
+			WHERE m.conversation_id = c.id
+			ORDER BY m.id DESC
+			LIMIT 1
+		) lm ON TRUE
+		WHERE c.seller_id=$1 OR c.buyer_id=$1
+		ORDER BY c.last_message_at DESC, c.id DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.Conversation, 0)
+	for rows.Next() {
+		c, err := scanListed(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanListed — строка ListForUser: переписка, счётчики и последнее сообщение
+// (колонки lm.* пусты, если сообщений нет).
+func scanListed(row rowScanner) (*domain.Conversation, error) {
+	var c domain.Conversation
+	var (
+		lmID, lmSender sql.NullInt64
+		lmBody         sql.NullString
+		lmAt, lmRead   sql.NullTime
+	)
+	if err := row.Scan(&c.ID, &c.AdID, &c.SellerID, &c.BuyerID, &c.CreatedAt, &c.LastMessageAt,
+		&c.Unread, &c.Blocked, &lmID, &lmSender, &lmBody, &lmAt, &lmRead); err != nil {
+		return nil, err
+	}
+	if lmID.Valid {
+		m := &domain.Message{ID: lmID.Int64, ConversationID: c.ID, SenderID: lmSender.Int64, Body: lmBody.String, CreatedAt: lmAt.Time}
+		if lmRead.Valid {
+			m.ReadAt = &lmRead.Time
+		}
+		c.LastMessage = m
+	}
+	return &c, nil
+}
+
+func (r *PostgresRepo) AddMessage(ctx context.Context, m *domain.Message) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// блокировка строки переписки упорядочивает сообщение с Block: после
+	// блокировки покупателя сообщение уже не пройдёт
+	var sellerID, buyerID int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT seller_id, buyer_id FROM conversations WHERE id=$1 FOR UPDATE
+	`, m.ConversationID).Scan(&sellerID, &buyerID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	var blocked bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM conversation_blocks WHERE seller_id=$1 AND user_id=$2)
+	`, sellerID, buyerID).Scan(&blocked); err != nil {
+		return err
+	}
+	if blocked {
+		return domain.ErrBlocked
+	}
+
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO conversation_messages (conversation_id, sender_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`, m.ConversationID, m.SenderID, m.Body).Scan(&m.ID, &m.CreatedAt); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE conversations SET last_message_at = $2 WHERE id = $1
+	`, m.ConversationID, m.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) Messages(ctx context.Context, conversationID, beforeID int64, limit int) ([]domain.Message, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, conversation_id, sender_id, body, created_at, read_at
+		FROM conversation_messages
+		WHERE conversation_id=$1 AND ($2::bigint = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`, conversationID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]domain.Message, 0)
+	for rows.Next() {
+		var m domain.Message
+		var readAt sql.NullTime
+		if err := rows.Scan(&m.ID, &m.ConversationID, &m.SenderID, &m.Body, &m.CreatedAt, &readAt); err != nil {
+			return nil, err
+		}
+		if readAt.Valid {
+			m.ReadAt = &readAt.Time
+		}
+		items = append(items, m)
+	}
+	return items, rows.Err()
+}
+
+func (r *PostgresRepo) MarkRead(ctx context.Context, conversationID, readerID int64) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE conversation_messages
+		SET read_at = now()
+		WHERE conversation_id=$1 AND sender_id <> $2 AND read_at IS NULL
+	`, conversationID, readerID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *PostgresRepo) UnreadCount(ctx context.Context, userID int64) (int64, error) {
+	var n int64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(1)
+		FROM conversation_messages m
+		JOIN conversations c ON c.id = m.conversation_id
+		WHERE (c.seller_id=$1 OR c.buyer_id=$1) AND m.sender_id <> $1 AND m.read_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+// Block ждёт сообщения, которые уже отправляются в переписки пары (см. AddMessage).
+func (r *PostgresRepo) Block(ctx context.Context, sellerID, userID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+		SELECT id FROM conversations WHERE seller_id=$1 AND buyer_id=$2 FOR UPDATE
+	`, sellerID, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_blocks (seller_id, user_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, sellerID, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) Unblock(ctx context.Context, sellerID, userID int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM conversation_blocks WHERE seller_id=$1 AND user_id=$2`, sellerID, userID)
+	return err
+}
+
+func (r *PostgresRepo) IsBlocked(ctx context.Context, sellerID, userID int64) (bool, error) {
+	var ok bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM conversation_blocks WHERE seller_id=$1 AND user_id=$2)
+	`, sellerID, userID).Scan(&ok)
+	return ok, err
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeRow раскладывает значения по указателям Scan так же, как database/sql.
+type fakeRow []any
+
+func (r fakeRow) Scan(dest ...any) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("scan: %d columns, %d destinations", len(r), len(dest))
+	}
+	for i, v := range r {
+		if s, ok := dest[i].(sql.Scanner); ok {
+			if err := s.Scan(v); err != nil {
+				return err
+			}
+			continue
+		}
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+func TestScanListed(t *testing.T) {
+	created := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	last := created.Add(time.Hour)
+	read := last.Add(time.Minute)
+
+	t.Run("with last message", func(t *testing.T) {
+		c, err := scanListed(fakeRow{
+			int64(1), int64(10), int64(7), int64(8), created, last,
+			int64(3), true,
+			int64(55), int64(8), "Добрый день", last, read,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != 1 || c.AdID != 10 || c.SellerID != 7 || c.BuyerID != 8 || c.Unread != 3 || !c.Blocked {
+			t.Fatalf("conversation = %+v", c)
+		}
+		m := c.LastMessage
+		if m == nil || m.ID != 55 || m.ConversationID != 1 || m.SenderID != 8 || m.Body != "Добрый день" {
+			t.Fatalf("last message = %+v", m)
+		}
+		if m.ReadAt == nil || !m.ReadAt.Equal(read) {
+			t.Fatalf("read_at = %v, want %v", m.ReadAt, read)
+		}
+	})
+
+	t.Run("without messages", func(t *testing.T) {
+		c, err := scanListed(fakeRow{
+			int64(2), int64(10), int64(7), int64(9), created, created,
+			int64(0), false,
+			nil, nil, nil, nil, nil,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.LastMessage != nil || c.Unread != 0 || c.Blocked {
+			t.Fatalf("conversation = %+v", c)
+		}
+	})
+
+	t.Run("unread message", func(t *testing.T) {
+		c, err := scanListed(fakeRow{
+			int64(3), int64(11), int64(7), int64(8), created, last,
+			int64(1), false,
+			int64(60), int64(7), "Да, актуально", last, nil,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.LastMessage == nil || c.LastMessage.ReadAt != nil {
+			t.Fatalf("last message = %+v", c.LastMessage)
+		}
+	})
+}
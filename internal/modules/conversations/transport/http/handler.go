@@ -0,0 +1,215 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"autera/internal/modules/conversations/application"
+	"autera/internal/modules/conversations/domain"
+	"autera/internal/transport/http/middleware"
+	"autera/internal/transport/http/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	svc *application.Service
+}
+
+func NewHandler(svc *application.Service) *Handler {
+	return &Handler{
+		svc: svc,
+	}
+}
+
+// writeError переводит ошибки доступа в 403/404, остальные — в 400.
+func writeError(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		response.NotFound(w, err.Error())
+	case errors.Is(err, domain.ErrNotParticipant), errors.Is(err, domain.ErrBlocked):
+		response.Forbidden(w, err.Error())
+	default:
+		response.BadRequest(w, msg, err.Error())
+	}
+}
+
+func queryInt(r *http.Request, key string, def, max int) (int, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return def, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 {
+		return 0, errors.New("invalid " + key)
+	}
+	return min(v, max), nil
+}
+
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	var body struct {
+		AdID int64  `json:"ad_id"`
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	res, err := h.svc.Start(r.Context(), body.AdID, user.ID, body.Text)
+	if err != nil {
+		writeError(w, "start failed", err)
+		return
+	}
+	status := http.StatusOK
+	if res.Created {
+		status = http.StatusCreated
+	}
+	response.JSON(w, status, map[string]any{"conversation": res.Conversation, "message": res.Message})
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	limit, err := queryInt(r, "limit", defaultLimit, maxLimit)
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+	offset, err := queryInt(r, "offset", 0, 10000)
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+
+	items, total, err := h.svc.List(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.Internal(w, "conversations failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"items": items, "total": total})
+}
+
+func (h *Handler) Unread(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	n, err := h.svc.UnreadCount(r.Context(), user.ID)
+	if err != nil {
+		response.Internal(w, "unread failed")
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"unread": n})
+}
+
+func (h *Handler) Messages(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	limit, err := queryInt(r, "limit", defaultLimit, maxLimit)
+	if err != nil {
+		response.BadRequest(w, "invalid filter", err.Error())
+		return
+	}
+	var before int64
+	if raw := r.URL.Query().Get("before"); raw != "" {
+		if before, err = strconv.ParseInt(raw, 10, 64); err != nil || before < 0 {
+			response.BadRequest(w, "invalid filter", "invalid before")
+			return
+		}
+	}
+
+	items, err := h.svc.Messages(r.Context(), id, user.ID, before, limit)
+	if err != nil {
+		writeError(w, "messages failed", err)
+		return
+	}
+	resp := map[string]any{"items": items}
+	if len(items) == limit {
+		resp["next_before"] = items[len(items)-1].ID
+	}
+	response.JSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Send(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	var body struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		response.BadRequest(w, "invalid json", err.Error())
+		return
+	}
+	m, err := h.svc.Send(r.Context(), id, user.ID, body.Text)
+	if err != nil {
+		writeError(w, "send failed", err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, m)
+}
+
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	n, err := h.svc.MarkRead(r.Context(), id, user.ID)
+	if err != nil {
+		writeError(w, "read failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true, "read": n})
+}
+
+func (h *Handler) Block(w http.ResponseWriter, r *http.Request) {
+	h.setBlocked(w, r, true)
+}
+
+func (h *Handler) Unblock(w http.ResponseWriter, r *http.Request) {
+	h.setBlocked(w, r, false)
+}
+
+func (h *Handler) setBlocked(w http.ResponseWriter, r *http.Request, blocked bool) {
+	user, ok := middleware.UserFromCtx(r)
+	if !ok || user == nil {
+		response.Unauthorized(w, "unauthorized")
+		return
+	}
+	id, _ := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+
+	if err := h.svc.SetBlocked(r.Context(), id, user.ID, blocked); err != nil {
+		writeError(w, "block failed", err)
+		return
+	}
+	response.JSON(w, http.StatusOK, map[string]any{"ok": true})
+}
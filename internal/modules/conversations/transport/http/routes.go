@@ -0,0 +1,15 @@
+package http
+
+import "github.com/go-chi/chi/v5"
+
+// RegisterAuthRoutes — переписка доступна любой роли; участие проверяет сервис.
+func RegisterAuthRoutes(r chi.Router, h *Handler) {
+	r.Get("/conversations", h.List)
+	r.Post("/conversations", h.Start)
+	r.Get("/conversations/unread", h.Unread)
+	r.Get("/conversations/{id}/messages", h.Messages)
+	r.Post("/conversations/{id}/messages", h.Send)
+	r.Post("/conversations/{id}/read", h.MarkRead)
+	r.Put("/conversations/{id}/block", h.Block)
+	r.Delete("/conversations/{id}/block", h.Unblock)
+}
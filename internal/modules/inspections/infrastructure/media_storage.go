@@ -1 +1,4 @@
 package infrastructure
+
+// Файлы осмотров (фото, сканы) хранятся через общий storage.MediaStorage
+// из pkg/storage — тот же слой, что и фото объявлений.
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ParseTrustedProxies — разбор списка доверенных прокси: подсети (10.0.0.0/8) или отдельные адреса.
+func ParseTrustedProxies(list []string) ([]netip.Prefix, error) {
+	out := make([]netip.Prefix, 0, len(list))
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", s, err)
+			}
+			out = append(out, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", s, err)
+		}
+		out = append(out, p.Masked())
+	}
+	return out, nil
+}
+
+// RealIP — адрес клиента из X-Forwarded-For / X-Real-IP, но только если запрос
+// пришёл от доверенного прокси. Иначе заголовки подделывает сам клиент, и
+// RemoteAddr остаётся адресом соединения.
+func RealIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	isTrusted := func(addr netip.Addr) bool {
+		addr = addr.Unmap()
+		for _, p := range trusted {
+			if p.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if peer, ok := remoteAddr(r.RemoteAddr); ok && isTrusted(peer) {
+				if ip, ok := forwardedFor(r.Header, isTrusted); ok {
+					r.RemoteAddr = ip.String()
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func remoteAddr(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	return addr, err == nil
+}
+
+// forwardedFor — первый недоверенный адрес справа в X-Forwarded-For: левые
+// элементы цепочки дописывает клиент. Без X-Forwarded-For — X-Real-IP.
+func forwardedFor(h http.Header, isTrusted func(netip.Addr) bool) (netip.Addr, bool) {
+	var hops []string
+	for _, v := range h.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	var last netip.Addr
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		last = addr.Unmap()
+		if !isTrusted(last) {
+			return last, true
+		}
+	}
+	if last.IsValid() {
+		// вся цепочка из доверенных прокси
+		return last, true
+	}
+	if addr, err := netip.ParseAddr(strings.TrimSpace(h.Get("X-Real-IP"))); err == nil {
+		return addr.Unmap(), true
+	}
+	return netip.Addr{}, false
+}
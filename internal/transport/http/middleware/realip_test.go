@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		xff    []string
+		xrip   string
+		want   string
+	}{
+		{name: "direct client spoofs xff", remote: "203.0.113.7:5000", xff: []string{"1.2.3.4"}, want: "203.0.113.7:5000"},
+		{name: "direct client spoofs x-real-ip", remote: "203.0.113.7:5000", xrip: "1.2.3.4", want: "203.0.113.7:5000"},
+		{name: "trusted proxy", remote: "10.0.0.2:5000", xff: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "single trusted address", remote: "192.168.1.5:5000", xff: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "spoofed prefix in chain", remote: "10.0.0.2:5000", xff: []string{"1.2.3.4, 198.51.100.1, 10.0.0.3"}, want: "198.51.100.1"},
+		{name: "several headers", remote: "10.0.0.2:5000", xff: []string{"1.2.3.4", "198.51.100.1"}, want: "198.51.100.1"},
+		{name: "x-real-ip from trusted proxy", remote: "10.0.0.2:5000", xrip: "198.51.100.1", want: "198.51.100.1"},
+		{name: "garbage header", remote: "10.0.0.2:5000", xff: []string{"unknown"}, want: "10.0.0.2:5000"},
+		{name: "untrusted neighbour", remote: "192.168.1.6:5000", xff: []string{"198.51.100.1"}, want: "192.168.1.6:5000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := RealIP(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remote
+			for _, v := range tt.xff {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if tt.xrip != "" {
+				r.Header.Set("X-Real-IP", tt.xrip)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Fatalf("RemoteAddr = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesInvalid(t *testing.T) {
+	if _, err := ParseTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Fatal("expected error for invalid cidr")
+	}
+	if _, err := ParseTrustedProxies([]string{"proxy.local"}); err == nil {
+		t.Fatal("expected error for host name")
+	}
+}
@@ -13,7 +13,12 @@ func Recovery(log *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
-				if rec := recover(); rec != nil {
+				rec := recover()
+				if rec == http.ErrAbortHandler {
+					// намеренный обрыв ответа (например, сбой посреди выгрузки)
+					panic(rec)
+				}
+				if rec != nil {
 					log.Error("panic recovered",
 						zap.Any("panic", rec),
 						zap.ByteString("stack", debug.Stack()),
@@ -3,9 +3,12 @@ package http
 import (
 	"autera/internal/modules/users/domain"
 	"net/http"
+	"net/netip"
 	"time"
 
 	adsh "autera/internal/modules/ads/transport/http"
+	catalogh "autera/internal/modules/catalog/transport/http"
+	convh "autera/internal/modules/conversations/transport/http"
 	insh "autera/internal/modules/inspections/transport/http"
 	reph "autera/internal/modules/reports/transport/http"
 	userh "autera/internal/modules/users/transport/http"
@@ -25,10 +28,18 @@ type RouterDeps struct {
 	// нужно для Auth middleware: is_active + token_version
 	UsersRepo domain.Repository
 
-	UsersHandler *userh.Handler
-	AdsHandler   *adsh.Handler
-	InsHandler   *insh.Handler
-	RepHandler   *reph.Handler
+	// прокси, которым доверяем X-Forwarded-For / X-Real-IP
+	TrustedProxies []netip.Prefix
+
+	// раздача файлов локального хранилища; nil — файлы отдаёт S3/CDN
+	MediaHandler http.Handler
+
+	UsersHandler   *userh.Handler
+	AdsHandler     *adsh.Handler
+	CatalogHandler *catalogh.Handler
+	ConvHandler    *convh.Handler
+	InsHandler     *insh.Handler
+	RepHandler     *reph.Handler
 }
 
 func NewRouter(d RouterDeps) http.Handler {
@@ -36,10 +47,29 @@ func NewRouter(d RouterDeps) http.Handler {
 
 	// базовые middleware
 	r.Use(chimw.RequestID)
-	r.Use(chimw.RealIP)
+	r.Use(middleware.RealIP(d.TrustedProxies))
 	r.Use(middleware.Recovery(d.Logger))
 	r.Use(middleware.Logging(d.Logger))
-	r.Use(chimw.Timeout(60 * time.Second))
+
+	// выгрузки стримятся дольше общего таймаута, дедлайн записи они ставят сами
+	r.Route("/api/v1/export", func(ex chi.Router) {
+		adsh.RegisterExportRoutes(ex, d.AdsHandler)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(chimw.Timeout(60 * time.Second))
+		routes(r, d)
+	})
+
+	return r
+}
+
+func routes(r chi.Router, d RouterDeps) {
+	if d.MediaHandler != nil {
+		r.Handle("/media/*", http.StripPrefix("/media/", d.MediaHandler))
+	}
+
+	adsh.RegisterSitemapRoutes(r, d.AdsHandler)
 
 	r.Route("/api/v1", func(api chi.Router) {
 		api.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
@@ -50,6 +80,7 @@ func NewRouter(d RouterDeps) http.Handler {
 		// PUBLIC
 		userh.RegisterPublicRoutes(api, d.UsersHandler)
 		adsh.RegisterPublicRoutes(api, d.AdsHandler)
+		catalogh.RegisterPublicRoutes(api, d.CatalogHandler)
 
 		// AUTH group
 		api.Group(func(authR chi.Router) {
@@ -57,6 +88,10 @@ func NewRouter(d RouterDeps) http.Handler {
 
 			// общие auth endpoints: logout/change_password
 			userh.RegisterAuthRoutes(authR, d.UsersHandler)
+			// жалобы на объявления: любая роль
+			adsh.RegisterAuthRoutes(authR, d.AdsHandler)
+			// переписка покупателя с продавцом: доступ только участникам
+			convh.RegisterAuthRoutes(authR, d.ConvHandler)
 
 			// SELLER
 			authR.Route("/seller", func(seller chi.Router) {
@@ -76,6 +111,7 @@ func NewRouter(d RouterDeps) http.Handler {
 				admin.Use(middleware.RBAC(d.Logger, domain.RoleAdmin))
 
 				adsh.RegisterAdminRoutes(admin, d.AdsHandler)
+				catalogh.RegisterAdminRoutes(admin, d.CatalogHandler)
 				insh.RegisterAdminRoutes(admin, d.InsHandler)
 
 				// admin может: block/unblock + назначать роли без admin/owner
@@ -96,9 +132,8 @@ func NewRouter(d RouterDeps) http.Handler {
 			authR.Route("/buyer", func(buyer chi.Router) {
 				buyer.Use(middleware.RBAC(d.Logger, domain.RoleBuyer))
 				reph.RegisterBuyerRoutes(buyer, d.RepHandler)
+				adsh.RegisterBuyerRoutes(buyer, d.AdsHandler)
 			})
 		})
 	})
-
-	return r
 }
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_ads_published_mileage;
+DROP INDEX IF EXISTS ix_ads_published_year;
+DROP INDEX IF EXISTS ix_ads_published_price;
@@ -0,0 +1,4 @@
+-- keyset-пагинация витрины: (ключ сортировки, id) среди опубликованных
+CREATE INDEX IF NOT EXISTS ix_ads_published_price ON ads (price, id) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS ix_ads_published_year ON ads (year, id) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS ix_ads_published_mileage ON ads (mileage, id) WHERE status = 'published';
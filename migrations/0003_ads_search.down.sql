@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_ads_search_tsv;
+ALTER TABLE ads DROP COLUMN IF EXISTS search_tsv;
+ALTER TABLE ads DROP COLUMN IF EXISTS description;
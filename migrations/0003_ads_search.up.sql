@@ -0,0 +1,14 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+-- марка/модель важнее города, город важнее описания
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS search_tsv tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', brand || ' ' || model), 'A') ||
+        setweight(to_tsvector('russian', city), 'B') ||
+        setweight(to_tsvector('russian', description), 'C')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS ix_ads_search_tsv ON ads USING GIN (search_tsv);
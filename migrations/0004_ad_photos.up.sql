@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS ad_photos
+(
+    id           BIGSERIAL PRIMARY KEY,
+    ad_id        BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    position     INT         NOT NULL DEFAULT 0,
+    is_cover     BOOLEAN     NOT NULL DEFAULT FALSE,
+    original_key TEXT        NOT NULL,
+    medium_key   TEXT        NOT NULL,
+    thumb_key    TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_photos_ad ON ad_photos (ad_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ad_photos_cover ON ad_photos (ad_id) WHERE is_cover;
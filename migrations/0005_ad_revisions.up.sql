@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ad_revisions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    ad_id      BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    field      TEXT        NOT NULL,
+    old_value  TEXT        NOT NULL DEFAULT '',
+    new_value  TEXT        NOT NULL DEFAULT '',
+    actor_id   BIGINT      NULL REFERENCES users (id) ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_revisions_ad ON ad_revisions (ad_id, id);
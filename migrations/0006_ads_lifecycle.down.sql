@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS ix_ads_published_at;
+ALTER TABLE ads
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS sold_at,
+    DROP COLUMN IF EXISTS sold_price,
+    DROP COLUMN IF EXISTS published_at;
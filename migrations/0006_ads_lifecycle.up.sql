@@ -0,0 +1,10 @@
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS published_at      TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS sold_price        INT         NULL,
+    ADD COLUMN IF NOT EXISTS sold_at           TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- для уже опубликованных срок считаем от создания
+UPDATE ads SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS ix_ads_published_at ON ads (published_at) WHERE status = 'published';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_ads_moderation_wait;
+DROP TABLE IF EXISTS moderation_decisions;
+DROP TABLE IF EXISTS moderation_claims;
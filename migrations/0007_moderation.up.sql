@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS moderation_claims
+(
+    ad_id        BIGINT PRIMARY KEY REFERENCES ads (id) ON DELETE CASCADE,
+    moderator_id BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    claimed_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS moderation_decisions
+(
+    id           BIGSERIAL PRIMARY KEY,
+    ad_id        BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    moderator_id BIGINT      NULL REFERENCES users (id) ON DELETE SET NULL,
+    decision     TEXT        NOT NULL,
+    reason       TEXT        NOT NULL DEFAULT '',
+    comment      TEXT        NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_moderation_decisions_ad ON moderation_decisions (ad_id, id);
+CREATE INDEX IF NOT EXISTS ix_ads_moderation_wait ON ads (status_changed_at) WHERE status = 'moderation';
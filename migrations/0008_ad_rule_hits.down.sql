@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ix_ads_seller_created;
+DROP TABLE IF EXISTS ad_rule_hits;
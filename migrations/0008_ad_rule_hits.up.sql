@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ad_rule_hits
+(
+    id         BIGSERIAL PRIMARY KEY,
+    ad_id      BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    rule       TEXT        NOT NULL,
+    action     TEXT        NOT NULL,
+    message    TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_rule_hits_ad ON ad_rule_hits (ad_id);
+CREATE INDEX IF NOT EXISTS ix_ads_seller_created ON ads (seller_id, created_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_ads_vehicle;
+ALTER TABLE ads DROP COLUMN IF EXISTS vehicle_id;
+DROP TABLE IF EXISTS vehicles;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS vehicles
+(
+    id         BIGSERIAL PRIMARY KEY,
+    vin        TEXT        NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE ads ADD COLUMN IF NOT EXISTS vehicle_id BIGINT NULL REFERENCES vehicles (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS ix_ads_vehicle ON ads (vehicle_id);
+
+-- объявления, созданные до нормализации VIN
+UPDATE ads SET vin = upper(regexp_replace(vin, '[\s-]', '', 'g')) WHERE vin <> '';
+
+INSERT INTO vehicles (vin)
+SELECT DISTINCT vin FROM ads WHERE vin <> ''
+ON CONFLICT (vin) DO NOTHING;
+
+UPDATE ads a SET vehicle_id = v.id FROM vehicles v WHERE v.vin = a.vin;
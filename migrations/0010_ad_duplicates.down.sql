@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ix_ads_duplicate_attrs;
+DROP TABLE IF EXISTS ad_duplicates;
+ALTER TABLE ad_photos DROP COLUMN IF EXISTS phash;
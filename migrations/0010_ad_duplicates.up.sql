@@ -0,0 +1,18 @@
+ALTER TABLE ad_photos ADD COLUMN IF NOT EXISTS phash BIGINT NULL;
+
+CREATE TABLE IF NOT EXISTS ad_duplicates
+(
+    id           BIGSERIAL PRIMARY KEY,
+    ad_id        BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    duplicate_of BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    reason       TEXT        NOT NULL,
+    same_seller  BOOLEAN     NOT NULL DEFAULT FALSE,
+    resolution   TEXT        NOT NULL DEFAULT 'pending',
+    resolved_by  BIGINT      NULL REFERENCES users (id) ON DELETE SET NULL,
+    resolved_at  TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (ad_id, duplicate_of, reason)
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_duplicates_pending ON ad_duplicates (ad_id) WHERE resolution = 'pending';
+CREATE INDEX IF NOT EXISTS ix_ads_duplicate_attrs ON ads (lower(brand), lower(model), year, lower(city));
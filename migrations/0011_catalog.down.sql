@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS ix_ads_generation_id;
+DROP INDEX IF EXISTS ix_ads_model_id;
+DROP INDEX IF EXISTS ix_ads_brand_id;
+ALTER TABLE ads
+    DROP COLUMN IF EXISTS generation_id,
+    DROP COLUMN IF EXISTS model_id,
+    DROP COLUMN IF EXISTS brand_id;
+DROP TABLE IF EXISTS engine_types;
+DROP TABLE IF EXISTS body_types;
+DROP TABLE IF EXISTS car_generations;
+DROP TABLE IF EXISTS car_models;
+DROP TABLE IF EXISTS car_brands;
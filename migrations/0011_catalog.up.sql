@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS car_brands
+(
+    id      BIGSERIAL PRIMARY KEY,
+    name    TEXT   NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    keys    TEXT[] NOT NULL DEFAULT '{}' -- нормализованные название и синонимы для поиска
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_car_brands_name ON car_brands (lower(name));
+CREATE INDEX IF NOT EXISTS ix_car_brands_keys ON car_brands USING GIN (keys);
+
+CREATE TABLE IF NOT EXISTS car_models
+(
+    id       BIGSERIAL PRIMARY KEY,
+    brand_id BIGINT NOT NULL REFERENCES car_brands (id) ON DELETE CASCADE,
+    name     TEXT   NOT NULL,
+    aliases  TEXT[] NOT NULL DEFAULT '{}',
+    keys     TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_car_models_name ON car_models (brand_id, lower(name));
+CREATE INDEX IF NOT EXISTS ix_car_models_keys ON car_models USING GIN (keys);
+
+CREATE TABLE IF NOT EXISTS car_generations
+(
+    id        BIGSERIAL PRIMARY KEY,
+    model_id  BIGINT NOT NULL REFERENCES car_models (id) ON DELETE CASCADE,
+    name      TEXT   NOT NULL,
+    year_from INT    NOT NULL,
+    year_to   INT    NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_car_generations_name ON car_generations (model_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS body_types
+(
+    id      BIGSERIAL PRIMARY KEY,
+    code    TEXT   NOT NULL UNIQUE,
+    name    TEXT   NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    keys    TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE IF NOT EXISTS engine_types
+(
+    id      BIGSERIAL PRIMARY KEY,
+    code    TEXT   NOT NULL UNIQUE,
+    name    TEXT   NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    keys    TEXT[] NOT NULL DEFAULT '{}'
+);
+
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS brand_id      BIGINT NULL REFERENCES car_brands (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS model_id      BIGINT NULL REFERENCES car_models (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS generation_id BIGINT NULL REFERENCES car_generations (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS ix_ads_brand_id ON ads (brand_id) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS ix_ads_model_id ON ads (model_id) WHERE status = 'published';
+CREATE INDEX IF NOT EXISTS ix_ads_generation_id ON ads (generation_id) WHERE status = 'published';
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS ix_ads_published_spec;
+ALTER TABLE ads
+    DROP COLUMN IF EXISTS customs_cleared,
+    DROP COLUMN IF EXISTS steering,
+    DROP COLUMN IF EXISTS color,
+    DROP COLUMN IF EXISTS engine_volume,
+    DROP COLUMN IF EXISTS body_type,
+    DROP COLUMN IF EXISTS drive,
+    DROP COLUMN IF EXISTS fuel,
+    DROP COLUMN IF EXISTS transmission;
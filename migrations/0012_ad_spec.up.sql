@@ -0,0 +1,11 @@
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS transmission    TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fuel            TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS drive           TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS body_type       TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS engine_volume   INT     NULL,
+    ADD COLUMN IF NOT EXISTS color           TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS steering        TEXT    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS customs_cleared BOOLEAN NULL;
+
+CREATE INDEX IF NOT EXISTS ix_ads_published_spec ON ads (body_type, transmission, fuel) WHERE status = 'published';
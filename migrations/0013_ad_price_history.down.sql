@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS ix_ads_published_price_dropped;
+ALTER TABLE ads
+    DROP COLUMN IF EXISTS price_dropped_at,
+    DROP COLUMN IF EXISTS price_before_drop;
+DROP TABLE IF EXISTS ad_price_history;
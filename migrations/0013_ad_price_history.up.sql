@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS ad_price_history
+(
+    id         BIGSERIAL PRIMARY KEY,
+    ad_id      BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    old_price  INT         NOT NULL,
+    new_price  INT         NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_price_history_ad ON ad_price_history (ad_id, id);
+
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS price_before_drop INT         NULL,
+    ADD COLUMN IF NOT EXISTS price_dropped_at  TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS ix_ads_published_price_dropped ON ads (price_dropped_at) WHERE status = 'published';
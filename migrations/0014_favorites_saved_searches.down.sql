@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS saved_search_queue;
+DROP TABLE IF EXISTS saved_searches;
+DROP TABLE IF EXISTS favorites;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS favorites
+(
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    ad_id      BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, ad_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_favorites_ad ON favorites (ad_id);
+
+CREATE TABLE IF NOT EXISTS saved_searches
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    query      TEXT        NOT NULL DEFAULT '',
+    filter     JSONB       NOT NULL, -- сериализованный ads ListFilter
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_saved_searches_user ON saved_searches (user_id);
+
+-- объявления, опубликованные и ещё не сопоставленные с сохранёнными поисками
+CREATE TABLE IF NOT EXISTS saved_search_queue
+(
+    ad_id     BIGINT      PRIMARY KEY REFERENCES ads (id) ON DELETE CASCADE,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS notifications
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind            TEXT        NOT NULL,
+    ad_id           BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    saved_search_id BIGINT      NULL REFERENCES saved_searches (id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at         TIMESTAMPTZ NULL,
+    UNIQUE (user_id, kind, ad_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_notifications_unsent ON notifications (id) WHERE sent_at IS NULL;
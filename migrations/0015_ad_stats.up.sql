@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS ad_stats_daily
+(
+    ad_id     BIGINT NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    day       DATE   NOT NULL,
+    views     BIGINT NOT NULL DEFAULT 0,
+    phones    BIGINT NOT NULL DEFAULT 0,
+    favorites BIGINT NOT NULL DEFAULT 0,
+    reports   BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (ad_id, day)
+);
+
+-- кто уже засчитан за день: дедупликация по устройству между инстансами;
+-- старые дни периодически удаляются
+CREATE TABLE IF NOT EXISTS ad_stats_devices
+(
+    ad_id  BIGINT NOT NULL,
+    day    DATE   NOT NULL,
+    event  TEXT   NOT NULL,
+    device TEXT   NOT NULL,
+    PRIMARY KEY (ad_id, day, event, device)
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_stats_devices_day ON ad_stats_devices (day);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ix_ads_seller_status_changed;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS ix_ads_seller_status_changed ON ads (seller_id, status_changed_at DESC);
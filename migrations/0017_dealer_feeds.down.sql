@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dealer_feed_ads;
+DROP TABLE IF EXISTS dealer_feeds;
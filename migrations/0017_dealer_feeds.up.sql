@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS dealer_feeds
+(
+    id           BIGSERIAL PRIMARY KEY,
+    seller_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url          TEXT        NOT NULL,
+    format       TEXT        NOT NULL,
+    active       BOOLEAN     NOT NULL DEFAULT TRUE,
+    last_sync_at TIMESTAMPTZ NULL,
+    last_result  JSONB       NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_dealer_feeds_seller ON dealer_feeds (seller_id);
+
+-- внешний ID объявления в учётной системе дилера
+CREATE TABLE IF NOT EXISTS dealer_feed_ads
+(
+    seller_id   BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    external_id TEXT   NOT NULL,
+    ad_id       BIGINT NOT NULL UNIQUE REFERENCES ads (id) ON DELETE CASCADE,
+    PRIMARY KEY (seller_id, external_id)
+);
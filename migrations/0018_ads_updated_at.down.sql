@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ix_ads_published_id;
+ALTER TABLE ads DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,5 @@
+ALTER TABLE ads
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- сегменты sitemap считаются по id среди опубликованных
+CREATE INDEX IF NOT EXISTS ix_ads_published_id ON ads (id) WHERE status = 'published';
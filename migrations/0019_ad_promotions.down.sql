@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ad_promotions;
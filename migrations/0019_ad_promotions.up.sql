@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS ad_promotions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    ad_id      BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    seller_id  BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind       TEXT        NOT NULL, -- top / highlight / pin_city
+    product    TEXT        NOT NULL,
+    city       TEXT        NOT NULL DEFAULT '',
+    price      INT         NOT NULL,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_ad_promotions_ad ON ad_promotions (ad_id, kind, ends_at);
+CREATE INDEX IF NOT EXISTS ix_ad_promotions_active ON ad_promotions (kind, ends_at);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS ad_complaints
+(
+    id          BIGSERIAL PRIMARY KEY,
+    ad_id       BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    seller_id   BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_id     BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason      TEXT        NOT NULL,
+    comment     TEXT        NOT NULL DEFAULT '',
+    outcome     TEXT        NOT NULL DEFAULT '', -- '' — не решена / dismissed / confirmed
+    resolved_by BIGINT      NULL REFERENCES users (id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- одна нерешённая жалоба пользователя на объявление
+CREATE UNIQUE INDEX IF NOT EXISTS ux_ad_complaints_open ON ad_complaints (ad_id, user_id) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS ix_ad_complaints_seller ON ad_complaints (seller_id, outcome);
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS conversations
+(
+    id              BIGSERIAL PRIMARY KEY,
+    ad_id           BIGINT      NOT NULL REFERENCES ads (id) ON DELETE CASCADE,
+    seller_id       BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    buyer_id        BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_message_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (ad_id, buyer_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_conversations_seller ON conversations (seller_id, last_message_at DESC);
+CREATE INDEX IF NOT EXISTS ix_conversations_buyer ON conversations (buyer_id, last_message_at DESC);
+
+CREATE TABLE IF NOT EXISTS conversation_messages
+(
+    id              BIGSERIAL PRIMARY KEY,
+    conversation_id BIGINT      NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    sender_id       BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body            TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    read_at         TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS ix_conversation_messages_conv ON conversation_messages (conversation_id, id DESC);
+-- непрочитанные: счётчики и отметка о прочтении
+CREATE INDEX IF NOT EXISTS ix_conversation_messages_unread ON conversation_messages (conversation_id, sender_id) WHERE read_at IS NULL;
+
+-- продавец заблокировал пользователя во всех своих переписках
+CREATE TABLE IF NOT EXISTS conversation_blocks
+(
+    seller_id  BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (seller_id, user_id)
+);
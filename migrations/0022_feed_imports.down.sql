@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dealer_feed_imports;
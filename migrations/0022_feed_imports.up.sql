@@ -0,0 +1,18 @@
+-- очередь загрузок фидов: большой фид не укладывается в таймаут HTTP-запроса
+CREATE TABLE IF NOT EXISTS dealer_feed_imports
+(
+    id              BIGSERIAL PRIMARY KEY,
+    seller_id       BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    feed_id         BIGINT      NULL REFERENCES dealer_feeds (id) ON DELETE CASCADE,
+    format          TEXT        NOT NULL,
+    archive_missing BOOLEAN     NOT NULL DEFAULT FALSE,
+    payload         BYTEA       NULL, -- файл продавца; для фида по URL — NULL
+    status          TEXT        NOT NULL DEFAULT 'pending',
+    result          JSONB       NULL,
+    error           TEXT        NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at      TIMESTAMPTZ NULL,
+    finished_at     TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS ix_dealer_feed_imports_queue ON dealer_feed_imports (id) WHERE status IN ('pending', 'running');
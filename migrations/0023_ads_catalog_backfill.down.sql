@@ -0,0 +1,2 @@
+-- заполненные ссылки на справочник не откатываются
+SELECT 1;
@@ -0,0 +1,36 @@
+-- привязка к справочнику объявлений, созданных до него: без brand_id/model_id
+-- они выпадали из фильтров по id. Ключ — как catalog/domain.Key: нижний
+-- регистр, ё→е, только буквы и цифры.
+CREATE OR REPLACE FUNCTION pg_temp.catalog_key(s TEXT) RETURNS TEXT AS
+$$
+SELECT regexp_replace(replace(lower(s), 'ё', 'е'), '[^[:alnum:]]', '', 'g')
+$$ LANGUAGE sql IMMUTABLE;
+
+UPDATE ads a
+SET brand_id = (SELECT b.id
+                FROM car_brands b
+                WHERE b.keys @> ARRAY [pg_temp.catalog_key(a.brand)]
+                ORDER BY b.id
+                LIMIT 1)
+WHERE a.brand_id IS NULL;
+
+UPDATE ads a
+SET model_id = (SELECT m.id
+                FROM car_models m
+                WHERE m.brand_id = a.brand_id
+                  AND m.keys @> ARRAY [pg_temp.catalog_key(a.model)]
+                ORDER BY m.id
+                LIMIT 1)
+WHERE a.model_id IS NULL
+  AND a.brand_id IS NOT NULL;
+
+UPDATE ads a
+SET generation_id = (SELECT g.id
+                     FROM car_generations g
+                     WHERE g.model_id = a.model_id
+                       AND a.year >= g.year_from
+                       AND (g.year_to IS NULL OR a.year <= g.year_to)
+                     ORDER BY g.year_from DESC
+                     LIMIT 1)
+WHERE a.generation_id IS NULL
+  AND a.model_id IS NOT NULL;
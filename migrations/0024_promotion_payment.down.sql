@@ -0,0 +1,8 @@
+DELETE FROM ad_promotions WHERE status <> 'active';
+
+ALTER TABLE ad_promotions
+    ALTER COLUMN starts_at SET NOT NULL,
+    ALTER COLUMN ends_at SET NOT NULL,
+    DROP COLUMN IF EXISTS paid_at,
+    DROP COLUMN IF EXISTS days,
+    DROP COLUMN IF EXISTS status;
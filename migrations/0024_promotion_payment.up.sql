@@ -0,0 +1,12 @@
+-- продвижение начинает действовать только после оплаты: до неё срока нет
+ALTER TABLE ad_promotions
+    ADD COLUMN IF NOT EXISTS status  TEXT        NOT NULL DEFAULT 'active', -- pending / active
+    ADD COLUMN IF NOT EXISTS days    INT         NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS paid_at TIMESTAMPTZ NULL;
+
+UPDATE ad_promotions SET paid_at = created_at WHERE paid_at IS NULL;
+
+ALTER TABLE ad_promotions
+    ALTER COLUMN status SET DEFAULT 'pending',
+    ALTER COLUMN starts_at DROP NOT NULL,
+    ALTER COLUMN ends_at DROP NOT NULL;
@@ -0,0 +1,8 @@
+DO
+$$
+    BEGIN
+        EXECUTE format('ALTER DATABASE %I RESET pg_trgm.word_similarity_threshold', current_database());
+    END
+$$;
+
+DROP INDEX IF EXISTS ix_ads_search_trgm;
@@ -0,0 +1,12 @@
+-- нечёткое совпадение слова запроса с маркой/моделью/городом: оператор <%
+-- (word_similarity >= порога) идёт по этому индексу. Выражение должно
+-- совпадать с searchFuzzyExpr в query_builder.go.
+CREATE INDEX IF NOT EXISTS ix_ads_search_trgm ON ads USING GIN ((brand || ' ' || model || ' ' || city) gin_trgm_ops);
+
+-- порог <% для всех соединений с базой (по умолчанию 0.6 — слишком строго для опечаток)
+DO
+$$
+    BEGIN
+        EXECUTE format('ALTER DATABASE %I SET pg_trgm.word_similarity_threshold = 0.4', current_database());
+    END
+$$;
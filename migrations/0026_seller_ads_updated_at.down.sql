@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ix_ads_seller_updated;
+CREATE INDEX IF NOT EXISTS ix_ads_seller_status_changed ON ads (seller_id, status_changed_at DESC);
@@ -0,0 +1,9 @@
+-- 0018 проставил updated_at = время миграции всем старым объявлениям;
+-- восстанавливаем его по ревизиям (updated_at меняется только правками)
+UPDATE ads a
+SET updated_at = GREATEST(a.created_at, COALESCE((SELECT MAX(r.changed_at) FROM ad_revisions r WHERE r.ad_id = a.id), a.created_at))
+WHERE a.updated_at <> GREATEST(a.created_at, COALESCE((SELECT MAX(r.changed_at) FROM ad_revisions r WHERE r.ad_id = a.id), a.created_at));
+
+-- кабинет продавца сортируется по последней правке
+DROP INDEX IF EXISTS ix_ads_seller_status_changed;
+CREATE INDEX IF NOT EXISTS ix_ads_seller_updated ON ads (seller_id, updated_at DESC, id DESC);
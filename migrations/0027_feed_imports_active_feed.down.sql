@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ux_dealer_feed_imports_active_feed;
@@ -0,0 +1,13 @@
+-- уже накопившиеся параллельные синхронизации одного фида: оставляем самую раннюю
+UPDATE dealer_feed_imports
+SET status = 'failed', error = 'duplicate sync', payload = NULL, finished_at = now()
+WHERE feed_id IS NOT NULL
+  AND status IN ('pending', 'running')
+  AND id NOT IN (SELECT MIN(id)
+                 FROM dealer_feed_imports
+                 WHERE feed_id IS NOT NULL AND status IN ('pending', 'running')
+                 GROUP BY feed_id);
+
+-- не больше одной незавершённой синхронизации на фид
+CREATE UNIQUE INDEX IF NOT EXISTS ux_dealer_feed_imports_active_feed ON dealer_feed_imports (feed_id)
+    WHERE feed_id IS NOT NULL AND status IN ('pending', 'running');
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Event — доменное событие; Name — ключ подписки ("ads.price_changed").
+type Event interface {
+	Name() string
+}
+
+type Handler func(ctx context.Context, e Event)
+
+// Bus — синхронная in-process шина событий между модулями. Публикация
+// выполняется после фиксации изменений; обработчики должны быть быстрыми
+// и сами решать, что делать со своими ошибками.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+func (b *Bus) Subscribe(name string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], h)
+}
+
+// Publish вызывает обработчики по порядку подписки. nil-шина ничего не делает.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	hs := b.handlers[e.Name()]
+	b.mu.RUnlock()
+	for _, h := range hs {
+		h(ctx, e)
+	}
+}
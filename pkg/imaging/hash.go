@@ -0,0 +1,60 @@
+package imaging
+
+import (
+	"image"
+	"math/bits"
+)
+
+// DHash — перцептивный difference-hash (64 бита): сравнивает яркость соседних
+// ячеек сетки 9×8. Устойчив к пережатию, масштабу и небольшой цветокоррекции.
+func DHash(src image.Image) uint64 {
+	const w, h = 9, 8
+	g := grayGrid(src, w, h)
+	var hash uint64
+	for y := 0; y < h; y++ {
+		for x := 0; x < w-1; x++ {
+			hash <<= 1
+			if g[y*w+x] < g[y*w+x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// HashDistance — число различающихся битов; 0 — одинаковые картинки.
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// grayGrid усредняет яркость по ячейкам сетки w×h без сохранения пропорций.
+func grayGrid(src image.Image, w, h int) []uint64 {
+	rgba := toRGBA(src)
+	sw, sh := rgba.Rect.Dx(), rgba.Rect.Dy()
+	out := make([]uint64, w*h)
+	if sw == 0 || sh == 0 {
+		return out
+	}
+
+	for y := 0; y < h; y++ {
+		sy0 := y * sh / h
+		sy1 := max((y+1)*sh/h, sy0+1)
+		for x := 0; x < w; x++ {
+			sx0 := x * sw / w
+			sx1 := max((x+1)*sw/w, sx0+1)
+
+			var sum, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				off := sy*rgba.Stride + sx0*4
+				for sx := sx0; sx < sx1; sx++ {
+					// ITU-R BT.601 в целых числах
+					sum += (299*uint64(rgba.Pix[off]) + 587*uint64(rgba.Pix[off+1]) + 114*uint64(rgba.Pix[off+2])) / 1000
+					off += 4
+					n++
+				}
+			}
+			out[y*w+x] = sum / n
+		}
+	}
+	return out
+}
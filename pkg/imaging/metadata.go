@@ -0,0 +1,131 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	jpegSOI      = []byte{0xFF, 0xD8}
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+	errBadJPEG = errors.New("imaging: malformed jpeg")
+	errBadPNG  = errors.New("imaging: malformed png")
+)
+
+// StripMetadata удаляет из JPEG/PNG метаданные (EXIF с GPS, XMP, IPTC,
+// комментарии, текстовые чанки) без перекодирования пикселей.
+// Прочие форматы возвращаются как есть.
+func StripMetadata(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, jpegSOI):
+		return stripJPEG(data)
+	case bytes.HasPrefix(data, pngSignature):
+		return stripPNG(data)
+	default:
+		return data, nil
+	}
+}
+
+// stripJPEG оставляет из APPn только JFIF (APP0), цветовой профиль (APP2
+// ICC_PROFILE) и Adobe (APP14, влияет на декодирование цвета); COM и
+// остальные APPn выбрасываются. Данные после SOS копируются без разбора.
+func stripJPEG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, jpegSOI...)
+	i := len(jpegSOI)
+	for {
+		if i >= len(data) || data[i] != 0xFF {
+			return nil, errBadJPEG
+		}
+		// перед маркером допустимы байты-заполнители 0xFF
+		for i < len(data) && data[i] == 0xFF {
+			i++
+		}
+		if i >= len(data) {
+			return nil, errBadJPEG
+		}
+		marker := data[i]
+		i++
+		if marker == 0xD9 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			// маркеры без длины
+			out = append(out, 0xFF, marker)
+			if marker == 0xD9 {
+				return out, nil
+			}
+			continue
+		}
+		if i+2 > len(data) {
+			return nil, errBadJPEG
+		}
+		n := int(binary.BigEndian.Uint16(data[i:]))
+		if n < 2 || i+n > len(data) {
+			return nil, errBadJPEG
+		}
+		seg := data[i : i+n]
+		i += n
+		if marker == 0xDA {
+			// SOS: дальше энтропийно закодированные данные до конца файла
+			out = append(out, 0xFF, marker)
+			out = append(out, seg...)
+			return append(out, data[i:]...), nil
+		}
+		if !keepJPEGSegment(marker, seg[2:]) {
+			continue
+		}
+		out = append(out, 0xFF, marker)
+		out = append(out, seg...)
+	}
+}
+
+func keepJPEGSegment(marker byte, payload []byte) bool {
+	switch {
+	case marker == 0xFE: // COM
+		return false
+	case marker == 0xE0:
+		return true
+	case marker == 0xE2:
+		return bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00"))
+	case marker == 0xEE:
+		return bytes.HasPrefix(payload, []byte("Adobe"))
+	case marker >= 0xE1 && marker <= 0xEF:
+		return false
+	default:
+		return true
+	}
+}
+
+// pngMetaChunks — вспомогательные чанки с метаданными (EXIF, текст, время).
+var pngMetaChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	i := len(pngSignature)
+	for i < len(data) {
+		if i+8 > len(data) {
+			return nil, errBadPNG
+		}
+		n := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + n // длина + тип + данные + CRC
+		if end > len(data) {
+			return nil, errBadPNG
+		}
+		typ := string(data[i+4 : i+8])
+		if !pngMetaChunks[typ] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+		if typ == "IEND" {
+			return out, nil
+		}
+	}
+	return nil, errBadPNG
+}
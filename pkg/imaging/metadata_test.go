@@ -0,0 +1,105 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func jpegSegment(marker byte, payload string) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+func pngChunk(typ, payload string) []byte {
+	c := make([]byte, 4, 12+len(payload))
+	binary.BigEndian.PutUint32(c, uint32(len(payload)))
+	c = append(c, typ...)
+	c = append(c, payload...)
+	return binary.BigEndian.AppendUint32(c, crc32.ChecksumIEEE(c[4:]))
+}
+
+func TestStripMetadataJPEG(t *testing.T) {
+	var clean bytes.Buffer
+	if err := jpeg.Encode(&clean, testImage(), nil); err != nil {
+		t.Fatal(err)
+	}
+	// jpeg.Encode не пишет APPn: вставляем EXIF, XMP и комментарий сразу после SOI
+	var data []byte
+	data = append(data, jpegSOI...)
+	data = append(data, jpegSegment(0xE1, "Exif\x00\x00GPS 55.75,37.61")...)
+	data = append(data, jpegSegment(0xE1, "http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>")...)
+	data = append(data, jpegSegment(0xFE, "shot on my phone")...)
+	data = append(data, jpegSegment(0xE2, "ICC_PROFILE\x00\x01\x01profile")...)
+	data = append(data, clean.Bytes()[2:]...)
+
+	got, err := StripMetadata(data)
+	if err != nil {
+		t.Fatalf("StripMetadata: %v", err)
+	}
+	for _, leak := range []string{"Exif", "GPS", "xmpmeta", "my phone"} {
+		if bytes.Contains(got, []byte(leak)) {
+			t.Errorf("%q left in output", leak)
+		}
+	}
+	want := append(append([]byte{}, jpegSOI...), jpegSegment(0xE2, "ICC_PROFILE\x00\x01\x01profile")...)
+	want = append(want, clean.Bytes()[2:]...)
+	if !bytes.Equal(got, want) {
+		t.Fatal("image data changed")
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(got)); err != nil {
+		t.Fatalf("decode stripped: %v", err)
+	}
+}
+
+func TestStripMetadataPNG(t *testing.T) {
+	var clean bytes.Buffer
+	if err := png.Encode(&clean, testImage()); err != nil {
+		t.Fatal(err)
+	}
+	// сигнатура (8) + IHDR (12+13)
+	head := len(pngSignature) + 25
+	var data []byte
+	data = append(data, clean.Bytes()[:head]...)
+	data = append(data, pngChunk("eXIf", "MM\x00*GPS")...)
+	data = append(data, pngChunk("tEXt", "Comment\x00secret")...)
+	data = append(data, clean.Bytes()[head:]...)
+
+	got, err := StripMetadata(data)
+	if err != nil {
+		t.Fatalf("StripMetadata: %v", err)
+	}
+	if !bytes.Equal(got, clean.Bytes()) {
+		t.Fatal("metadata chunks not removed or image data changed")
+	}
+}
+
+func TestStripMetadataMalformed(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"truncated jpeg segment": append(append([]byte{}, jpegSOI...), 0xFF, 0xE1, 0x10, 0x00, 'E'),
+		"jpeg garbage":           append(append([]byte{}, jpegSOI...), 0x00, 0x01),
+		"png without IEND":       append(append([]byte{}, pngSignature...), pngChunk("IHDR", "x")...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := StripMetadata(data); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// exifOrientation — тег Orientation (0x0112) в IFD0.
+const exifOrientation = 0x0112
+
+// Orientation возвращает значение EXIF Orientation (1–8) из JPEG/PNG.
+// Без EXIF или при битых данных — 1 (как есть). Читать нужно до
+// StripMetadata: вместе с EXIF пропадает и поворот снимка.
+func Orientation(data []byte) int {
+	var tiff []byte
+	switch {
+	case bytes.HasPrefix(data, jpegSOI):
+		tiff = jpegExif(data)
+	case bytes.HasPrefix(data, pngSignature):
+		tiff = pngExif(data)
+	}
+	if o := tiffOrientation(tiff); o >= 1 && o <= 8 {
+		return o
+	}
+	return 1
+}
+
+// jpegExif — TIFF-данные первого APP1 Exif до начала скана.
+func jpegExif(data []byte) []byte {
+	i := len(jpegSOI)
+	for i+4 <= len(data) && data[i] == 0xFF {
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		n := int(binary.BigEndian.Uint16(data[i+2:]))
+		if n < 2 || i+2+n > len(data) {
+			return nil
+		}
+		payload := data[i+4 : i+2+n]
+		if marker == 0xE1 && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return payload[6:]
+		}
+		i += 2 + n
+	}
+	return nil
+}
+
+// pngExif — содержимое чанка eXIf (TIFF без префикса).
+func pngExif(data []byte) []byte {
+	i := len(pngSignature)
+	for i+8 <= len(data) {
+		n := int(binary.BigEndian.Uint32(data[i:]))
+		if i+12+n > len(data) {
+			return nil
+		}
+		switch string(data[i+4 : i+8]) {
+		case "eXIf":
+			return data[i+8 : i+8+n]
+		case "IDAT", "IEND":
+			return nil
+		}
+		i += 12 + n
+	}
+	return nil
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var bo binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		bo = binary.LittleEndian
+	case "MM":
+		bo = binary.BigEndian
+	default:
+		return 0
+	}
+	if bo.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+	ifd := int(bo.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(bo.Uint16(tiff[ifd:]))
+	for k := 0; k < count; k++ {
+		e := ifd + 2 + k*12
+		if e+12 > len(tiff) {
+			return 0
+		}
+		// SHORT (3), одно значение — лежит прямо в поле значения
+		if bo.Uint16(tiff[e:]) == exifOrientation && bo.Uint16(tiff[e+2:]) == 3 {
+			return int(bo.Uint16(tiff[e+8:]))
+		}
+	}
+	return 0
+}
+
+// Orient поворачивает/отражает изображение по значению EXIF Orientation так,
+// чтобы оно отображалось правильно без метаданных.
+func Orient(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	rgba := toRGBA(src)
+	w, h := rgba.Rect.Dx(), rgba.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // отражение по горизонтали
+				dx, dy = w-1-x, y
+			case 3: // 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // отражение по вертикали
+				dx, dy = x, h-1-y
+			case 5: // транспонирование
+				dx, dy = y, x
+			case 6: // 90° по часовой
+				dx, dy = h-1-y, x
+			case 7: // транспонирование по побочной диагонали
+				dx, dy = h-1-y, w-1-x
+			case 8: // 90° против часовой
+				dx, dy = y, w-1-x
+			}
+			s, d := y*rgba.Stride+x*4, dy*dst.Stride+dx*4
+			copy(dst.Pix[d:d+4], rgba.Pix[s:s+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,109 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// exifTIFF — минимальный TIFF с IFD0 из одного тега Orientation.
+func exifTIFF(bo binary.ByteOrder, orientation uint16) []byte {
+	t := make([]byte, 8+2+12+4)
+	if bo == binary.LittleEndian {
+		copy(t, "II")
+	} else {
+		copy(t, "MM")
+	}
+	bo.PutUint16(t[2:], 42)
+	bo.PutUint32(t[4:], 8)
+	bo.PutUint16(t[8:], 1)
+	bo.PutUint16(t[10:], exifOrientation)
+	bo.PutUint16(t[12:], 3)
+	bo.PutUint32(t[14:], 1)
+	bo.PutUint16(t[18:], orientation)
+	return t
+}
+
+func TestOrientation(t *testing.T) {
+	var cleanJPEG, cleanPNG bytes.Buffer
+	if err := jpeg.Encode(&cleanJPEG, testImage(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(&cleanPNG, testImage()); err != nil {
+		t.Fatal(err)
+	}
+	withJPEGExif := func(tiff []byte) []byte {
+		data := append([]byte{}, jpegSOI...)
+		data = append(data, jpegSegment(0xE0, "JFIF\x00\x01\x01")...)
+		data = append(data, jpegSegment(0xE1, "Exif\x00\x00"+string(tiff))...)
+		return append(data, cleanJPEG.Bytes()[2:]...)
+	}
+	head := len(pngSignature) + 25
+	withPNGExif := func(tiff []byte) []byte {
+		data := append([]byte{}, cleanPNG.Bytes()[:head]...)
+		data = append(data, pngChunk("eXIf", string(tiff))...)
+		return append(data, cleanPNG.Bytes()[head:]...)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "jpeg little endian", data: withJPEGExif(exifTIFF(binary.LittleEndian, 6)), want: 6},
+		{name: "jpeg big endian", data: withJPEGExif(exifTIFF(binary.BigEndian, 8)), want: 8},
+		{name: "png exif", data: withPNGExif(exifTIFF(binary.BigEndian, 3)), want: 3},
+		{name: "jpeg without exif", data: cleanJPEG.Bytes(), want: 1},
+		{name: "png without exif", data: cleanPNG.Bytes(), want: 1},
+		{name: "out of range", data: withJPEGExif(exifTIFF(binary.LittleEndian, 9)), want: 1},
+		{name: "truncated tiff", data: withJPEGExif(exifTIFF(binary.LittleEndian, 6)[:12]), want: 1},
+		{name: "not an image", data: []byte("hello"), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Orientation(tt.data); got != tt.want {
+				t.Fatalf("Orientation = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 3×2: каждый пиксель помечен своими координатами
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			src.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), A: 255})
+		}
+	}
+	// где оказывается левый верхний пиксель исходника и размер результата
+	tests := []struct {
+		orientation int
+		w, h        int
+		topLeft     image.Point
+	}{
+		{orientation: 1, w: 3, h: 2, topLeft: image.Pt(0, 0)},
+		{orientation: 2, w: 3, h: 2, topLeft: image.Pt(2, 0)},
+		{orientation: 3, w: 3, h: 2, topLeft: image.Pt(2, 1)},
+		{orientation: 4, w: 3, h: 2, topLeft: image.Pt(0, 1)},
+		{orientation: 5, w: 2, h: 3, topLeft: image.Pt(0, 0)},
+		{orientation: 6, w: 2, h: 3, topLeft: image.Pt(1, 0)},
+		{orientation: 7, w: 2, h: 3, topLeft: image.Pt(1, 2)},
+		{orientation: 8, w: 2, h: 3, topLeft: image.Pt(0, 2)},
+	}
+	for _, tt := range tests {
+		got := Orient(src, tt.orientation)
+		b := got.Bounds()
+		if b.Dx() != tt.w || b.Dy() != tt.h {
+			t.Errorf("orientation %d: size %dx%d, want %dx%d", tt.orientation, b.Dx(), b.Dy(), tt.w, tt.h)
+			continue
+		}
+		if c := color.RGBAModel.Convert(got.At(tt.topLeft.X, tt.topLeft.Y)).(color.RGBA); c.R != 0 || c.G != 0 {
+			t.Errorf("orientation %d: pixel at %v = (%d,%d), want source (0,0)", tt.orientation, tt.topLeft, c.R, c.G)
+		}
+	}
+}
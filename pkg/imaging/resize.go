@@ -0,0 +1,63 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit уменьшает изображение так, чтобы большая сторона была не больше maxSide,
+// усредняя пиксели по площади (без внешних зависимостей). Меньшие картинки
+// возвращаются без изменений.
+func Fit(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if maxSide <= 0 || (w <= maxSide && h <= maxSide) {
+		return src
+	}
+
+	dw, dh := maxSide, h*maxSide/w
+	if h > w {
+		dw, dh = w*maxSide/h, maxSide
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	rgba := toRGBA(src)
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		sy0, sy1 := y*h/dh, max((y+1)*h/dh, y*h/dh+1)
+		for x := 0; x < dw; x++ {
+			sx0, sx1 := x*w/dw, max((x+1)*w/dw, x*w/dw+1)
+
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				off := sy*rgba.Stride + sx0*4
+				for sx := sx0; sx < sx1; sx++ {
+					r += uint64(rgba.Pix[off])
+					g += uint64(rgba.Pix[off+1])
+					bl += uint64(rgba.Pix[off+2])
+					a += uint64(rgba.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			d := y*dst.Stride + x*4
+			dst.Pix[d] = uint8(r / n)
+			dst.Pix[d+1] = uint8(g / n)
+			dst.Pix[d+2] = uint8(bl / n)
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	if rgba, ok := src.(*image.RGBA); ok && b.Min == (image.Point{}) {
+		return rgba
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	return dst
+}
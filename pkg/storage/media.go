@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+// Config — выбор и настройки хранилища медиафайлов.
+type Config struct {
+	Driver   string // local / s3
+	LocalDir string
+	BaseURL  string
+	S3       S3Config
+}
+
+// NewMediaStorage создаёт хранилище по Driver. Для local возвращает
+// ещё и хендлер раздачи файлов (монтируется роутером на /media/).
+func NewMediaStorage(cfg Config) (MediaStorage, http.Handler, error) {
+	switch cfg.Driver {
+	case "", "local":
+		ls, err := NewLocalStorage(cfg.LocalDir, cfg.BaseURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ls, http.FileServer(filesOnly{http.Dir(ls.Root())}), nil
+	case "s3":
+		if cfg.S3.Endpoint == "" || cfg.S3.Bucket == "" {
+			return nil, nil, fmt.Errorf("media.s3.endpoint and media.s3.bucket are required")
+		}
+		return NewS3Storage(cfg.S3), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown media.driver: %s", cfg.Driver)
+	}
+}
+
+// filesOnly отдаёт только файлы: каталоги для FileServer «не существуют»,
+// иначе он выдаёт их листинг (а с ним — ключи всех фото).
+type filesOnly struct{ fs http.FileSystem }
+
+func (f filesOnly) Open(name string) (http.File, error) {
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	st, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if st.IsDir() {
+		_ = file.Close()
+		return nil, fs.ErrNotExist
+	}
+	return file, nil
+}
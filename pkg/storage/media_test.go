@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMediaHandlerNoListing(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "ads", "1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ads", "1", "thumb.jpg"), []byte("jpeg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, h, err := NewMediaStorage(Config{Driver: "local", LocalDir: dir, BaseURL: "/media"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/ads/1/thumb.jpg", want: http.StatusOK},
+		{path: "/", want: http.StatusNotFound},
+		{path: "/ads/", want: http.StatusNotFound},
+		{path: "/ads/1/", want: http.StatusNotFound},
+		{path: "/ads/2/thumb.jpg", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d (body %q)", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewMediaStorageConfig(t *testing.T) {
+	if _, _, err := NewMediaStorage(Config{Driver: "s3", S3: S3Config{Endpoint: "http://minio:9000"}}); err == nil {
+		t.Fatal("expected error without bucket")
+	}
+	if _, _, err := NewMediaStorage(Config{Driver: "ftp"}); err == nil {
+		t.Fatal("expected error for unknown driver")
+	}
+	ms, h, err := NewMediaStorage(Config{Driver: "s3", S3: S3Config{Endpoint: "http://minio:9000", Bucket: "media"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ms.(*S3Storage); !ok || h != nil {
+		t.Fatalf("s3 driver: storage %T, handler %v", ms, h)
+	}
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "ru-central1"
+)
+
+type s3Call struct {
+	method      string
+	path        string
+	body        string
+	contentType string
+	authErr     string
+}
+
+// verifySigV4 пересчитывает подпись запроса на стороне «сервера» по полученным
+// заголовкам; пустая строка — подпись верна.
+func verifySigV4(r *http.Request, body []byte) string {
+	amzDate := r.Header.Get("X-Amz-Date")
+	if _, err := time.Parse("20060102T150405Z", amzDate); err != nil {
+		return "bad X-Amz-Date: " + amzDate
+	}
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	if got := r.Header.Get("X-Amz-Content-Sha256"); got != payloadHash {
+		return "X-Amz-Content-Sha256 = " + got
+	}
+
+	scope := amzDate[:8] + "/" + testRegion + "/s3/aws4_request"
+	canonical := r.Method + "\n" + r.URL.EscapedPath() + "\n\n" +
+		"host:" + r.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n\n" +
+		"host;x-amz-content-sha256;x-amz-date\n" + payloadHash
+	ch := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(ch[:])
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{amzDate[:8], testRegion, "s3", "aws4_request"} {
+		m := hmac.New(sha256.New, key)
+		m.Write([]byte(part))
+		key = m.Sum(nil)
+	}
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(toSign))
+
+	want := "AWS4-HMAC-SHA256 Credential=" + testAccessKey + "/" + scope +
+		", SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=" + hex.EncodeToString(m.Sum(nil))
+	if got := r.Header.Get("Authorization"); got != want {
+		return "Authorization = " + got + ", want " + want
+	}
+	return ""
+}
+
+func newS3Server(t *testing.T, status int) (*S3Storage, *[]s3Call) {
+	t.Helper()
+	var calls []s3Call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, s3Call{
+			method:      r.Method,
+			path:        r.URL.EscapedPath(),
+			body:        string(body),
+			contentType: r.Header.Get("Content-Type"),
+			authErr:     verifySigV4(r, body),
+		})
+		w.WriteHeader(status)
+		if status >= 400 {
+			_, _ = w.Write([]byte("<Error><Code>Denied</Code></Error>"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return NewS3Storage(S3Config{
+		Endpoint:  srv.URL + "/",
+		Region:    testRegion,
+		Bucket:    "media",
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+	}), &calls
+}
+
+func TestS3Put(t *testing.T) {
+	s, calls := newS3Server(t, http.StatusOK)
+	if err := s.Put(context.Background(), "ads/42/фото 1+2.jpg", strings.NewReader("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if len(*calls) != 1 {
+		t.Fatalf("calls = %d", len(*calls))
+	}
+	c := (*calls)[0]
+	if c.method != http.MethodPut || c.body != "jpeg" || c.contentType != "image/jpeg" {
+		t.Fatalf("call = %+v", c)
+	}
+	if want := "/media/ads/42/%D1%84%D0%BE%D1%82%D0%BE%201%2B2.jpg"; c.path != want {
+		t.Fatalf("path = %s, want %s", c.path, want)
+	}
+	if c.authErr != "" {
+		t.Fatal(c.authErr)
+	}
+}
+
+func TestS3Delete(t *testing.T) {
+	s, calls := newS3Server(t, http.StatusNoContent)
+	if err := s.Delete(context.Background(), "ads/42/thumb.jpg"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	c := (*calls)[0]
+	if c.method != http.MethodDelete || c.path != "/media/ads/42/thumb.jpg" || c.body != "" {
+		t.Fatalf("call = %+v", c)
+	}
+	if c.authErr != "" {
+		t.Fatal(c.authErr)
+	}
+}
+
+func TestS3Errors(t *testing.T) {
+	s, _ := newS3Server(t, http.StatusNotFound)
+	if err := s.Delete(context.Background(), "ads/1/x.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("404: err = %v, want ErrNotFound", err)
+	}
+
+	s, _ = newS3Server(t, http.StatusForbidden)
+	err := s.Put(context.Background(), "ads/1/x.jpg", strings.NewReader("x"), "")
+	if err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "Denied") {
+		t.Fatalf("403: err = %v", err)
+	}
+}
+
+func TestS3URL(t *testing.T) {
+	tests := []struct {
+		cfg  S3Config
+		key  string
+		want string
+	}{
+		{cfg: S3Config{Endpoint: "http://minio:9000/", Bucket: "media"}, key: "ads/1/a b.jpg", want: "http://minio:9000/media/ads/1/a%20b.jpg"},
+		{cfg: S3Config{Endpoint: "http://minio:9000", Bucket: "media", PublicURL: "https://cdn.example.com/"}, key: "ads/1/~x_y-z.jpg", want: "https://cdn.example.com/ads/1/~x_y-z.jpg"},
+	}
+	for _, tt := range tests {
+		if got := NewS3Storage(tt.cfg).URL(tt.key); got != tt.want {
+			t.Errorf("URL(%q) = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("object not found")
+
+// MediaStorage — хранилище пользовательских файлов (фото объявлений, осмотров).
+// Ключ — относительный путь вида "ads/42/abc/thumb.jpg".
+type MediaStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL — публичный адрес объекта для клиента.
+	URL(key string) string
+}
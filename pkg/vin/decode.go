@@ -0,0 +1,191 @@
+package vin
+
+import (
+	"strings"
+	"time"
+)
+
+// Info — расшифровка VIN.
+type Info struct {
+	VIN          string   `json:"vin"`
+	WMI          string   `json:"wmi"`
+	VDS          string   `json:"vds"`
+	VIS          string   `json:"vis"`
+	Region       string   `json:"region"`
+	Country      string   `json:"country"`
+	Manufacturer string   `json:"manufacturer"`
+	Makes        []string `json:"makes"` // марки, выпускаемые под этим WMI
+	// ModelYear — наиболее вероятный модельный год; CandidateYears — все
+	// годы, которым соответствует символ 10 (цикл 30 лет).
+	ModelYear          int   `json:"model_year"`
+	CandidateYears     []int `json:"candidate_years"`
+	CheckDigitValid    bool  `json:"check_digit_valid"`
+	CheckDigitRequired bool  `json:"check_digit_required"`
+	// ModelYearCoded — позиция 10 обязана кодировать модельный год;
+	// иначе ModelYear лишь предположение.
+	ModelYearCoded bool `json:"model_year_coded"`
+}
+
+// Decode разбирает нормализованный VIN; структура должна быть валидной,
+// контрольная цифра только вычисляется (см. CheckDigitRequired).
+func Decode(v string) (*Info, error) {
+	if err := ValidateStructure(v); err != nil {
+		return nil, err
+	}
+	d, _ := CheckDigit(v)
+
+	info := &Info{
+		VIN:                v,
+		WMI:                v[:3],
+		VDS:                v[3:9],
+		VIS:                v[9:],
+		Region:             region(v[0]),
+		Country:            country(v[:2]),
+		CheckDigitValid:    v[8] == d,
+		CheckDigitRequired: CheckDigitRequired(v),
+		ModelYearCoded:     ModelYearCoded(v),
+	}
+	if m, ok := lookupWMI(v[:3]); ok {
+		info.Manufacturer = m.name
+		info.Makes = m.makes
+	}
+	info.CandidateYears = candidateYears(v[9])
+	info.ModelYear = pickModelYear(v, info.CandidateYears, time.Now().Year()+1)
+	return info, nil
+}
+
+// CheckDigitRequired — для Северной Америки и Китая контрольная цифра
+// обязательна; у остальных производителей позиция 9 может быть любой.
+func CheckDigitRequired(v string) bool {
+	if v == "" {
+		return false
+	}
+	c := v[0]
+	return (c >= '1' && c <= '5') || c == 'L'
+}
+
+// ModelYearCoded — модельный год в позиции 10 обязателен в тех же регионах,
+// что и контрольная цифра; европейские и японские производители ставят туда
+// что угодно.
+func ModelYearCoded(v string) bool {
+	return CheckDigitRequired(v)
+}
+
+// yearCodes — символы позиции 10 по порядку начиная с 1980 (без I O Q U Z 0).
+const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+func candidateYears(c byte) []int {
+	i := strings.IndexByte(yearCodes, c)
+	if i < 0 {
+		return nil
+	}
+	return []int{1980 + i, 2010 + i}
+}
+
+// pickModelYear: для североамериканских VIN позиция 7 различает циклы
+// (цифра — 1980–2009, буква — 2010–2039); иначе берём самый поздний год,
+// не превышающий maxYear.
+func pickModelYear(v string, years []int, maxYear int) int {
+	if len(years) == 0 {
+		return 0
+	}
+	if v[0] >= '1' && v[0] <= '5' {
+		if v[6] >= '0' && v[6] <= '9' {
+			return years[0]
+		}
+		return years[1]
+	}
+	best := 0
+	for _, y := range years {
+		if y <= maxYear && y > best {
+			best = y
+		}
+	}
+	return best
+}
+
+func region(c byte) string {
+	switch {
+	case c >= 'A' && c <= 'H':
+		return "Africa"
+	case c >= 'J' && c <= 'R':
+		return "Asia"
+	case c >= 'S' && c <= 'Z':
+		return "Europe"
+	case c >= '1' && c <= '5':
+		return "North America"
+	case c == '6' || c == '7':
+		return "Oceania"
+	case c == '8' || c == '9' || c == '0':
+		return "South America"
+	}
+	return ""
+}
+
+// isoOrder — порядок символов в диапазонах ISO 3780.
+const isoOrder = "ABCDEFGHJKLMNPRSTUVWXYZ1234567890"
+
+type countryRange struct {
+	first   byte
+	from    byte
+	to      byte
+	country string
+}
+
+var countries = []countryRange{
+	{'A', 'A', 'H', "South Africa"},
+	{'J', 'A', '0', "Japan"},
+	{'K', 'L', 'R', "South Korea"},
+	{'K', 'F', 'K', "Israel"},
+	{'L', 'A', '0', "China"},
+	{'M', 'A', 'E', "India"},
+	{'M', 'F', 'K', "Indonesia"},
+	{'M', 'L', 'R', "Thailand"},
+	{'N', 'L', 'R', "Turkey"},
+	{'P', 'L', 'R', "Malaysia"},
+	{'R', 'F', 'K', "Taiwan"},
+	{'S', 'A', 'M', "United Kingdom"},
+	{'S', 'N', 'T', "Germany"},
+	{'S', 'U', 'Z', "Poland"},
+	{'T', 'A', 'H', "Switzerland"},
+	{'T', 'J', 'P', "Czech Republic"},
+	{'T', 'R', 'V', "Hungary"},
+	{'T', 'W', '1', "Portugal"},
+	{'U', 'U', '7', "Romania"},
+	{'V', 'A', 'E', "Austria"},
+	{'V', 'F', 'R', "France"},
+	{'V', 'S', 'W', "Spain"},
+	{'W', 'A', '0', "Germany"},
+	{'X', 'L', 'R', "Netherlands"},
+	{'X', 'S', 'W', "Russia"}, // бывший СССР (АвтоВАЗ и др.)
+	{'X', '3', '0', "Russia"},
+	{'Y', 'A', 'E', "Belgium"},
+	{'Y', 'F', 'K', "Finland"},
+	{'Y', 'S', 'W', "Sweden"},
+	{'Z', 'A', 'R', "Italy"},
+	{'1', 'A', '0', "United States"},
+	{'4', 'A', '0', "United States"},
+	{'5', 'A', '0', "United States"},
+	{'2', 'A', '0', "Canada"},
+	{'3', 'A', 'W', "Mexico"},
+	{'6', 'A', 'W', "Australia"},
+	{'7', 'A', 'E', "New Zealand"},
+	{'8', 'A', 'E', "Argentina"},
+	{'9', 'A', 'E', "Brazil"},
+	{'9', '3', '9', "Brazil"},
+}
+
+func country(prefix string) string {
+	pos := strings.IndexByte(isoOrder, prefix[1])
+	for _, r := range countries {
+		if r.first != prefix[0] {
+			continue
+		}
+		from := strings.IndexByte(isoOrder, r.from)
+		to := strings.IndexByte(isoOrder, r.to)
+		if pos >= from && pos <= to {
+			return r.country
+		}
+	}
+	return ""
+}
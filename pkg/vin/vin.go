@@ -0,0 +1,85 @@
+package vin
+
+import (
+	"errors"
+	"strings"
+)
+
+const Length = 17
+
+var (
+	ErrLength     = errors.New("vin must be 17 characters")
+	ErrCharacters = errors.New("vin contains invalid characters (I, O, Q are not allowed)")
+	ErrCheckDigit = errors.New("vin check digit mismatch")
+)
+
+// Normalize убирает пробелы/дефисы и приводит к верхнему регистру.
+func Normalize(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// transliteration — числовые значения символов по ISO 3779 / FMVSS 565.
+func transliterate(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1, true
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1, true
+	case c == 'P':
+		return 7, true
+	case c == 'R':
+		return 9, true
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2, true
+	}
+	return 0, false
+}
+
+var weights = [Length]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// ValidateStructure проверяет длину и алфавит (без контрольной цифры).
+func ValidateStructure(v string) error {
+	if len(v) != Length {
+		return ErrLength
+	}
+	for i := 0; i < Length; i++ {
+		if _, ok := transliterate(v[i]); !ok {
+			return ErrCharacters
+		}
+	}
+	return nil
+}
+
+// CheckDigit вычисляет контрольный символ (позиция 9) для нормализованного VIN.
+func CheckDigit(v string) (byte, error) {
+	if err := ValidateStructure(v); err != nil {
+		return 0, err
+	}
+	sum := 0
+	for i := 0; i < Length; i++ {
+		n, _ := transliterate(v[i])
+		sum += n * weights[i]
+	}
+	r := sum % 11
+	if r == 10 {
+		return 'X', nil
+	}
+	return byte('0' + r), nil
+}
+
+// Validate — структура плюс контрольная цифра. Контрольная цифра обязательна
+// для Северной Америки и Китая; у европейских и японских авто она часто не
+// соблюдается, поэтому вызывающий код может проверять их раздельно.
+func Validate(v string) error {
+	d, err := CheckDigit(v)
+	if err != nil {
+		return err
+	}
+	if v[8] != d {
+		return ErrCheckDigit
+	}
+	return nil
+}
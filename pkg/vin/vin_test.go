@@ -0,0 +1,59 @@
+package vin
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		vin  string
+		want error
+	}{
+		{"1HGCM82633A004352", nil},
+		{"1HGCM82643A004352", ErrCheckDigit},
+		{"1HGCM82633A00435", ErrLength},
+		{"1HGCM82633A00435O", ErrCharacters},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.vin); err != tt.want {
+			t.Errorf("Validate(%s) = %v, want %v", tt.vin, err, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize(" 1hg-cm826 33a004352 "); got != "1HGCM82633A004352" {
+		t.Errorf("Normalize = %q", got)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	info, err := Decode("1HGCM82633A004352")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Country != "United States" || len(info.Makes) == 0 || info.Makes[0] != "Honda" {
+		t.Errorf("info = %+v", info)
+	}
+	// позиция 7 — цифра: цикл 1980–2009
+	if info.ModelYear != 2003 || !info.ModelYearCoded || !info.CheckDigitValid || !info.CheckDigitRequired {
+		t.Errorf("model year/check digit = %+v", info)
+	}
+
+	eu, err := Decode("WBAPH5C55BA123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eu.CheckDigitRequired || eu.ModelYearCoded || eu.Country != "Germany" {
+		t.Errorf("eu info = %+v", eu)
+	}
+}
+
+func TestCandidateYearsCycle(t *testing.T) {
+	// символ года повторяется каждые 30 лет
+	got := candidateYears('A')
+	if len(got) != 2 || got[0] != 1980 || got[1] != 2010 {
+		t.Errorf("candidateYears(A) = %v", got)
+	}
+	if candidateYears('U') != nil {
+		t.Error("candidateYears(U): U is not a year code")
+	}
+}
@@ -0,0 +1,100 @@
+package vin
+
+type manufacturer struct {
+	name  string
+	makes []string
+}
+
+// wmiTable — производители, частые на рынке; полные 3-символьные WMI
+// проверяются раньше 2-символьных префиксов.
+var wmiTable = map[string]manufacturer{
+	// Japan
+	"JT":  {"Toyota Motor Corporation", []string{"Toyota", "Lexus"}},
+	"JTH": {"Toyota Motor Corporation", []string{"Lexus"}},
+	"JTJ": {"Toyota Motor Corporation", []string{"Lexus"}},
+	"JHM": {"Honda Motor Co.", []string{"Honda"}},
+	"JHL": {"Honda Motor Co.", []string{"Honda"}},
+	"JH4": {"Honda Motor Co.", []string{"Acura"}},
+	"JN":  {"Nissan Motor Co.", []string{"Nissan", "Infiniti"}},
+	"JNK": {"Nissan Motor Co.", []string{"Infiniti"}},
+	"JM":  {"Mazda Motor Corporation", []string{"Mazda"}},
+	"JA":  {"Mitsubishi Motors", []string{"Mitsubishi"}},
+	"JMB": {"Mitsubishi Motors", []string{"Mitsubishi"}},
+	"JF":  {"Subaru Corporation", []string{"Subaru"}},
+	"JS":  {"Suzuki Motor Corporation", []string{"Suzuki"}},
+	// Korea
+	"KMH": {"Hyundai Motor Company", []string{"Hyundai", "Genesis"}},
+	"KNA": {"Kia Corporation", []string{"Kia"}},
+	"KND": {"Kia Corporation", []string{"Kia"}},
+	"KL":  {"GM Korea", []string{"Chevrolet", "Daewoo"}},
+	"KPT": {"SsangYong Motor", []string{"SsangYong"}},
+	// China
+	"LVV": {"Chery Automobile", []string{"Chery"}},
+	"LGX": {"BYD Auto", []string{"BYD"}},
+	"LGW": {"Great Wall Motor", []string{"Haval", "Great Wall"}},
+	"L6T": {"Geely Automobile", []string{"Geely"}},
+	"LS5": {"Changan Automobile", []string{"Changan"}},
+	"LFV": {"FAW-Volkswagen", []string{"Volkswagen", "Audi"}},
+	"LVS": {"Changan Ford", []string{"Ford"}},
+	"LRW": {"Tesla Shanghai", []string{"Tesla"}},
+	// Germany
+	"WBA": {"BMW AG", []string{"BMW"}},
+	"WBS": {"BMW M GmbH", []string{"BMW"}},
+	"WBY": {"BMW AG", []string{"BMW"}},
+	"WDB": {"Mercedes-Benz", []string{"Mercedes-Benz"}},
+	"WDD": {"Mercedes-Benz", []string{"Mercedes-Benz"}},
+	"WDC": {"Mercedes-Benz", []string{"Mercedes-Benz"}},
+	"W1K": {"Mercedes-Benz", []string{"Mercedes-Benz"}},
+	"W1N": {"Mercedes-Benz", []string{"Mercedes-Benz"}},
+	"WVW": {"Volkswagen AG", []string{"Volkswagen"}},
+	"WVG": {"Volkswagen AG", []string{"Volkswagen"}},
+	"WV1": {"Volkswagen Commercial Vehicles", []string{"Volkswagen"}},
+	"WV2": {"Volkswagen Commercial Vehicles", []string{"Volkswagen"}},
+	"WAU": {"Audi AG", []string{"Audi"}},
+	"WA1": {"Audi AG", []string{"Audi"}},
+	"WP0": {"Porsche AG", []string{"Porsche"}},
+	"WP1": {"Porsche AG", []string{"Porsche"}},
+	"W0L": {"Opel Automobile", []string{"Opel"}},
+	// Europe
+	"VF1": {"Renault", []string{"Renault"}},
+	"VF3": {"Peugeot", []string{"Peugeot"}},
+	"VF7": {"Citroën", []string{"Citroen"}},
+	"TMB": {"Škoda Auto", []string{"Skoda"}},
+	"YV1": {"Volvo Cars", []string{"Volvo"}},
+	"SAL": {"Jaguar Land Rover", []string{"Land Rover"}},
+	"SAJ": {"Jaguar Land Rover", []string{"Jaguar"}},
+	"ZFA": {"Fiat", []string{"Fiat"}},
+	"XTA": {"AvtoVAZ", []string{"Lada", "ВАЗ"}},
+	"X7L": {"Renault Russia", []string{"Renault"}},
+	"Z94": {"Hyundai Motor Manufacturing Rus", []string{"Hyundai"}},
+	// North America
+	"1FA": {"Ford Motor Company", []string{"Ford"}},
+	"1FM": {"Ford Motor Company", []string{"Ford"}},
+	"1FT": {"Ford Motor Company", []string{"Ford"}},
+	"1G1": {"General Motors", []string{"Chevrolet"}},
+	"1GC": {"General Motors", []string{"Chevrolet"}},
+	"1GN": {"General Motors", []string{"Chevrolet"}},
+	"1C4": {"FCA US", []string{"Jeep", "Chrysler", "Dodge"}},
+	"1J4": {"FCA US", []string{"Jeep"}},
+	"2T1": {"Toyota Canada", []string{"Toyota"}},
+	"4T1": {"Toyota Motor Manufacturing", []string{"Toyota"}},
+	"4T3": {"Toyota Motor Manufacturing", []string{"Toyota"}},
+	"5YJ": {"Tesla", []string{"Tesla"}},
+	"1HG": {"Honda of America", []string{"Honda"}},
+	"2HG": {"Honda Canada", []string{"Honda"}},
+	"5J6": {"Honda of America", []string{"Honda"}},
+	"5N1": {"Nissan North America", []string{"Nissan"}},
+	"1N4": {"Nissan North America", []string{"Nissan"}},
+	"5XY": {"Kia/Hyundai Georgia", []string{"Kia", "Hyundai"}},
+	"5NP": {"Hyundai Motor Manufacturing Alabama", []string{"Hyundai"}},
+	"4S3": {"Subaru of Indiana", []string{"Subaru"}},
+	"4S4": {"Subaru of Indiana", []string{"Subaru"}},
+}
+
+func lookupWMI(wmi string) (manufacturer, bool) {
+	if m, ok := wmiTable[wmi]; ok {
+		return m, true
+	}
+	m, ok := wmiTable[wmi[:2]]
+	return m, ok
+}